@@ -24,6 +24,16 @@ type Config struct {
 
 	// Define targets for composite buildpacks
 	Targets []dist.Target `toml:"targets"`
+
+	// Build prepares the contents of each target directory before packaging
+	Build BuildConfig `toml:"build"`
+}
+
+// BuildConfig defines a host command that is run once per target to prepare the target directory of a buildpack,
+// for example to cross-compile its binaries.
+type BuildConfig struct {
+	Command string            `toml:"command"`
+	Env     map[string]string `toml:"env"`
 }
 
 func DefaultConfig() Config {
@@ -93,6 +103,10 @@ func (r *ConfigReader) Read(path string) (Config, error) {
 			style.Symbol("platform.os"), style.Symbol("linux"), style.Symbol("windows"), style.Symbol(packageConfig.Platform.OS))
 	}
 
+	if packageConfig.Build.Command == "" && len(packageConfig.Build.Env) > 0 {
+		return packageConfig, errors.Errorf("missing %s configuration", style.Symbol("build.command"))
+	}
+
 	configDir, err := filepath.Abs(filepath.Dir(path))
 	if err != nil {
 		return packageConfig, err
@@ -221,6 +221,33 @@ func testBuildpackageConfigReader(t *testing.T, when spec.G, it spec.S) {
 			h.AssertNotNil(t, err)
 			h.AssertError(t, err, "missing 'buildpack.uri' configuration")
 		})
+
+		it("returns the build configuration", func() {
+			configFile := filepath.Join(tmpDir, "package.toml")
+
+			err := os.WriteFile(configFile, []byte(buildPackageToml), os.ModePerm)
+			h.AssertNil(t, err)
+
+			packageConfigReader := buildpackage.NewConfigReader()
+
+			config, err := packageConfigReader.Read(configFile)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, config.Build.Command, "go build -o {{.TargetDir}}/bin/build ./cmd/build")
+			h.AssertEq(t, config.Build.Env, map[string]string{"CGO_ENABLED": "0"})
+		})
+
+		it("returns an error when build env is configured without a command", func() {
+			configFile := filepath.Join(tmpDir, "package.toml")
+
+			err := os.WriteFile(configFile, []byte(missingBuildCommandPackageToml), os.ModePerm)
+			h.AssertNil(t, err)
+
+			packageConfigReader := buildpackage.NewConfigReader()
+
+			_, err = packageConfigReader.Read(configFile)
+			h.AssertError(t, err, "missing 'build.command' configuration")
+		})
 	})
 }
 
@@ -306,3 +333,22 @@ const missingBuildpackPackageToml = `
 [[dependencies]]
 uri = "bp/b"
 `
+
+const buildPackageToml = `
+[buildpack]
+uri = "https://example.com/bp/a.tgz"
+
+[build]
+command = "go build -o {{.TargetDir}}/bin/build ./cmd/build"
+
+[build.env]
+CGO_ENABLED = "0"
+`
+
+const missingBuildCommandPackageToml = `
+[buildpack]
+uri = "https://example.com/bp/a.tgz"
+
+[build.env]
+CGO_ENABLED = "0"
+`
@@ -1,6 +1,9 @@
 package cmd
 
 import (
+	"os"
+	"strings"
+
 	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -10,8 +13,10 @@ import (
 	"github.com/buildpacks/pack/internal/commands"
 	"github.com/buildpacks/pack/internal/config"
 	imagewriter "github.com/buildpacks/pack/internal/inspectimage/writer"
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/internal/term"
 	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
@@ -28,10 +33,16 @@ type ConfigurableLogger interface {
 //nolint:staticcheck
 func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	cobra.EnableCommandSorting = false
-	cfg, cfgPath, err := initConfig()
+	userCfg, cfgPath, err := initConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	layered, err := layerConfig(logger, userCfg, cfgPath, appPath(os.Args[1:]))
 	if err != nil {
 		return nil, err
 	}
+	cfg := layered.Config
 
 	packClient, err := initClient(logger, cfg)
 	if err != nil {
@@ -79,7 +90,7 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd.AddCommand(commands.NewBuilderCommand(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.NewBuildpackCommand(logger, cfg, packClient, buildpackage.NewConfigReader()))
 	rootCmd.AddCommand(commands.NewExtensionCommand(logger, cfg, packClient, buildpackage.NewConfigReader()))
-	rootCmd.AddCommand(commands.NewConfigCommand(logger, cfg, cfgPath, packClient))
+	rootCmd.AddCommand(commands.NewConfigCommand(logger, userCfg, cfgPath, packClient, layered))
 	rootCmd.AddCommand(commands.InspectImage(logger, imagewriter.NewFactory(), cfg, packClient))
 	rootCmd.AddCommand(commands.NewStackCommand(logger))
 	rootCmd.AddCommand(commands.Rebase(logger, cfg, packClient))
@@ -88,23 +99,24 @@ func NewPackCommand(logger ConfigurableLogger) (*cobra.Command, error) {
 	rootCmd.AddCommand(commands.InspectBuildpack(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.InspectBuilder(logger, cfg, packClient, builderwriter.NewFactory()))
 
-	rootCmd.AddCommand(commands.SetDefaultBuilder(logger, cfg, cfgPath, packClient))
-	rootCmd.AddCommand(commands.SetRunImagesMirrors(logger, cfg, cfgPath))
+	rootCmd.AddCommand(commands.SetDefaultBuilder(logger, userCfg, cfgPath, packClient))
+	rootCmd.AddCommand(commands.SetRunImagesMirrors(logger, userCfg, cfgPath))
 	rootCmd.AddCommand(commands.SuggestBuilders(logger, packClient))
-	rootCmd.AddCommand(commands.TrustBuilder(logger, cfg, cfgPath))
-	rootCmd.AddCommand(commands.UntrustBuilder(logger, cfg, cfgPath))
+	rootCmd.AddCommand(commands.TrustBuilder(logger, userCfg, cfgPath))
+	rootCmd.AddCommand(commands.UntrustBuilder(logger, userCfg, cfgPath))
 	rootCmd.AddCommand(commands.ListTrustedBuilders(logger, cfg))
 	rootCmd.AddCommand(commands.CreateBuilder(logger, cfg, packClient))
 	rootCmd.AddCommand(commands.PackageBuildpack(logger, cfg, packClient, buildpackage.NewConfigReader()))
 
 	if cfg.Experimental {
-		rootCmd.AddCommand(commands.AddBuildpackRegistry(logger, cfg, cfgPath))
+		rootCmd.AddCommand(commands.AddBuildpackRegistry(logger, userCfg, cfgPath))
 		rootCmd.AddCommand(commands.ListBuildpackRegistries(logger, cfg))
 		rootCmd.AddCommand(commands.RegisterBuildpack(logger, cfg, packClient))
-		rootCmd.AddCommand(commands.SetDefaultRegistry(logger, cfg, cfgPath))
-		rootCmd.AddCommand(commands.RemoveRegistry(logger, cfg, cfgPath))
+		rootCmd.AddCommand(commands.SetDefaultRegistry(logger, userCfg, cfgPath))
+		rootCmd.AddCommand(commands.RemoveRegistry(logger, userCfg, cfgPath))
 		rootCmd.AddCommand(commands.YankBuildpack(logger, cfg, packClient))
 		rootCmd.AddCommand(commands.NewManifestCommand(logger, packClient))
+		rootCmd.AddCommand(commands.Detect(logger, cfg, packClient))
 	}
 
 	packHome, err := config.PackHome()
@@ -137,6 +149,87 @@ func initConfig() (config.Config, string, error) {
 	return cfg, path, nil
 }
 
+// layerConfig layers the project config of projectDir, or of the working directory when it is empty, and then the
+// config environment variables over the user config.
+func layerConfig(logger logging.Logger, userCfg config.Config, cfgPath, projectDir string) (config.Layered, error) {
+	layers := []config.Layer{{Origin: config.Origin{Source: config.SourceUser, Path: cfgPath}, Config: userCfg}}
+
+	if projectDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return config.Layered{}, errors.Wrap(err, "getting working directory")
+		}
+		projectDir = wd
+	}
+	projectPath, err := config.FindProjectConfig(projectDir)
+	if err != nil {
+		return config.Layered{}, errors.Wrap(err, "finding project config")
+	}
+
+	if projectPath != "" && !sameFile(projectPath, cfgPath) {
+		projectCfg, undecoded, err := config.ReadProject(projectPath)
+		if err != nil {
+			return config.Layered{}, err
+		}
+		if len(undecoded) > 0 {
+			logger.Warnf("Ignoring %s in project config %s", config.FormatUndecodedKeys(undecoded), projectPath)
+		}
+		if sources := config.ImageSources(projectCfg); len(sources) > 0 {
+			var keys []string
+			for _, source := range sources {
+				keys = append(keys, style.Symbol(source))
+			}
+			logger.Warnf("Project config %s changes where images are pulled from with %s", projectPath, strings.Join(keys, ", "))
+		}
+		layers = append(layers, config.Layer{Origin: config.Origin{Source: config.SourceProject, Path: projectPath}, Config: projectCfg})
+	}
+
+	envLayers, err := config.ReadEnv(os.LookupEnv)
+	if err != nil {
+		return config.Layered{}, errors.Wrap(err, "reading config environment variables")
+	}
+
+	return config.Merge(append(layers, envLayers...)...), nil
+}
+
+// appPath returns the app path given to the commands building an app, which the config is layered before parsing
+func appPath(args []string) string {
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		args = args[1:]
+	}
+	if len(args) == 0 || (args[0] != "build" && args[0] != "detect") {
+		return ""
+	}
+
+	for i := 1; i < len(args); i++ {
+		switch arg := args[i]; {
+		case arg == "--":
+			return ""
+		case arg == "--path" || arg == "-p":
+			if i+1 < len(args) {
+				return args[i+1]
+			}
+		case strings.HasPrefix(arg, "--path="):
+			return strings.TrimPrefix(arg, "--path=")
+		case strings.HasPrefix(arg, "-p"):
+			return strings.TrimPrefix(strings.TrimPrefix(arg, "-p"), "=")
+		}
+	}
+	return ""
+}
+
+func sameFile(a, b string) bool {
+	aInfo, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	bInfo, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(aInfo, bInfo)
+}
+
 func initClient(logger logging.Logger, cfg config.Config) (*client.Client, error) {
 	if err := client.ProcessDockerContext(logger); err != nil {
 		return nil, err
@@ -146,5 +239,19 @@ func initClient(logger logging.Logger, cfg config.Config) (*client.Client, error
 	if err != nil {
 		return nil, err
 	}
-	return client.NewClient(client.WithLogger(logger), client.WithExperimental(cfg.Experimental), client.WithRegistryMirrors(cfg.RegistryMirrors), client.WithDockerClient(dc))
+	return client.NewClient(
+		client.WithLogger(logger),
+		client.WithExperimental(cfg.Experimental),
+		client.WithRegistryMirrors(cfg.RegistryMirrors),
+		client.WithRegistryMirrorRules(registryMirrorRules(cfg)),
+		client.WithDockerClient(dc),
+	)
+}
+
+func registryMirrorRules(cfg config.Config) []image.RegistryMirrorRule {
+	var rules []image.RegistryMirrorRule
+	for _, rule := range cfg.RegistryMirrorRules {
+		rules = append(rules, image.RegistryMirrorRule{Match: rule.Match, Mirrors: rule.Mirrors})
+	}
+	return rules
 }
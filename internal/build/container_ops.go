@@ -1,12 +1,14 @@
 package build
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"os"
 	"runtime"
+	"sort"
 
 	"github.com/BurntSushi/toml"
 	"github.com/buildpacks/lifecycle/platform/files"
@@ -257,6 +259,46 @@ func WriteRunToml(dstPath string, runImages []builder.RunImageMetadata, os strin
 	}
 }
 
+// WriteSecrets writes each secret as a file named after it in dstDir, readable only by the UID/GID-based user. The files
+// are written to the filesystem of the container, so they are never part of an image.
+func WriteSecrets(dstDir string, secrets map[string]string, uid, gid int, os string) ContainerOperation {
+	return func(ctrClient DockerClient, ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+		if os == "windows" {
+			return errors.New("secrets are not supported for Windows builds")
+		}
+
+		var names []string
+		for name := range secrets {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		buf := &bytes.Buffer{}
+		tw := tar.NewWriter(buf)
+		for _, name := range names {
+			if err := tw.WriteHeader(&tar.Header{
+				Typeflag: tar.TypeReg,
+				Name:     dstDir + "/" + name,
+				Mode:     0400,
+				Uid:      uid,
+				Gid:      gid,
+				Size:     int64(len(secrets[name])),
+				ModTime:  archive.NormalizedDateTime,
+			}); err != nil {
+				return errors.Wrapf(err, "writing secret %s", name)
+			}
+			if _, err := tw.Write([]byte(secrets[name])); err != nil {
+				return errors.Wrapf(err, "writing secret %s", name)
+			}
+		}
+		if err := tw.Close(); err != nil {
+			return err
+		}
+
+		return ctrClient.CopyToContainer(ctx, containerID, "/", buf, types.CopyToContainerOptions{})
+	}
+}
+
 func createReader(src, dst string, uid, gid int, includeRoot bool, fileFilter func(string) bool) (io.ReadCloser, error) {
 	fi, err := os.Stat(src)
 	if err != nil {
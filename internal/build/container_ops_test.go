@@ -545,6 +545,36 @@ drwxrwxrwx    2 123      456 (.*) some-vol
 		})
 	})
 
+	when("#WriteSecrets", func() {
+		it("writes a file readable only by the user for each secret", func() {
+			h.SkipIf(t, osType == "windows", "secrets are not supported for Windows builds")
+
+			ctx := context.Background()
+			ctr, err := createContainer(ctx, imageName, "/some-vol", osType,
+				"sh", "-c", "ls -ln /platform/env && cat /platform/env/SOME_SECRET")
+			h.AssertNil(t, err)
+			defer cleanupContainer(ctx, ctr.ID)
+
+			writeOp := build.WriteSecrets("/platform/env", map[string]string{"SOME_SECRET": "some-value", "OTHER_SECRET": "other-value"}, 123, 456, osType)
+
+			var outBuf, errBuf bytes.Buffer
+			h.AssertNil(t, writeOp(ctrClient, ctx, ctr.ID, &outBuf, &errBuf))
+
+			err = container.RunWithHandler(ctx, ctrClient, ctr.ID, container.DefaultHandler(&outBuf, &errBuf))
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, errBuf.String(), "")
+			h.AssertContainsMatch(t, outBuf.String(), `-r--------\s+1\s+123\s+456\s+11 .* OTHER_SECRET`)
+			h.AssertContainsMatch(t, outBuf.String(), `-r--------\s+1\s+123\s+456\s+10 .* SOME_SECRET`)
+			h.AssertContains(t, outBuf.String(), "some-value")
+		})
+
+		it("errors for Windows containers", func() {
+			writeOp := build.WriteSecrets(`c:\platform\env`, map[string]string{"SOME_SECRET": "some-value"}, 0, 0, "windows")
+			h.AssertError(t, writeOp(ctrClient, context.Background(), "some-container", nil, nil), "secrets are not supported for Windows builds")
+		})
+	})
+
 	when("#WriteProjectMetadata", func() {
 		it("writes file", func() {
 			containerDir := "/layers-vol"
@@ -165,6 +165,11 @@ func (l *LifecycleExecution) PrevImageName() string {
 
 func (l *LifecycleExecution) Run(ctx context.Context, phaseFactoryCreator PhaseFactoryCreator) error {
 	phaseFactory := phaseFactoryCreator(l)
+	if l.opts.DetectOnly {
+		l.logger.Info(style.Step("DETECTING"))
+		return l.Detect(ctx, phaseFactory)
+	}
+
 	var buildCache Cache
 	if l.opts.CacheImage != "" || (l.opts.Cache.Build.Format == cache.CacheImage) {
 		cacheImageName := l.opts.CacheImage
@@ -386,6 +391,7 @@ func (l *LifecycleExecution) Create(ctx context.Context, buildCache, launchCache
 		cacheBindOp,
 		WithContainerOperations(WriteProjectMetadata(l.mountPaths.projectPath(), l.opts.ProjectMetadata, l.os)),
 		WithContainerOperations(CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter)),
+		l.withSecrets(),
 		If(l.opts.SBOMDestinationDir != "", WithPostContainerRunOperations(
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyOutTo(l.mountPaths.sbomDir(), l.opts.SBOMDestinationDir))),
@@ -447,11 +453,15 @@ func (l *LifecycleExecution) Detect(ctx context.Context, phaseFactory PhaseFacto
 			EnsureVolumeAccess(l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, l.layersVolume, l.appVolume),
 			CopyDir(l.opts.AppPath, l.mountPaths.appDir(), l.opts.Builder.UID(), l.opts.Builder.GID(), l.os, true, l.opts.FileFilter),
 		),
+		l.withSecrets(),
 		WithFlags(flags...),
 		If(l.hasExtensions(), WithPostContainerRunOperations(
 			CopyOutToMaybe(filepath.Join(l.mountPaths.layersDir(), "analyzed.toml"), l.tmpDir))),
 		If(l.hasExtensions(), WithPostContainerRunOperations(
 			CopyOutToMaybe(filepath.Join(l.mountPaths.layersDir(), "generated"), l.tmpDir))),
+		If(l.opts.DetectOutputDir != "", WithPostContainerRunOperations(
+			CopyOutToMaybe(filepath.Join(l.mountPaths.layersDir(), "group.toml"), l.opts.DetectOutputDir),
+			CopyOutToMaybe(filepath.Join(l.mountPaths.layersDir(), "plan.toml"), l.opts.DetectOutputDir))),
 		envOp,
 	)
 
@@ -715,6 +725,7 @@ func (l *LifecycleExecution) Build(ctx context.Context, phaseFactory PhaseFactor
 		WithNetwork(l.opts.Network),
 		WithBinds(l.opts.Volumes...),
 		WithFlags(flags...),
+		l.withSecrets(),
 	)
 
 	build := phaseFactory.New(configProvider)
@@ -722,6 +733,12 @@ func (l *LifecycleExecution) Build(ctx context.Context, phaseFactory PhaseFactor
 	return build.Run(ctx)
 }
 
+// withSecrets writes the secrets to the platform env of a phase running buildpacks
+func (l *LifecycleExecution) withSecrets() PhaseConfigProviderOperation {
+	return If(len(l.opts.Secrets) > 0, WithContainerOperations(
+		WriteSecrets(l.mountPaths.platformEnvDir(), l.opts.Secrets, l.opts.Builder.UID(), l.opts.Builder.GID(), l.os)))
+}
+
 func (l *LifecycleExecution) ExtendBuild(ctx context.Context, kanikoCache Cache, phaseFactory PhaseFactory, experimental bool) error {
 	flags := []string{"-app", l.mountPaths.appDir()}
 
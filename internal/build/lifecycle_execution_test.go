@@ -511,6 +511,30 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 				}
 			})
 
+			when("detect only", func() {
+				it("only runs the detector", func() {
+					opts := build.LifecycleOptions{
+						RunImage:   "test",
+						Image:      imageName,
+						Builder:    fakeBuilder,
+						UseCreator: false,
+						DetectOnly: true,
+						Termui:     fakeTermui,
+					}
+
+					lifecycle, err := build.NewLifecycleExecution(logger, docker, "some-temp-dir", opts)
+					h.AssertNil(t, err)
+
+					err = lifecycle.Run(context.Background(), func(execution *build.LifecycleExecution) build.PhaseFactory {
+						return fakePhaseFactory
+					})
+					h.AssertNil(t, err)
+
+					h.AssertEq(t, len(fakePhaseFactory.NewCalledWithProvider), 1)
+					h.AssertEq(t, fakePhaseFactory.NewCalledWithProvider[0].Name(), "detector")
+				})
+			})
+
 			when("Run with workspace dir", func() {
 				it("succeeds", func() {
 					opts := build.LifecycleOptions{
@@ -866,6 +890,17 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, configProvider.HostConfig().NetworkMode, container.NetworkMode(providedNetworkMode))
 		})
 
+		when("there are secrets", func() {
+			lifecycleOps = append(lifecycleOps, func(options *build.LifecycleOptions) {
+				options.Secrets = map[string]string{"SOME_SECRET": "some-value"}
+			})
+
+			it("configures the phase to write them to the platform env", func() {
+				h.AssertEq(t, len(configProvider.ContainerOps()), 3)
+				h.AssertFunctionName(t, configProvider.ContainerOps()[2], "WriteSecrets")
+			})
+		})
+
 		when("clear cache", func() {
 			providedClearCache = true
 
@@ -1356,6 +1391,33 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 			h.AssertFunctionName(t, configProvider.ContainerOps()[1], "CopyDir")
 		})
 
+		when("there are secrets", func() {
+			lifecycleOps = append(lifecycleOps, func(options *build.LifecycleOptions) {
+				options.Secrets = map[string]string{"SOME_SECRET": "some-value"}
+			})
+
+			it("configures the phase to write them to the platform env", func() {
+				h.AssertEq(t, len(configProvider.ContainerOps()), 3)
+				h.AssertFunctionName(t, configProvider.ContainerOps()[2], "WriteSecrets")
+			})
+		})
+
+		it("doesn't copy out the detection results", func() {
+			h.AssertEq(t, len(configProvider.PostContainerRunOps()), 0)
+		})
+
+		when("detect output directory is provided", func() {
+			lifecycleOps = append(lifecycleOps, func(opts *build.LifecycleOptions) {
+				opts.DetectOutputDir = "some-output-dir"
+			})
+
+			it("copies out group.toml and plan.toml", func() {
+				h.AssertEq(t, len(configProvider.PostContainerRunOps()), 2)
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[0], "CopyOutMaybe")
+				h.AssertFunctionName(t, configProvider.PostContainerRunOps()[1], "CopyOutMaybe")
+			})
+		})
+
 		when("extensions", func() {
 			platformAPI = api.MustParse("0.10")
 
@@ -2056,6 +2118,21 @@ func testLifecycleExecution(t *testing.T, when spec.G, it spec.S) {
 		it("configures the phase with binds", func() {
 			h.AssertSliceContains(t, configProvider.HostConfig().Binds, providedVolumes...)
 		})
+
+		it("doesn't write secrets", func() {
+			h.AssertEq(t, len(configProvider.ContainerOps()), 0)
+		})
+
+		when("there are secrets", func() {
+			lifecycleOps = append(lifecycleOps, func(options *build.LifecycleOptions) {
+				options.Secrets = map[string]string{"SOME_SECRET": "some-value"}
+			})
+
+			it("configures the phase to write them to the platform env", func() {
+				h.AssertEq(t, len(configProvider.ContainerOps()), 1)
+				h.AssertFunctionName(t, configProvider.ContainerOps()[0], "WriteSecrets")
+			})
+		})
 	})
 
 	when("#ExtendBuild", func() {
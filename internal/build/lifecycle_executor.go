@@ -103,6 +103,9 @@ type LifecycleOptions struct {
 	SBOMDestinationDir              string
 	CreationTime                    *time.Time
 	Keychain                        authn.Keychain
+	Secrets                         map[string]string // written to the platform env of the phases running buildpacks, never to an image
+	DetectOnly                      bool              // if set, only the detect phase is run
+	DetectOutputDir                 string            // optional - if set, group.toml and plan.toml are copied here after detection
 }
 
 func NewLifecycleExecutor(logger logging.Logger, docker DockerClient) *LifecycleExecutor {
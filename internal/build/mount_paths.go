@@ -50,6 +50,10 @@ func (m mountPaths) reportPath() string {
 	return m.join(m.layersDir(), "report.toml")
 }
 
+func (m mountPaths) platformEnvDir() string {
+	return m.join(m.volume, "platform", "env")
+}
+
 func (m mountPaths) appDirName() string {
 	return m.workspace
 }
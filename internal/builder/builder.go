@@ -85,6 +85,7 @@ type Builder struct {
 	order                dist.Order
 	orderExtensions      dist.Order
 	validateMixins       bool
+	versionLock          *buildpack.VersionLock
 }
 
 type orderTOML struct {
@@ -388,6 +389,11 @@ func (b *Builder) SetOrder(order dist.Order) {
 	b.replaceOrder = true
 }
 
+// SetVersionLock sets the lock pinning the versions that version constraints in the order resolve to
+func (b *Builder) SetVersionLock(lock *buildpack.VersionLock) {
+	b.versionLock = lock
+}
+
 // SetOrderExtensions sets the order of the builder
 func (b *Builder) SetOrderExtensions(order dist.Order) {
 	for i, entry := range order {
@@ -518,11 +524,11 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 	}
 
 	if b.replaceOrder {
-		resolvedOrderBp, err := processOrder(b.metadata.Buildpacks, b.order, buildpack.KindBuildpack)
+		resolvedOrderBp, err := processOrder(b.metadata.Buildpacks, b.order, buildpack.KindBuildpack, b.versionLock, logger)
 		if err != nil {
 			return errors.Wrap(err, "processing buildpacks order")
 		}
-		resolvedOrderExt, err := processOrder(b.metadata.Extensions, b.orderExtensions, buildpack.KindExtension)
+		resolvedOrderExt, err := processOrder(b.metadata.Extensions, b.orderExtensions, buildpack.KindExtension, b.versionLock, logger)
 		if err != nil {
 			return errors.Wrap(err, "processing extensions order")
 		}
@@ -534,10 +540,10 @@ func (b *Builder) Save(logger logging.Logger, creatorMetadata CreatorMetadata) e
 		if err := b.image.AddLayer(orderTar); err != nil {
 			return errors.Wrap(err, "adding order.tar layer")
 		}
-		if err := dist.SetLabel(b.image, OrderLabel, b.order); err != nil {
+		if err := dist.SetLabel(b.image, OrderLabel, pinConstraints(b.order, resolvedOrderBp)); err != nil {
 			return err
 		}
-		if err := dist.SetLabel(b.image, OrderExtensionsLabel, b.orderExtensions); err != nil {
+		if err := dist.SetLabel(b.image, OrderExtensionsLabel, pinConstraints(b.orderExtensions, resolvedOrderExt)); err != nil {
 			return err
 		}
 	}
@@ -738,13 +744,13 @@ func (b *Builder) addFlattenedModules(kind string, logger logging.Logger, tmpDir
 	return buildModuleExcluded, nil
 }
 
-func processOrder(modulesOnBuilder []dist.ModuleInfo, order dist.Order, kind string) (dist.Order, error) {
+func processOrder(modulesOnBuilder []dist.ModuleInfo, order dist.Order, kind string, lock *buildpack.VersionLock, logger logging.Logger) (dist.Order, error) {
 	resolved := dist.Order{}
 	for idx, g := range order {
 		resolved = append(resolved, dist.OrderEntry{})
 		for _, ref := range g.Group {
 			var err error
-			if ref, err = resolveRef(modulesOnBuilder, ref, kind); err != nil {
+			if ref, err = resolveRef(modulesOnBuilder, ref, kind, lock, logger); err != nil {
 				return dist.Order{}, err
 			}
 			resolved[idx].Group = append(resolved[idx].Group, ref)
@@ -753,7 +759,27 @@ func processOrder(modulesOnBuilder []dist.ModuleInfo, order dist.Order, kind str
 	return resolved, nil
 }
 
-func resolveRef(moduleList []dist.ModuleInfo, ref dist.ModuleRef, kind string) (dist.ModuleRef, error) {
+// pinConstraints replaces the version constraints of an order with the versions they resolved to, leaving other
+// versions as declared.
+func pinConstraints(order, resolved dist.Order) dist.Order {
+	if order == nil {
+		return nil
+	}
+	pinned := dist.Order{}
+	for i, entry := range order {
+		group := make([]dist.ModuleRef, len(entry.Group))
+		for j, ref := range entry.Group {
+			if buildpack.IsVersionConstraint(ref.Version) {
+				ref.Version = resolved[i].Group[j].Version
+			}
+			group[j] = ref
+		}
+		pinned = append(pinned, dist.OrderEntry{Group: group})
+	}
+	return pinned
+}
+
+func resolveRef(moduleList []dist.ModuleInfo, ref dist.ModuleRef, kind string, lock *buildpack.VersionLock, logger logging.Logger) (dist.ModuleRef, error) {
 	var matching []dist.ModuleInfo
 	for _, bp := range moduleList {
 		if ref.ID == bp.ID {
@@ -775,6 +801,16 @@ func resolveRef(moduleList []dist.ModuleInfo, ref dist.ModuleRef, kind string) (
 		ref.Version = matching[0].Version
 	}
 
+	if buildpack.IsVersionConstraint(ref.Version) {
+		constraint := ref.Version
+		version, err := lock.Resolve(ref.ID, constraint, uniqueVersions(matching))
+		if err != nil {
+			return dist.ModuleRef{}, fmt.Errorf("unable to resolve version of %s %s on the builder: %s", kind, style.Symbol(ref.ID), err)
+		}
+		logger.Infof("Resolved %s to version %s", style.Symbol(ref.ID+"@"+constraint), style.Symbol(version))
+		ref.Version = version
+	}
+
 	if !hasElementWithVersion(matching, ref.Version) {
 		return dist.ModuleRef{},
 			fmt.Errorf("%s %s with version %s was not found on the builder", kind, style.Symbol(ref.ID), style.Symbol(ref.Version))
@@ -545,6 +545,66 @@ func testBuilder(t *testing.T, when spec.G, it spec.S) {
 					})
 				})
 
+				when("order uses a version constraint", func() {
+					it.Before(func() {
+						for _, version := range []string{"1.4.0", "1.5.2", "2.0.0"} {
+							bp, err := ifakes.NewFakeBuildpack(dist.BuildpackDescriptor{
+								WithAPI:    api.MustParse("0.2"),
+								WithInfo:   dist.ModuleInfo{ID: "semver-bp", Version: version},
+								WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+							}, 0644)
+							h.AssertNil(t, err)
+							subject.AddBuildpack(bp)
+						}
+					})
+
+					it("resolves the highest satisfying version", func() {
+						subject.SetOrder(dist.Order{{
+							Group: []dist.ModuleRef{
+								{ModuleInfo: dist.ModuleInfo{ID: "semver-bp", Version: "^1.4"}}},
+						}})
+
+						h.AssertNil(t, subject.Save(logger, builder.CreatorMetadata{}))
+						h.AssertContains(t, outBuf.String(), "Resolved 'semver-bp@^1.4' to version '1.5.2'")
+
+						layerTar, err := baseImage.FindLayerWithPath("/cnb/order.toml")
+						h.AssertNil(t, err)
+						h.AssertOnTarEntry(t, layerTar, "/cnb/order.toml", h.ContentEquals(`[[order]]
+
+  [[order.group]]
+    id = "semver-bp"
+    version = "1.5.2"
+`))
+
+						label, err := baseImage.Label("io.buildpacks.buildpack.order")
+						h.AssertNil(t, err)
+						h.AssertContains(t, label, `"version":"1.5.2"`)
+					})
+
+					it("prefers the version pinned by the version lock", func() {
+						lock := &buildpack.VersionLock{}
+						lock.Pin("semver-bp", "^1.4", "1.4.0")
+						subject.SetVersionLock(lock)
+						subject.SetOrder(dist.Order{{
+							Group: []dist.ModuleRef{
+								{ModuleInfo: dist.ModuleInfo{ID: "semver-bp", Version: "^1.4"}}},
+						}})
+
+						h.AssertNil(t, subject.Save(logger, builder.CreatorMetadata{}))
+						h.AssertContains(t, outBuf.String(), "Resolved 'semver-bp@^1.4' to version '1.4.0'")
+					})
+
+					it("errors when no version satisfies the constraint", func() {
+						subject.SetOrder(dist.Order{{
+							Group: []dist.ModuleRef{
+								{ModuleInfo: dist.ModuleInfo{ID: "semver-bp", Version: "^3.0"}}},
+						}})
+
+						err := subject.Save(logger, builder.CreatorMetadata{})
+						h.AssertError(t, err, "unable to resolve version of buildpack 'semver-bp' on the builder")
+					})
+				})
+
 				when("has multiple buildpacks with same ID", func() {
 					it.Before(func() {
 						subject.AddBuildpack(bp1v1)
@@ -0,0 +1,266 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+const (
+	OutcomePass = "pass"
+	OutcomeFail = "fail"
+)
+
+// DetectOutcomes are the assumed results of running detect for each buildpack. Buildpacks are referenced
+// either by id, matching any version, or by id@version. Failures take precedence over passes, and
+// buildpacks that are not referenced get the default outcome.
+type DetectOutcomes struct {
+	Default string   `toml:"default"`
+	Pass    []string `toml:"pass"`
+	Fail    []string `toml:"fail"`
+}
+
+// ReadDetectOutcomes reads assumed detect outcomes from a toml file.
+func ReadDetectOutcomes(path string) (DetectOutcomes, error) {
+	var outcomes DetectOutcomes
+	if _, err := toml.DecodeFile(path, &outcomes); err != nil {
+		return DetectOutcomes{}, errors.Wrapf(err, "reading detect outcomes from %s", style.Symbol(path))
+	}
+
+	return outcomes, outcomes.Validate()
+}
+
+// Validate returns an error if the default outcome is not recognized.
+func (o DetectOutcomes) Validate() error {
+	switch o.Default {
+	case "", OutcomePass, OutcomeFail:
+		return nil
+	default:
+		return errors.Errorf("invalid default outcome %s, must be one of %s or %s", style.Symbol(o.Default), style.Symbol(OutcomePass), style.Symbol(OutcomeFail))
+	}
+}
+
+// Passes returns the assumed detect outcome of the buildpack.
+func (o DetectOutcomes) Passes(bp dist.ModuleInfo) bool {
+	if matchesAny(o.Fail, bp) {
+		return false
+	}
+	if matchesAny(o.Pass, bp) {
+		return true
+	}
+
+	return o.Default == OutcomePass
+}
+
+func matchesAny(refs []string, bp dist.ModuleInfo) bool {
+	for _, ref := range refs {
+		id, version, _ := strings.Cut(ref, "@")
+		if id == bp.ID && (version == "" || version == bp.Version) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DetectionTrial is a candidate group, with composite buildpacks resolved, that detection was run against.
+type DetectionTrial struct {
+	Buildpacks []TrialBuildpack
+	Passed     bool
+	Reason     string
+}
+
+// TrialBuildpack is a buildpack of a trial along with its assumed detect outcome.
+type TrialBuildpack struct {
+	dist.ModuleRef
+
+	// Composite buildpack the buildpack was resolved from, if any.
+	Via    []dist.ModuleInfo
+	Passed bool
+}
+
+// SimulatedGroup is the outcome of detection for a group of the builder order.
+type SimulatedGroup struct {
+	Group   dist.OrderEntry
+	Trials  []DetectionTrial
+	Passed  bool
+	Skipped bool
+}
+
+// DetectionSimulation is the outcome of statically evaluating the order of a builder.
+type DetectionSimulation struct {
+	Groups []SimulatedGroup
+
+	// Index of the chosen group in Groups, or -1 if no group passed.
+	Chosen int
+
+	// Buildpacks that would run the build phase.
+	Result []dist.ModuleRef
+}
+
+// SimulateDetection evaluates the order the same way the lifecycle detector does, using assumed detect
+// outcomes instead of running buildpacks. Composite buildpacks are resolved using the order in their
+// layers metadata. The build plan is not considered, so a group can only fail because a required
+// buildpack failed or because no buildpack passed.
+func SimulateDetection(order dist.Order, layers dist.ModuleLayers, outcomes DetectOutcomes) DetectionSimulation {
+	result := DetectionSimulation{Chosen: -1}
+	for _, entry := range order {
+		group := SimulatedGroup{Group: entry}
+		if result.Chosen != -1 {
+			group.Skipped = true
+			result.Groups = append(result.Groups, group)
+			continue
+		}
+
+		s := &simulator{layers: layers, outcomes: outcomes, visiting: map[string]bool{}}
+		found, ok := s.detectGroup(toElements(entry.Group, nil), nil)
+		group.Trials = s.trials
+		group.Passed = ok
+		if ok {
+			result.Chosen = len(result.Groups)
+			for _, el := range found {
+				result.Result = append(result.Result, el.ModuleRef)
+			}
+		}
+
+		result.Groups = append(result.Groups, group)
+	}
+
+	return result
+}
+
+type groupElement struct {
+	dist.ModuleRef
+	via []dist.ModuleInfo
+}
+
+type simulator struct {
+	layers   dist.ModuleLayers
+	outcomes DetectOutcomes
+	visiting map[string]bool
+	trials   []DetectionTrial
+}
+
+func toElements(refs []dist.ModuleRef, via []dist.ModuleInfo) []groupElement {
+	var elements []groupElement
+	for _, ref := range refs {
+		elements = append(elements, groupElement{ModuleRef: ref, via: via})
+	}
+
+	return elements
+}
+
+// detectOrder tries each group of the order of a composite buildpack followed by the rest of the enclosing group
+func (s *simulator) detectOrder(order dist.Order, done, next []groupElement, optional bool, via []dist.ModuleInfo) ([]groupElement, bool) {
+	for _, entry := range order {
+		group := append(toElements(entry.Group, via), next...)
+		if found, ok := s.detectGroup(group, done); ok {
+			return found, true
+		}
+	}
+
+	if optional {
+		return s.detectGroup(next, done)
+	}
+
+	return nil, false
+}
+
+func (s *simulator) detectGroup(group, done []groupElement) ([]groupElement, bool) {
+	done = append([]groupElement{}, done...)
+	for i, el := range group {
+		if containsID(done, el.ID) {
+			continue
+		}
+
+		layer, ok := s.layers.Get(el.ID, el.Version)
+		if ok && el.Version == "" {
+			el.Version = versionOf(s.layers, el.ID)
+		}
+		if ok && len(layer.Order) > 0 {
+			key := el.FullName()
+			if s.visiting[key] {
+				s.trials = append(s.trials, DetectionTrial{
+					Buildpacks: trialBuildpacks(done, s.outcomes),
+					Reason:     fmt.Sprintf("composite buildpack %s references itself", style.Symbol(key)),
+				})
+				return nil, false
+			}
+
+			s.visiting[key] = true
+			found, passed := s.detectOrder(layer.Order, done, group[i+1:], el.Optional, append(append([]dist.ModuleInfo{}, el.via...), el.ModuleInfo))
+			delete(s.visiting, key)
+			return found, passed
+		}
+
+		done = append(done, el)
+	}
+
+	return s.resolve(done)
+}
+
+// resolve drops optional buildpacks that failed, and fails the trial if a required buildpack failed or none passed
+func (s *simulator) resolve(done []groupElement) ([]groupElement, bool) {
+	trial := DetectionTrial{Buildpacks: trialBuildpacks(done, s.outcomes)}
+
+	var found []groupElement
+	for i, bp := range trial.Buildpacks {
+		if bp.Passed {
+			found = append(found, done[i])
+			continue
+		}
+
+		if !bp.Optional && trial.Reason == "" {
+			trial.Reason = fmt.Sprintf("required buildpack %s failed detection", style.Symbol(bp.FullName()))
+		}
+	}
+
+	if trial.Reason == "" && len(found) == 0 {
+		trial.Reason = "no buildpacks passed detection"
+	}
+
+	trial.Passed = trial.Reason == ""
+	s.trials = append(s.trials, trial)
+	if !trial.Passed {
+		return nil, false
+	}
+
+	return found, true
+}
+
+func trialBuildpacks(done []groupElement, outcomes DetectOutcomes) []TrialBuildpack {
+	var bps []TrialBuildpack
+	for _, el := range done {
+		bps = append(bps, TrialBuildpack{
+			ModuleRef: el.ModuleRef,
+			Via:       el.via,
+			Passed:    outcomes.Passes(el.ModuleInfo),
+		})
+	}
+
+	return bps
+}
+
+func containsID(elements []groupElement, id string) bool {
+	for _, el := range elements {
+		if el.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+// versionOf returns the version of a buildpack referenced without one, which is only possible if the builder has a single version
+func versionOf(layers dist.ModuleLayers, id string) string {
+	for version := range layers[id] {
+		return version
+	}
+
+	return ""
+}
@@ -0,0 +1,152 @@
+package builder_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/pkg/dist"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExplain(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "testExplain", testExplain, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testExplain(t *testing.T, when spec.G, it spec.S) {
+	var (
+		ref = func(id, version string, optional bool) dist.ModuleRef {
+			return dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: id, Version: version}, Optional: optional}
+		}
+		group = func(refs ...dist.ModuleRef) dist.OrderEntry {
+			return dist.OrderEntry{Group: refs}
+		}
+		names = func(refs []dist.ModuleRef) []string {
+			var result []string
+			for _, r := range refs {
+				result = append(result, r.FullName())
+			}
+			return result
+		}
+		layers = dist.ModuleLayers{
+			"some/composite": {
+				"1.0.0": dist.ModuleLayerInfo{Order: dist.Order{
+					group(ref("some/bp-a", "1.0.0", false)),
+					group(ref("some/bp-b", "1.0.0", false)),
+				}},
+			},
+			"some/bp-a":  {"1.0.0": dist.ModuleLayerInfo{}},
+			"some/bp-b":  {"1.0.0": dist.ModuleLayerInfo{}},
+			"some/bp-c":  {"1.0.0": dist.ModuleLayerInfo{}},
+			"some/cycle": {"1.0.0": dist.ModuleLayerInfo{Order: dist.Order{group(ref("some/cycle", "1.0.0", false))}}},
+		}
+	)
+
+	when("#SimulateDetection", func() {
+		it("chooses the first group that passes and skips later groups", func() {
+			order := dist.Order{
+				group(ref("some/bp-a", "1.0.0", false), ref("some/bp-b", "1.0.0", false)),
+				group(ref("some/bp-a", "1.0.0", false), ref("some/bp-c", "1.0.0", true)),
+				group(ref("some/bp-a", "1.0.0", false)),
+			}
+
+			sim := builder.SimulateDetection(order, layers, builder.DetectOutcomes{Pass: []string{"some/bp-a"}})
+
+			h.AssertEq(t, sim.Chosen, 1)
+			h.AssertEq(t, names(sim.Result), []string{"some/bp-a@1.0.0"})
+
+			h.AssertEq(t, sim.Groups[0].Passed, false)
+			h.AssertEq(t, sim.Groups[0].Trials[0].Reason, "required buildpack 'some/bp-b@1.0.0' failed detection")
+
+			h.AssertEq(t, sim.Groups[1].Passed, true)
+			h.AssertEq(t, len(sim.Groups[1].Trials[0].Buildpacks), 2)
+			h.AssertEq(t, sim.Groups[1].Trials[0].Buildpacks[1].Passed, false)
+			h.AssertEq(t, sim.Groups[1].Trials[0].Buildpacks[1].Optional, true)
+
+			h.AssertEq(t, sim.Groups[2].Skipped, true)
+			h.AssertEq(t, len(sim.Groups[2].Trials), 0)
+		})
+
+		it("fails a group where no buildpack passed", func() {
+			order := dist.Order{group(ref("some/bp-c", "1.0.0", true))}
+
+			sim := builder.SimulateDetection(order, layers, builder.DetectOutcomes{})
+
+			h.AssertEq(t, sim.Chosen, -1)
+			h.AssertEq(t, sim.Groups[0].Trials[0].Reason, "no buildpacks passed detection")
+		})
+
+		it("tries each group of a composite buildpack followed by the rest of the group", func() {
+			order := dist.Order{group(ref("some/composite", "1.0.0", false), ref("some/bp-c", "1.0.0", false))}
+
+			sim := builder.SimulateDetection(order, layers, builder.DetectOutcomes{Default: builder.OutcomePass, Fail: []string{"some/bp-a"}})
+
+			h.AssertEq(t, sim.Chosen, 0)
+			h.AssertEq(t, names(sim.Result), []string{"some/bp-b@1.0.0", "some/bp-c@1.0.0"})
+			h.AssertEq(t, len(sim.Groups[0].Trials), 2)
+			h.AssertEq(t, sim.Groups[0].Trials[0].Passed, false)
+			h.AssertEq(t, sim.Groups[0].Trials[1].Buildpacks[0].Via, []dist.ModuleInfo{{ID: "some/composite", Version: "1.0.0"}})
+		})
+
+		it("skips an optional composite buildpack when none of its groups pass", func() {
+			order := dist.Order{group(ref("some/composite", "1.0.0", true), ref("some/bp-c", "1.0.0", false))}
+
+			sim := builder.SimulateDetection(order, layers, builder.DetectOutcomes{Pass: []string{"some/bp-c@1.0.0"}})
+
+			h.AssertEq(t, sim.Chosen, 0)
+			h.AssertEq(t, names(sim.Result), []string{"some/bp-c@1.0.0"})
+			h.AssertEq(t, len(sim.Groups[0].Trials), 3)
+		})
+
+		it("fails on cyclic composite buildpacks", func() {
+			order := dist.Order{group(ref("some/cycle", "1.0.0", false))}
+
+			sim := builder.SimulateDetection(order, layers, builder.DetectOutcomes{Default: builder.OutcomePass})
+
+			h.AssertEq(t, sim.Chosen, -1)
+			h.AssertEq(t, sim.Groups[0].Trials[0].Reason, "composite buildpack 'some/cycle@1.0.0' references itself")
+		})
+
+		it("resolves the version of buildpacks referenced by id only", func() {
+			order := dist.Order{group(ref("some/bp-a", "", false))}
+
+			sim := builder.SimulateDetection(order, layers, builder.DetectOutcomes{Pass: []string{"some/bp-a@1.0.0"}})
+
+			h.AssertEq(t, names(sim.Result), []string{"some/bp-a@1.0.0"})
+		})
+	})
+
+	when("#DetectOutcomes", func() {
+		it("gives failures precedence over passes", func() {
+			outcomes := builder.DetectOutcomes{Pass: []string{"some/bp-a"}, Fail: []string{"some/bp-a@2.0.0"}}
+
+			h.AssertEq(t, outcomes.Passes(dist.ModuleInfo{ID: "some/bp-a", Version: "1.0.0"}), true)
+			h.AssertEq(t, outcomes.Passes(dist.ModuleInfo{ID: "some/bp-a", Version: "2.0.0"}), false)
+			h.AssertEq(t, outcomes.Passes(dist.ModuleInfo{ID: "some/bp-b", Version: "1.0.0"}), false)
+		})
+
+		it("reads outcomes from a file", func() {
+			tmpDir := t.TempDir()
+			path := filepath.Join(tmpDir, "outcomes.toml")
+			h.AssertNil(t, os.WriteFile(path, []byte(`default = "pass"
+fail = ["some/bp-b"]
+`), 0600))
+
+			outcomes, err := builder.ReadDetectOutcomes(path)
+			h.AssertNil(t, err)
+			h.AssertEq(t, outcomes.Passes(dist.ModuleInfo{ID: "some/bp-a"}), true)
+			h.AssertEq(t, outcomes.Passes(dist.ModuleInfo{ID: "some/bp-b"}), false)
+		})
+
+		it("errors on an invalid default outcome", func() {
+			h.AssertError(t, builder.DetectOutcomes{Default: "maybe"}.Validate(), "invalid default outcome 'maybe'")
+		})
+	})
+}
@@ -0,0 +1,286 @@
+package builder
+
+import (
+	"archive/tar"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+type LayerOwner string
+
+const (
+	LayerOwnerBaseImage      LayerOwner = "base image"
+	LayerOwnerDirs           LayerOwner = "default directories"
+	LayerOwnerLifecycle      LayerOwner = "lifecycle"
+	LayerOwnerBuildpack      LayerOwner = "buildpack"
+	LayerOwnerExtension      LayerOwner = "extension"
+	LayerOwnerWhiteout       LayerOwner = "whiteout"
+	LayerOwnerSuperseded     LayerOwner = "superseded module"
+	LayerOwnerOrder          LayerOwner = "order"
+	LayerOwnerStack          LayerOwner = "stack"
+	LayerOwnerRunImages      LayerOwner = "run images"
+	LayerOwnerBuildConfigEnv LayerOwner = "build config env"
+	LayerOwnerEnv            LayerOwner = "env"
+	LayerOwnerUnknown        LayerOwner = "unknown"
+
+	// TarLayerOverhead approximates the size of the parent directory headers and end-of-archive marker
+	// repeated in every module layer, and thus saved for every layer removed by flattening.
+	TarLayerOverhead = 4 * 512
+)
+
+// LayerInfo describes a single layer of a builder image and the entity that contributed it.
+// A Size of -1 means the size of the layer is unknown.
+type LayerInfo struct {
+	DiffID  string
+	Size    int64
+	Owner   LayerOwner
+	Modules []dist.ModuleInfo
+}
+
+// Flattened returns true if the layer holds more than one module.
+func (l LayerInfo) Flattened() bool {
+	return len(l.Modules) > 1
+}
+
+// FlattenEstimate describes the effect of flattening a group of modules into a single layer.
+type FlattenEstimate struct {
+	Modules       []dist.ModuleInfo
+	Missing       []dist.ModuleInfo
+	CurrentLayers int
+	CurrentSize   int64
+	LayersSaved   int
+
+	// ApproximateSaved is TarLayerOverhead for each layer saved. It is not computed from the layer contents.
+	ApproximateSaved int64
+}
+
+// AnalyzeLayers attributes every layer of the builder image to the entity that contributed it. Module
+// layers are identified using the layers metadata of the builder, while the remaining layers are
+// identified by their contents. Layers beneath the default directories layer belong to the base image.
+func AnalyzeLayers(img v1.Image, bpLayers, extLayers dist.ModuleLayers) ([]LayerInfo, error) {
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading image layers")
+	}
+
+	moduleOwners := map[string]LayerInfo{}
+	addModuleOwners(moduleOwners, bpLayers, LayerOwnerBuildpack)
+	addModuleOwners(moduleOwners, extLayers, LayerOwnerExtension)
+
+	result := make([]LayerInfo, len(layers))
+	baseReached := false
+	for i := len(layers) - 1; i >= 0; i-- {
+		layer := layers[i]
+
+		diffID, err := layer.DiffID()
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading diff id of layer %d", i)
+		}
+
+		info := LayerInfo{DiffID: diffID.String()}
+		moduleOwner, isModuleLayer := moduleOwners[diffID.String()]
+		switch {
+		case baseReached:
+			info.Owner = LayerOwnerBaseImage
+		case isModuleLayer:
+			info.Owner = moduleOwner.Owner
+			info.Modules = moduleOwner.Modules
+		case diffID.String() == emptyTarDiffID:
+			info.Owner = LayerOwnerEnv
+		default:
+			info.Owner, info.Modules, err = classifyLayer(layer)
+			if err != nil {
+				return nil, errors.Wrapf(err, "reading contents of layer %s", diffID)
+			}
+			baseReached = info.Owner == LayerOwnerDirs
+		}
+
+		// daemon images only know the size of layers once their contents have been read
+		if info.Size, err = layer.Size(); err != nil {
+			return nil, errors.Wrapf(err, "reading size of layer %s", diffID)
+		}
+
+		result[i] = info
+	}
+
+	return result, nil
+}
+
+// EstimateFlatten estimates the savings of flattening each of the given groups of modules into a single layer.
+// Flattening doesn't deduplicate file contents, so only the per-layer overhead is counted as saved.
+func EstimateFlatten(layers []LayerInfo, toFlatten buildpack.FlattenModuleInfos) []FlattenEstimate {
+	if toFlatten == nil {
+		return nil
+	}
+
+	var estimates []FlattenEstimate
+	for _, group := range toFlatten.FlattenModules() {
+		estimate := FlattenEstimate{Modules: group.BuildModule()}
+		seen := map[string]bool{}
+
+		for _, module := range group.BuildModule() {
+			layer, ok := findModuleLayer(layers, module)
+			if !ok {
+				estimate.Missing = append(estimate.Missing, module)
+				continue
+			}
+
+			if !seen[layer.DiffID] {
+				seen[layer.DiffID] = true
+				estimate.CurrentLayers++
+				if layer.Size > 0 {
+					estimate.CurrentSize += layer.Size
+				}
+			}
+		}
+
+		if estimate.CurrentLayers > 1 {
+			estimate.LayersSaved = estimate.CurrentLayers - 1
+			estimate.ApproximateSaved = int64(estimate.LayersSaved * TarLayerOverhead)
+		}
+
+		estimates = append(estimates, estimate)
+	}
+
+	return estimates
+}
+
+func addModuleOwners(owners map[string]LayerInfo, layers dist.ModuleLayers, owner LayerOwner) {
+	for id, versions := range layers {
+		for version, layerInfo := range versions {
+			info := owners[layerInfo.LayerDiffID]
+			info.Owner = owner
+			info.Modules = append(info.Modules, dist.ModuleInfo{
+				ID:       id,
+				Version:  version,
+				Name:     layerInfo.Name,
+				Homepage: layerInfo.Homepage,
+			})
+			sort.Slice(info.Modules, func(i, j int) bool {
+				return info.Modules[i].FullName() < info.Modules[j].FullName()
+			})
+			owners[layerInfo.LayerDiffID] = info
+		}
+	}
+}
+
+func findModuleLayer(layers []LayerInfo, module dist.ModuleInfo) (LayerInfo, bool) {
+	for _, layer := range layers {
+		if layer.Owner != LayerOwnerBuildpack && layer.Owner != LayerOwnerExtension {
+			continue
+		}
+
+		for _, m := range layer.Modules {
+			if m.ID == module.ID && m.Version == module.Version {
+				return layer, true
+			}
+		}
+	}
+
+	return LayerInfo{}, false
+}
+
+// classifyLayer reads the headers of the layer until it can determine who contributed it
+func classifyLayer(layer v1.Layer) (LayerOwner, []dist.ModuleInfo, error) {
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return "", nil, err
+	}
+	defer rc.Close()
+
+	defaultDirs := map[string]bool{}
+	for _, dir := range []string{workspaceDir, layersDir, cnbDir, dist.BuildpacksDir, dist.ExtensionsDir, platformDir, platformDir + "/env", buildConfigDir, buildConfigDir + "/env"} {
+		defaultDirs[strings.TrimPrefix(dir, "/")] = true
+	}
+
+	var (
+		module     *dist.ModuleInfo
+		onlyDirs   = true
+		anyEntries = false
+		tr         = tar.NewReader(rc)
+	)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", nil, err
+		}
+
+		anyEntries = true
+		name := strings.TrimPrefix(path.Clean("/"+header.Name), "/")
+
+		switch {
+		case name == strings.TrimPrefix(lifecycleDir, "/") || strings.HasPrefix(name, strings.TrimPrefix(lifecycleDir, "/")+"/"):
+			return LayerOwnerLifecycle, nil, nil
+		case name == strings.TrimPrefix(orderPath, "/"):
+			return LayerOwnerOrder, nil, nil
+		case name == strings.TrimPrefix(stackPath, "/"):
+			return LayerOwnerStack, nil, nil
+		case name == strings.TrimPrefix(runPath, "/"):
+			return LayerOwnerRunImages, nil, nil
+		case header.Typeflag != tar.TypeDir && strings.HasPrefix(name, strings.TrimPrefix(buildConfigDir, "/")+"/env/"):
+			return LayerOwnerBuildConfigEnv, nil, nil
+		case header.Typeflag != tar.TypeDir && strings.HasPrefix(name, strings.TrimPrefix(platformDir, "/")+"/env/"):
+			return LayerOwnerEnv, nil, nil
+		}
+
+		if defaultDirs[name] && header.Typeflag == tar.TypeDir {
+			continue
+		}
+		onlyDirs = false
+
+		id, version, isModule := modulePath(name)
+		if !isModule {
+			return LayerOwnerUnknown, nil, nil
+		}
+
+		if strings.HasPrefix(version, ".wh.") {
+			return LayerOwnerWhiteout, []dist.ModuleInfo{{ID: id, Version: strings.TrimPrefix(version, ".wh.")}}, nil
+		}
+
+		if module == nil && version != "" {
+			module = &dist.ModuleInfo{ID: id, Version: version}
+		}
+	}
+
+	switch {
+	case !anyEntries:
+		return LayerOwnerEnv, nil, nil
+	case onlyDirs:
+		return LayerOwnerDirs, nil, nil
+	case module != nil:
+		return LayerOwnerSuperseded, []dist.ModuleInfo{*module}, nil
+	default:
+		return LayerOwnerUnknown, nil, nil
+	}
+}
+
+// modulePath returns the escaped id and version of the module a path under the buildpacks or extensions dir belongs to
+func modulePath(name string) (string, string, bool) {
+	for _, dir := range []string{dist.BuildpacksDir, dist.ExtensionsDir} {
+		prefix := strings.TrimPrefix(dir, "/") + "/"
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		parts := strings.SplitN(strings.TrimPrefix(name, prefix), "/", 3)
+		id := parts[0]
+		if len(parts) < 2 {
+			return id, "", true
+		}
+
+		return id, parts[1], true
+	}
+
+	return "", "", false
+}
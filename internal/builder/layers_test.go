@@ -0,0 +1,179 @@
+package builder_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLayerAnalysis(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "testLayerAnalysis", testLayerAnalysis, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLayerAnalysis(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+
+		img      v1.Image
+		bpLayers dist.ModuleLayers
+	)
+
+	layerDiffID := func(layer v1.Layer) string {
+		diffID, err := layer.DiffID()
+		assert.Nil(err)
+		return diffID.String()
+	}
+
+	it.Before(func() {
+		baseLayer := tarLayer(t, tarEntry{name: "bin"}, tarEntry{name: "bin/sh", contents: "#!/bin/sh"})
+		dirsLayer := tarLayer(t, tarEntry{name: "/workspace"}, tarEntry{name: "/layers"}, tarEntry{name: "/cnb"}, tarEntry{name: "/cnb/buildpacks"})
+		lifecycleLayer := tarLayer(t, tarEntry{name: "/cnb/lifecycle"}, tarEntry{name: "/cnb/lifecycle/detector", contents: "detector"})
+		bpOneLayer := tarLayer(t, tarEntry{name: "/cnb/buildpacks/bp.one/1.0/bin/build", contents: "build"})
+		flattenedLayer := tarLayer(t, tarEntry{name: "/cnb/buildpacks/bp.two/2.0/bin/build", contents: "build"}, tarEntry{name: "/cnb/buildpacks/bp.three/3.0/bin/build", contents: "build"})
+		oldBpOneLayer := tarLayer(t, tarEntry{name: "/cnb/buildpacks/bp.one/1.0/bin/build", contents: "old build"})
+		whiteoutLayer := tarLayer(t, tarEntry{name: "/cnb/buildpacks/bp.one/.wh.1.0", contents: ""})
+		orderLayer := tarLayer(t, tarEntry{name: "/cnb/order.toml", contents: "[[order]]"})
+		stackLayer := tarLayer(t, tarEntry{name: "/cnb/stack.toml", contents: "[run-image]"})
+		runLayer := tarLayer(t, tarEntry{name: "/cnb/run.toml", contents: "[[images]]"})
+		envLayer := tarLayer(t, tarEntry{name: "/platform/env/SOME_KEY", contents: "some-value"})
+
+		var err error
+		img, err = mutate.AppendLayers(
+			empty.Image,
+			baseLayer, dirsLayer, lifecycleLayer, oldBpOneLayer, whiteoutLayer, bpOneLayer, flattenedLayer,
+			orderLayer, stackLayer, runLayer, envLayer,
+		)
+		assert.Nil(err)
+
+		bpLayers = dist.ModuleLayers{
+			"bp.one":   {"1.0": dist.ModuleLayerInfo{LayerDiffID: layerDiffID(bpOneLayer)}},
+			"bp.two":   {"2.0": dist.ModuleLayerInfo{LayerDiffID: layerDiffID(flattenedLayer)}},
+			"bp.three": {"3.0": dist.ModuleLayerInfo{LayerDiffID: layerDiffID(flattenedLayer)}},
+		}
+	})
+
+	when("AnalyzeLayers", func() {
+		it("attributes each layer to its owner", func() {
+			layers, err := builder.AnalyzeLayers(img, bpLayers, dist.ModuleLayers{})
+			assert.Nil(err)
+
+			var owners []builder.LayerOwner
+			for _, layer := range layers {
+				owners = append(owners, layer.Owner)
+			}
+			assert.Equal(owners, []builder.LayerOwner{
+				builder.LayerOwnerBaseImage,
+				builder.LayerOwnerDirs,
+				builder.LayerOwnerLifecycle,
+				builder.LayerOwnerSuperseded,
+				builder.LayerOwnerWhiteout,
+				builder.LayerOwnerBuildpack,
+				builder.LayerOwnerBuildpack,
+				builder.LayerOwnerOrder,
+				builder.LayerOwnerStack,
+				builder.LayerOwnerRunImages,
+				builder.LayerOwnerEnv,
+			})
+		})
+
+		it("names the modules in module layers", func() {
+			layers, err := builder.AnalyzeLayers(img, bpLayers, dist.ModuleLayers{})
+			assert.Nil(err)
+
+			assert.Equal(layers[3].Modules, []dist.ModuleInfo{{ID: "bp.one", Version: "1.0"}})
+			assert.Equal(layers[4].Modules, []dist.ModuleInfo{{ID: "bp.one", Version: "1.0"}})
+			assert.Equal(layers[5].Modules, []dist.ModuleInfo{{ID: "bp.one", Version: "1.0"}})
+			assert.Equal(layers[6].Modules, []dist.ModuleInfo{{ID: "bp.three", Version: "3.0"}, {ID: "bp.two", Version: "2.0"}})
+			assert.TrueWithMessage(layers[6].Flattened(), "expected layer to be flattened")
+			assert.Equal(layers[5].Flattened(), false)
+		})
+
+		it("reports the size of each layer", func() {
+			layers, err := builder.AnalyzeLayers(img, bpLayers, dist.ModuleLayers{})
+			assert.Nil(err)
+
+			imgLayers, err := img.Layers()
+			assert.Nil(err)
+			for i, layer := range layers {
+				size, err := imgLayers[i].Size()
+				assert.Nil(err)
+				assert.Equal(layer.Size, size)
+			}
+		})
+	})
+
+	when("EstimateFlatten", func() {
+		it("estimates the layers saved by flattening", func() {
+			layers, err := builder.AnalyzeLayers(img, bpLayers, dist.ModuleLayers{})
+			assert.Nil(err)
+
+			toFlatten, err := buildpack.ParseFlattenBuildModules([]string{"bp.one@1.0,bp.two@2.0", "bp.two@2.0,bp.three@3.0", "bp.one@1.0,bp.missing@1.0"})
+			assert.Nil(err)
+
+			estimates := builder.EstimateFlatten(layers, toFlatten)
+			assert.Equal(len(estimates), 3)
+
+			assert.Equal(estimates[0].CurrentLayers, 2)
+			assert.Equal(estimates[0].CurrentSize, layers[5].Size+layers[6].Size)
+			assert.Equal(estimates[0].LayersSaved, 1)
+			assert.Equal(estimates[0].ApproximateSaved > 0, true)
+
+			assert.Equal(estimates[1].CurrentLayers, 1)
+			assert.Equal(estimates[1].LayersSaved, 0)
+			assert.Equal(estimates[1].ApproximateSaved, int64(0))
+
+			assert.Equal(estimates[2].Missing, []dist.ModuleInfo{{ID: "bp.missing", Version: "1.0"}})
+			assert.Equal(estimates[2].LayersSaved, 0)
+		})
+
+		it("returns nothing when there is nothing to flatten", func() {
+			assert.Equal(len(builder.EstimateFlatten(nil, nil)), 0)
+		})
+	})
+}
+
+type tarEntry struct {
+	name     string
+	contents string
+}
+
+func tarLayer(t *testing.T, entries ...tarEntry) v1.Layer {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0755, Typeflag: tar.TypeDir}
+		if entry.contents != "" || bytes.Contains([]byte(entry.name), []byte(".wh.")) {
+			header = &tar.Header{Name: entry.name, Mode: 0644, Typeflag: tar.TypeReg, Size: int64(len(entry.contents))}
+		}
+		h.AssertNil(t, tw.WriteHeader(header))
+		_, err := tw.Write([]byte(entry.contents))
+		h.AssertNil(t, err)
+	}
+	h.AssertNil(t, tw.Close())
+
+	contents := buf.Bytes()
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(contents)), nil
+	})
+	h.AssertNil(t, err)
+
+	return layer
+}
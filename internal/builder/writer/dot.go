@@ -0,0 +1,84 @@
+package writer
+
+import (
+	"fmt"
+	"strings"
+)
+
+type DOT struct {
+	GraphFormat
+}
+
+func NewDOT() BuilderWriter {
+	return &DOT{
+		GraphFormat: GraphFormat{
+			RenderFunc: renderDOT,
+		},
+	}
+}
+
+func renderDOT(g *OrderGraph) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(g.Name))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&b, "  %s [label=%s%s];\n", dotQuote(node.ID), dotQuote(node.Label), dotShape(node.Kind))
+	}
+
+	for _, edge := range g.Edges {
+		var attrs []string
+		if label := edgeLabel(edge); label != "" {
+			attrs = append(attrs, "label="+dotQuote(label))
+		}
+		switch {
+		case edge.Cyclical:
+			attrs = append(attrs, "style=dotted")
+		case edge.Optional:
+			attrs = append(attrs, "style=dashed")
+		}
+
+		fmt.Fprintf(&b, "  %s -> %s", dotQuote(edge.From), dotQuote(edge.To))
+		if len(attrs) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(attrs, ", "))
+		}
+		b.WriteString(";\n")
+	}
+
+	b.WriteString("}")
+	return b.String()
+}
+
+func dotShape(kind GraphNodeKind) string {
+	switch kind {
+	case BuilderNode:
+		return ", shape=house"
+	case OrderNode:
+		return ", shape=folder"
+	case GroupNode:
+		return ", shape=ellipse"
+	default:
+		return ""
+	}
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func edgeLabel(edge GraphEdge) string {
+	var parts []string
+	if edge.Label != "" {
+		parts = append(parts, edge.Label)
+	}
+	if edge.Optional {
+		parts = append(parts, "optional")
+	}
+	if edge.Cyclical {
+		parts = append(parts, "cyclic")
+	}
+
+	return strings.Join(parts, " ")
+}
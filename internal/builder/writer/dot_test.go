@@ -0,0 +1,161 @@
+package writer_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDOT(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Builder Writer", testDOT, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDOT(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		outBuf bytes.Buffer
+
+		remoteInfo *client.BuilderInfo
+		localInfo  *client.BuilderInfo
+	)
+
+	it.Before(func() {
+		outBuf = bytes.Buffer{}
+
+		remoteInfo = &client.BuilderInfo{
+			Buildpacks:      buildpacks,
+			Order:           order,
+			Extensions:      extensions,
+			OrderExtensions: orderExtensions,
+		}
+
+		localInfo = &client.BuilderInfo{
+			Buildpacks: buildpacks,
+			Order:      order[1:],
+		}
+	})
+
+	when("Print", func() {
+		it("renders the detection order of the remote builder as a digraph", func() {
+			dotWriter := writer.NewDOT()
+
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			err := dotWriter.Print(logger, localRunImages, localInfo, remoteInfo, nil, nil, sharedBuilderInfo)
+			assert.Nil(err)
+
+			assert.ContainsAll(outBuf.String(),
+				`digraph "test-builder" {`,
+				`"builder" [label="test-builder", shape=house];`,
+				`"builder" -> "order";`,
+				`"order" -> "group_1" [label="1"];`,
+				`"order" -> "group_5" [label="2"];`,
+			)
+		})
+
+		it("expands composite buildpacks into their groups", func() {
+			dotWriter := writer.NewDOT()
+
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			err := dotWriter.Print(logger, localRunImages, localInfo, remoteInfo, nil, nil, sharedBuilderInfo)
+			assert.Nil(err)
+
+			assert.ContainsAll(outBuf.String(),
+				`"bp_1" [label="test.top.nested@test.top.nested.version"];`,
+				`"group_1" -> "bp_1";`,
+				`"bp_1" -> "group_2" [label="1"];`,
+				`"group_2" -> "bp_2";`,
+			)
+		})
+
+		it("marks optional and cyclic buildpacks", func() {
+			dotWriter := writer.NewDOT()
+
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			err := dotWriter.Print(logger, localRunImages, localInfo, remoteInfo, nil, nil, sharedBuilderInfo)
+			assert.Nil(err)
+
+			assert.ContainsAll(outBuf.String(),
+				`"group_3" -> "bp_3" [label="optional", style=dashed];`,
+				`"group_4" -> "bp_3" [label="optional cyclic", style=dotted];`,
+			)
+		})
+
+		it("links buildpacks shared between groups to a single node", func() {
+			dotWriter := writer.NewDOT()
+
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			err := dotWriter.Print(logger, localRunImages, localInfo, remoteInfo, nil, nil, sharedBuilderInfo)
+			assert.Nil(err)
+
+			assert.ContainsAll(outBuf.String(),
+				`"group_2" -> "bp_4" [label="optional", style=dashed];`,
+				`"group_5" -> "bp_4";`,
+			)
+			assert.NotContains(outBuf.String(), `"bp_7"`)
+		})
+
+		it("renders the extensions order", func() {
+			dotWriter := writer.NewDOT()
+
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			err := dotWriter.Print(logger, localRunImages, localInfo, remoteInfo, nil, nil, sharedBuilderInfo)
+			assert.Nil(err)
+
+			assert.ContainsAll(outBuf.String(),
+				`"builder" -> "order_extensions";`,
+				`"order_extensions" -> "group_6" [label="1"];`,
+				`"group_6" -> "ext_7";`,
+				`"group_7" -> "ext_8" [label="optional", style=dashed];`,
+			)
+		})
+
+		when("builder doesn't exist remotely", func() {
+			it("renders the local builder", func() {
+				dotWriter := writer.NewDOT()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := dotWriter.Print(logger, localRunImages, localInfo, nil, nil, nil, sharedBuilderInfo)
+				assert.Nil(err)
+
+				assert.Contains(outBuf.String(), `"group_1" -> "bp_1";`)
+				assert.Contains(outBuf.String(), `"bp_1" [label="test.bp.three@test.bp.three.version"];`)
+				assert.NotContains(outBuf.String(), "order_extensions")
+			})
+		})
+
+		when("builder doesn't exist locally or remotely", func() {
+			it("returns an error", func() {
+				dotWriter := writer.NewDOT()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := dotWriter.Print(logger, localRunImages, nil, nil, nil, nil, sharedBuilderInfo)
+				assert.ErrorWithMessage(err, "unable to find builder 'test-builder' locally or remotely")
+			})
+		})
+
+		when("remoteErr is an error", func() {
+			it("returns the error, and doesn't write any output", func() {
+				expectedErr := errors.New("failed to retrieve remote info")
+
+				dotWriter := writer.NewDOT()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := dotWriter.Print(logger, localRunImages, localInfo, remoteInfo, nil, expectedErr, sharedBuilderInfo)
+				assert.ErrorWithMessage(err, "preparing output for 'test-builder': failed to retrieve remote info")
+
+				assert.Equal(outBuf.String(), "")
+			})
+		})
+	})
+}
@@ -46,6 +46,10 @@ func (f *Factory) Writer(kind string) (BuilderWriter, error) {
 		return NewYAML(), nil
 	case "toml":
 		return NewTOML(), nil
+	case "dot":
+		return NewDOT(), nil
+	case "mermaid":
+		return NewMermaid(), nil
 	}
 
 	return nil, fmt.Errorf("output format %s is not supported", style.Symbol(kind))
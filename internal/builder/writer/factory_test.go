@@ -81,6 +81,36 @@ func testFactory(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("output format is dot", func() {
+			it("return a DOT writer", func() {
+				factory := writer.NewFactory()
+
+				returnedWriter, err := factory.Writer("dot")
+				assert.Nil(err)
+
+				_, ok := returnedWriter.(*writer.DOT)
+				assert.TrueWithMessage(
+					ok,
+					fmt.Sprintf("expected %T to be assignable to type `*writer.DOT`", returnedWriter),
+				)
+			})
+		})
+
+		when("output format is mermaid", func() {
+			it("return a Mermaid writer", func() {
+				factory := writer.NewFactory()
+
+				returnedWriter, err := factory.Writer("mermaid")
+				assert.Nil(err)
+
+				_, ok := returnedWriter.(*writer.Mermaid)
+				assert.TrueWithMessage(
+					ok,
+					fmt.Sprintf("expected %T to be assignable to type `*writer.Mermaid`", returnedWriter),
+				)
+			})
+		})
+
 		when("output format is not supported", func() {
 			it("returns an error", func() {
 				factory := writer.NewFactory()
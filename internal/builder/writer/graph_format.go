@@ -0,0 +1,177 @@
+package writer
+
+import (
+	"fmt"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type GraphNodeKind int
+
+const (
+	BuilderNode GraphNodeKind = iota
+	OrderNode
+	GroupNode
+	ModuleNode
+)
+
+type GraphNode struct {
+	ID    string
+	Label string
+	Kind  GraphNodeKind
+}
+
+type GraphEdge struct {
+	From     string
+	To       string
+	Label    string
+	Optional bool
+	Cyclical bool
+}
+
+// OrderGraph is the detection order of a builder flattened into nodes and edges. Modules are
+// represented by a single node no matter how many groups reference them, so a module shared
+// between groups shows up as a node with several incoming edges.
+type OrderGraph struct {
+	Name  string
+	Nodes []GraphNode
+	Edges []GraphEdge
+
+	moduleNodes map[string]string
+	expanded    map[string]bool
+	groupCount  int
+}
+
+func NewOrderGraph(name string, order, orderExtensions pubbldr.DetectionOrder) *OrderGraph {
+	g := &OrderGraph{
+		Name:        name,
+		moduleNodes: map[string]string{},
+		expanded:    map[string]bool{},
+	}
+
+	g.addNode(GraphNode{ID: "builder", Label: name, Kind: BuilderNode})
+
+	g.addNode(GraphNode{ID: "order", Label: "Detection Order", Kind: OrderNode})
+	g.Edges = append(g.Edges, GraphEdge{From: "builder", To: "order"})
+	g.addOrder("order", "bp", order)
+
+	if len(orderExtensions) > 0 {
+		g.addNode(GraphNode{ID: "order_extensions", Label: "Detection Order (Extensions)", Kind: OrderNode})
+		g.Edges = append(g.Edges, GraphEdge{From: "builder", To: "order_extensions"})
+		g.addOrder("order_extensions", "ext", orderExtensions)
+	}
+
+	return g
+}
+
+func (g *OrderGraph) addNode(node GraphNode) {
+	g.Nodes = append(g.Nodes, node)
+}
+
+func (g *OrderGraph) addGroup(label string) string {
+	g.groupCount++
+	id := fmt.Sprintf("group_%d", g.groupCount)
+	g.addNode(GraphNode{ID: id, Label: label, Kind: GroupNode})
+	return id
+}
+
+func (g *OrderGraph) moduleNode(namespace string, entry pubbldr.DetectionOrderEntry) string {
+	key := namespace + "/" + entry.FullName()
+	if id, ok := g.moduleNodes[key]; ok {
+		return id
+	}
+
+	id := fmt.Sprintf("%s_%d", namespace, len(g.moduleNodes)+1)
+	g.moduleNodes[key] = id
+	g.addNode(GraphNode{ID: id, Label: entry.FullName(), Kind: ModuleNode})
+	return id
+}
+
+func (g *OrderGraph) addOrder(parentID, namespace string, order pubbldr.DetectionOrder) {
+	for i, entry := range order {
+		groupNumber := i + 1
+		groupID := g.addGroup(fmt.Sprintf("Group #%d", groupNumber))
+		g.Edges = append(g.Edges, GraphEdge{From: parentID, To: groupID, Label: fmt.Sprintf("%d", groupNumber)})
+
+		group := entry.GroupDetectionOrder
+		if len(group) == 0 && entry.ID != "" {
+			// extension orders are flattened to a single module per group
+			group = pubbldr.DetectionOrder{entry}
+		}
+		g.addGroupEntries(groupID, namespace, group)
+	}
+}
+
+func (g *OrderGraph) addGroupEntries(groupID, namespace string, entries pubbldr.DetectionOrder) {
+	linked := map[string]bool{}
+	compositeGroups := map[string]int{}
+
+	for _, entry := range entries {
+		moduleID := g.moduleNode(namespace, entry)
+
+		if !linked[moduleID] {
+			linked[moduleID] = true
+			g.Edges = append(g.Edges, GraphEdge{
+				From:     groupID,
+				To:       moduleID,
+				Optional: entry.Optional,
+				Cyclical: entry.Cyclical,
+			})
+		}
+
+		if len(entry.GroupDetectionOrder) == 0 {
+			continue
+		}
+
+		// a composite module contributes one entry per group in its own order
+		compositeGroups[moduleID]++
+		groupNumber := compositeGroups[moduleID]
+
+		expandedKey := fmt.Sprintf("%s#%d", moduleID, groupNumber)
+		if g.expanded[expandedKey] {
+			continue
+		}
+		g.expanded[expandedKey] = true
+
+		childGroupID := g.addGroup(fmt.Sprintf("Group #%d", groupNumber))
+		g.Edges = append(g.Edges, GraphEdge{From: moduleID, To: childGroupID, Label: fmt.Sprintf("%d", groupNumber)})
+		g.addGroupEntries(childGroupID, namespace, entry.GroupDetectionOrder)
+	}
+}
+
+type GraphFormat struct {
+	RenderFunc func(*OrderGraph) string
+}
+
+func (w *GraphFormat) Print(
+	logger logging.Logger,
+	localRunImages []config.RunImage,
+	local, remote *client.BuilderInfo,
+	localErr, remoteErr error,
+	builderInfo SharedBuilderInfo,
+) error {
+	if localErr != nil {
+		return fmt.Errorf("preparing output for %s: %w", style.Symbol(builderInfo.Name), localErr)
+	}
+
+	if remoteErr != nil {
+		return fmt.Errorf("preparing output for %s: %w", style.Symbol(builderInfo.Name), remoteErr)
+	}
+
+	info := remote
+	if info == nil {
+		info = local
+	}
+
+	if info == nil {
+		return fmt.Errorf("unable to find builder %s locally or remotely", style.Symbol(builderInfo.Name))
+	}
+
+	logger.Info(w.RenderFunc(NewOrderGraph(builderInfo.Name, info.Order, info.OrderExtensions)))
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package writer
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Mermaid struct {
+	GraphFormat
+}
+
+func NewMermaid() BuilderWriter {
+	return &Mermaid{
+		GraphFormat: GraphFormat{
+			RenderFunc: renderMermaid,
+		},
+	}
+}
+
+func renderMermaid(g *OrderGraph) string {
+	var b strings.Builder
+
+	b.WriteString("flowchart LR\n")
+
+	for _, node := range g.Nodes {
+		open, closing := mermaidShape(node.Kind)
+		fmt.Fprintf(&b, "  %s%s%s%s\n", node.ID, open, mermaidQuote(node.Label), closing)
+	}
+
+	for _, edge := range g.Edges {
+		arrow := "-->"
+		if edge.Optional || edge.Cyclical {
+			arrow = "-.->"
+		}
+
+		if label := edgeLabel(edge); label != "" {
+			fmt.Fprintf(&b, "  %s %s|%s| %s\n", edge.From, arrow, mermaidQuote(label), edge.To)
+		} else {
+			fmt.Fprintf(&b, "  %s %s %s\n", edge.From, arrow, edge.To)
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func mermaidShape(kind GraphNodeKind) (string, string) {
+	switch kind {
+	case BuilderNode:
+		return "[[", "]]"
+	case OrderNode:
+		return "[/", "/]"
+	case GroupNode:
+		return "([", "])"
+	default:
+		return "[", "]"
+	}
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,79 @@
+package writer_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder/writer"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestMermaid(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Builder Writer", testMermaid, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testMermaid(t *testing.T, when spec.G, it spec.S) {
+	var (
+		assert = h.NewAssertionManager(t)
+		outBuf bytes.Buffer
+
+		remoteInfo *client.BuilderInfo
+	)
+
+	it.Before(func() {
+		outBuf = bytes.Buffer{}
+
+		remoteInfo = &client.BuilderInfo{
+			Buildpacks:      buildpacks,
+			Order:           order,
+			Extensions:      extensions,
+			OrderExtensions: orderExtensions,
+		}
+	})
+
+	when("Print", func() {
+		it("renders the detection order as a flowchart", func() {
+			mermaidWriter := writer.NewMermaid()
+
+			logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+			err := mermaidWriter.Print(logger, localRunImages, nil, remoteInfo, nil, nil, sharedBuilderInfo)
+			assert.Nil(err)
+
+			assert.ContainsAll(outBuf.String(),
+				"flowchart LR\n",
+				`builder[["test-builder"]]`,
+				`group_1(["Group #1"])`,
+				`bp_1["test.top.nested@test.top.nested.version"]`,
+				`order -->|"1"| group_1`,
+				`bp_1 -->|"1"| group_2`,
+				`group_3 -.->|"optional"| bp_3`,
+				`group_4 -.->|"optional cyclic"| bp_3`,
+				`group_5 --> bp_4`,
+				`order_extensions -->|"1"| group_6`,
+			)
+		})
+
+		when("localErr is an error", func() {
+			it("returns the error, and doesn't write any output", func() {
+				expectedErr := errors.New("failed to retrieve local info")
+
+				mermaidWriter := writer.NewMermaid()
+
+				logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+				err := mermaidWriter.Print(logger, localRunImages, nil, remoteInfo, expectedErr, nil, sharedBuilderInfo)
+				assert.ErrorWithMessage(err, "preparing output for 'test-builder': failed to retrieve local info")
+
+				assert.Equal(outBuf.String(), "")
+			})
+		})
+	})
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/image"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -39,10 +40,12 @@ type BuildFlags struct {
 	Policy               string
 	Network              string
 	DescriptorPath       string
+	Profile              string
 	DefaultProcessType   string
 	LifecycleImage       string
 	Env                  []string
 	EnvFiles             []string
+	Secrets              []string
 	Buildpacks           []string
 	Extensions           []string
 	Volumes              []string
@@ -56,6 +59,10 @@ type BuildFlags struct {
 	DateTime             string
 	PreBuildpacks        []string
 	PostBuildpacks       []string
+	VersionLock          string
+	Strict               bool
+	VerifyMirrors        bool
+	MirrorTimeout        time.Duration
 }
 
 // Build an image from source code
@@ -80,13 +87,16 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 
 			inputPreviousImage := client.ParseInputImageReference(flags.PreviousImage)
 
-			descriptor, actualDescriptorPath, err := parseProjectToml(flags.AppPath, flags.DescriptorPath, logger)
+			descriptor, actualDescriptorPath, err := parseProjectToml(flags.AppPath, flags.DescriptorPath, flags.Profile, logger)
 			if err != nil {
 				return err
 			}
 
 			if actualDescriptorPath != "" {
 				logger.Debugf("Using project descriptor located at %s", style.Symbol(actualDescriptorPath))
+				if flags.Profile != "" {
+					logger.Infof("Using build profile %s", style.Symbol(flags.Profile))
+				}
 			}
 
 			builder := flags.Builder
@@ -157,6 +167,13 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 			if err != nil {
 				return errors.Wrapf(err, "parsing creation time %s", flags.DateTime)
 			}
+
+			var versionLock *buildpack.VersionLock
+			if flags.VersionLock != "" {
+				if versionLock, err = buildpack.ReadVersionLock(flags.VersionLock); err != nil {
+					return err
+				}
+			}
 			if err := packClient.Build(cmd.Context(), client.BuildOptions{
 				AppPath:           flags.AppPath,
 				Builder:           builder,
@@ -165,6 +182,7 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 				AdditionalTags:    flags.AdditionalTags,
 				RunImage:          flags.RunImage,
 				Env:               env,
+				Secrets:           flags.Secrets,
 				Image:             inputImageName.Name(),
 				Publish:           flags.Publish,
 				DockerHost:        flags.DockerHost,
@@ -202,9 +220,18 @@ func Build(logger logging.Logger, cfg config.Config, packClient PackClient) *cob
 					PreviousInputImage: inputPreviousImage,
 					LayoutRepoDir:      cfg.LayoutRepositoryDir,
 				},
+				VersionLock:           versionLock,
+				Strict:                flags.Strict,
+				VerifyRunImageMirrors: flags.VerifyMirrors,
+				RunImageMirrorTimeout: flags.MirrorTimeout,
 			}); err != nil {
 				return errors.Wrap(err, "failed to build")
 			}
+			if versionLock != nil {
+				if err := versionLock.Write(flags.VersionLock); err != nil {
+					return err
+				}
+			}
 			logger.Infof("Successfully built image %s", style.Symbol(inputImageName.Name()))
 			return nil
 		}),
@@ -235,7 +262,7 @@ func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Co
 	cmd.Flags().StringVarP(&buildFlags.AppPath, "path", "p", "", "Path to app dir or zip-formatted file (defaults to current working directory)")
 	cmd.Flags().StringSliceVarP(&buildFlags.Buildpacks, "buildpack", "b", nil, "Buildpack to use. One of:\n  a buildpack by id and version in the form of '<buildpack>@<version>',\n  path to a buildpack directory (not supported on Windows),\n  path/URL to a buildpack .tar or .tgz file, or\n  a packaged buildpack image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("buildpack"))
 	cmd.Flags().StringSliceVarP(&buildFlags.Extensions, "extension", "", nil, "Extension to use. One of:\n  an extension by id and version in the form of '<extension>@<version>',\n  path to an extension directory (not supported on Windows),\n  path/URL to an extension .tar or .tgz file, or\n  a packaged extension image name in the form of '<hostname>/<repo>[:<tag>]'"+stringSliceHelp("extension"))
-	cmd.Flags().StringVarP(&buildFlags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image")
+	cmd.Flags().StringVarP(&buildFlags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image, or a builder stored as a file, in the form 'oci:<path-to-layout>' or 'docker-archive:<path-to-tar>'")
 	cmd.Flags().Var(&buildFlags.Cache, "cache",
 		`Cache options used to define cache techniques for build process.
 - Cache as bind: 'type=<build/launch>;format=bind;source=<path to directory>'
@@ -247,8 +274,10 @@ func buildCommandFlags(cmd *cobra.Command, buildFlags *BuildFlags, cfg config.Co
 	cmd.Flags().BoolVar(&buildFlags.ClearCache, "clear-cache", false, "Clear image's associated cache before building")
 	cmd.Flags().StringVar(&buildFlags.DateTime, "creation-time", "", "Desired create time in the output image config. Accepted values are Unix timestamps (e.g., '1641013200'), or 'now'. Platform API version must be at least 0.9 to use this feature.")
 	cmd.Flags().StringVarP(&buildFlags.DescriptorPath, "descriptor", "d", "", "Path to the project descriptor file")
+	cmd.Flags().StringVar(&buildFlags.Profile, "profile", "", "Build profile declared in the project descriptor as [io.buildpacks.profiles.<name>] to use")
 	cmd.Flags().StringVarP(&buildFlags.DefaultProcessType, "default-process", "D", "", `Set the default process type. (default "web")`)
 	cmd.Flags().StringArrayVarP(&buildFlags.Env, "env", "e", []string{}, "Build-time environment variable, in the form 'VAR=VALUE' or 'VAR'.\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed.\nThis flag may be specified multiple times and will override\n  individual values defined by --env-file."+stringArrayHelp("env")+"\nNOTE: These are NOT available at image runtime.")
+	cmd.Flags().StringArrayVar(&buildFlags.Secrets, "secret", []string{}, "Secret of the project descriptor allowed to be read from an environment variable, by name.\nSecrets are only provided to the build and are never stored in an image."+stringArrayHelp("secret"))
 	cmd.Flags().StringArrayVar(&buildFlags.EnvFiles, "env-file", []string{}, "Build-time environment variables file\nOne variable per line, of the form 'VAR=VALUE' or 'VAR'\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed\nNOTE: These are NOT available at image runtime.\"")
 	cmd.Flags().StringVar(&buildFlags.Network, "network", "", "Connect detect and build containers to network")
 	cmd.Flags().StringArrayVar(&buildFlags.PreBuildpacks, "pre-buildpack", []string{}, "Buildpacks to prepend to the groups in the builder's order")
@@ -265,6 +294,8 @@ This option may set DOCKER_HOST environment variable for the build container if
 	cmd.Flags().StringVar(&buildFlags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "always")`)
 	cmd.Flags().StringVarP(&buildFlags.Registry, "buildpack-registry", "r", cfg.DefaultRegistryName, "Buildpack Registry by name")
 	cmd.Flags().StringVar(&buildFlags.RunImage, "run-image", "", "Run image (defaults to default stack's run image)")
+	cmd.Flags().BoolVar(&buildFlags.VerifyMirrors, "verify-run-image-mirrors", false, "Only use a run image mirror with the same digest as the run image, skipping mirrors that time out")
+	cmd.Flags().DurationVar(&buildFlags.MirrorTimeout, "run-image-mirror-timeout", 0, "How long the run image and each mirror may take to respond with --verify-run-image-mirrors (default 10s)")
 	cmd.Flags().StringSliceVarP(&buildFlags.AdditionalTags, "tag", "t", nil, "Additional tags to push the output image to.\nTags should be in the format 'image:tag' or 'repository/image:tag'."+stringSliceHelp("tag"))
 	cmd.Flags().BoolVar(&buildFlags.TrustBuilder, "trust-builder", false, "Trust the provided builder.\nAll lifecycle phases will be run in a single container.\nFor more on trusted builders, and when to trust or untrust a builder, check out our docs here: https://buildpacks.io/docs/tools/pack/concepts/trusted_builders")
 	cmd.Flags().StringArrayVar(&buildFlags.Volumes, "volume", nil, "Mount host volume into the build container, in the form '<host path>:<target path>[:<options>]'.\n- 'host path': Name of the volume or absolute directory path to mount.\n- 'target path': The path where the file or directory is available in the container.\n- 'options' (default \"ro\"): An optional comma separated list of mount options.\n    - \"ro\", volume contents are read-only.\n    - \"rw\", volume contents are readable and writeable.\n    - \"volume-opt=<key>=<value>\", can be specified more than once, takes a key-value pair consisting of the option name and its value."+stringArrayHelp("volume"))
@@ -273,6 +304,8 @@ This option may set DOCKER_HOST environment variable for the build container if
 	cmd.Flags().IntVar(&buildFlags.UID, "uid", 0, `Override UID of user in the stack's build and run images. The provided value must be a positive number`)
 	cmd.Flags().StringVar(&buildFlags.PreviousImage, "previous-image", "", "Set previous image to a particular tag reference, digest reference, or (when performing a daemon build) image ID")
 	cmd.Flags().StringVar(&buildFlags.SBOMDestinationDir, "sbom-output-dir", "", "Path to export SBoM contents.\nOmitting the flag will yield no SBoM content.")
+	cmd.Flags().StringVar(&buildFlags.VersionLock, "version-lock", "", "Path to a file pinning the versions that buildpack version constraints (ie. '^1.4') resolve to; created or updated after a successful build")
+	cmd.Flags().BoolVar(&buildFlags.Strict, "strict", false, "Fail instead of warning when deprecated buildpacks or extensions are used")
 	cmd.Flags().StringVar(&buildFlags.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
 	cmd.Flags().BoolVar(&buildFlags.Interactive, "interactive", false, "Launch a terminal UI to depict the build process")
 	cmd.Flags().BoolVar(&buildFlags.Sparse, "sparse", false, "Use this flag to avoid saving on disk the run-image layers when the application image is exported to OCI layout format")
@@ -367,7 +400,7 @@ func addEnvVar(env map[string]string, item string) map[string]string {
 	return env
 }
 
-func parseProjectToml(appPath, descriptorPath string, logger logging.Logger) (projectTypes.Descriptor, string, error) {
+func parseProjectToml(appPath, descriptorPath, profile string, logger logging.Logger) (projectTypes.Descriptor, string, error) {
 	actualPath := descriptorPath
 	computePath := descriptorPath == ""
 
@@ -377,11 +410,14 @@ func parseProjectToml(appPath, descriptorPath string, logger logging.Logger) (pr
 
 	if _, err := os.Stat(actualPath); err != nil {
 		if computePath {
+			if profile != "" {
+				return projectTypes.Descriptor{}, "", errors.Errorf("build profile %s can't be used without a project descriptor, none was found at %s", style.Symbol(profile), actualPath)
+			}
 			return projectTypes.Descriptor{}, "", nil
 		}
 		return projectTypes.Descriptor{}, "", errors.Wrap(err, "stat project descriptor")
 	}
 
-	descriptor, err := project.ReadProjectDescriptor(actualPath, logger)
+	descriptor, err := project.ReadProjectDescriptorWithProfile(actualPath, profile, logger)
 	return descriptor, actualPath, err
 }
@@ -137,6 +137,17 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--verify-run-image-mirrors", func() {
+			it("forwards the option and mirror timeout onto the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithVerifiedRunImageMirrors(5*time.Second)).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--verify-run-image-mirrors", "--run-image-mirror-timeout", "5s"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
 		when("a network is given", func() {
 			it("forwards the network onto the client", func() {
 				mockClient.EXPECT().
@@ -213,6 +224,17 @@ func testBuildCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("secrets are allowed", func() {
+			it("passes them to the client", func() {
+				mockClient.EXPECT().
+					Build(gomock.Any(), EqBuildOptionsWithSecrets([]string{"TOKEN", "OTHER_TOKEN"})).
+					Return(nil)
+
+				command.SetArgs([]string{"image", "--builder", "my-builder", "--secret", "TOKEN", "--secret", "OTHER_TOKEN"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
 		when("volume mounts are specified", func() {
 			it("mounts the volumes", func() {
 				mockClient.EXPECT().
@@ -621,6 +643,47 @@ builder = "my-builder"
 				})
 			})
 
+			when("file has build profiles", func() {
+				var projectTomlPath string
+
+				it.Before(func() {
+					projectToml, err := os.CreateTemp("", "project.toml")
+					h.AssertNil(t, err)
+					defer projectToml.Close()
+
+					projectToml.WriteString(`
+[_]
+schema-version = "0.2"
+
+[io.buildpacks]
+builder = "dev-builder"
+
+[io.buildpacks.profiles.prod]
+builder = "prod-builder"
+`)
+					projectTomlPath = projectToml.Name()
+				})
+
+				it.After(func() {
+					h.AssertNil(t, os.RemoveAll(projectTomlPath))
+				})
+
+				it("should build an image with the builder of the selected profile", func() {
+					mockClient.EXPECT().
+						Build(gomock.Any(), EqBuildOptionsWithBuilder("prod-builder")).
+						Return(nil)
+
+					command.SetArgs([]string{"--descriptor", projectTomlPath, "--profile", "prod", "image"})
+					h.AssertNil(t, command.Execute())
+					h.AssertContains(t, outBuf.String(), "Using build profile 'prod'")
+				})
+
+				it("should fail when the profile isn't defined", func() {
+					command.SetArgs([]string{"--descriptor", projectTomlPath, "--profile", "staging", "image"})
+					h.AssertError(t, command.Execute(), "profile 'staging' is not defined")
+				})
+			})
+
 			when("file is invalid", func() {
 				var projectTomlPath string
 
@@ -679,6 +742,11 @@ builder = "my-builder"
 				})
 
 				when("project.toml does NOT exist in source repo", func() {
+					it("should fail when a profile is selected", func() {
+						command.SetArgs([]string{"--builder", "my-builder", "--profile", "prod", "image"})
+						h.AssertError(t, command.Execute(), "build profile 'prod' can't be used without a project descriptor")
+					})
+
 					it("should use empty descriptor", func() {
 						mockClient.EXPECT().
 							Build(gomock.Any(), EqBuildOptionsWithEnv(map[string]string{})).
@@ -1023,6 +1091,15 @@ func EqBuildOptionsWithNetwork(network string) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithVerifiedRunImageMirrors(timeout time.Duration) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("VerifyRunImageMirrors=true RunImageMirrorTimeout=%s", timeout),
+		equals: func(o client.BuildOptions) bool {
+			return o.VerifyRunImageMirrors && o.RunImageMirrorTimeout == timeout
+		},
+	}
+}
+
 func EqBuildOptionsWithBuilder(builder string) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("Builder=%s", builder),
@@ -1050,6 +1127,15 @@ func EqBuildOptionsWithVolumes(volumes []string) gomock.Matcher {
 	}
 }
 
+func EqBuildOptionsWithSecrets(secrets []string) gomock.Matcher {
+	return buildOptionsMatcher{
+		description: fmt.Sprintf("Secrets=%s", secrets),
+		equals: func(o client.BuildOptions) bool {
+			return reflect.DeepEqual(o.Secrets, secrets)
+		},
+	}
+}
+
 func EqBuildOptionsWithAdditionalTags(additionalTags []string) gomock.Matcher {
 	return buildOptionsMatcher{
 		description: fmt.Sprintf("AdditionalTags=%s", additionalTags),
@@ -1061,7 +1147,7 @@ func EqBuildOptionsWithAdditionalTags(additionalTags []string) gomock.Matcher {
 
 func EqBuildOptionsWithProjectDescriptor(descriptor projectTypes.Descriptor) gomock.Matcher {
 	return buildOptionsMatcher{
-		description: fmt.Sprintf("Descriptor=%s", descriptor),
+		description: fmt.Sprintf("Descriptor=%v", descriptor),
 		equals: func(o client.BuildOptions) bool {
 			return reflect.DeepEqual(o.ProjectDescriptor, descriptor)
 		},
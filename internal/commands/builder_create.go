@@ -25,6 +25,8 @@ type BuilderCreateFlags struct {
 	Flatten         []string
 	Targets         []string
 	Label           map[string]string
+	VersionLock     string
+	Strict          bool
 }
 
 // CreateBuilder creates a builder image, based on a builder config
@@ -97,6 +99,13 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 				logger.Infof("Pro tip: use --targets flag OR [[targets]] in builder.toml to specify the desired platform")
 			}
 
+			var versionLock *buildpack.VersionLock
+			if flags.VersionLock != "" {
+				if versionLock, err = buildpack.ReadVersionLock(flags.VersionLock); err != nil {
+					return err
+				}
+			}
+
 			imageName := args[0]
 			if err := pack.CreateBuilder(cmd.Context(), client.CreateBuilderOptions{
 				RelativeBaseDir: relativeBaseDir,
@@ -109,9 +118,16 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 				Flatten:         toFlatten,
 				Labels:          flags.Label,
 				Targets:         multiArchCfg.Targets(),
+				VersionLock:     versionLock,
+				Strict:          flags.Strict,
 			}); err != nil {
 				return err
 			}
+			if versionLock != nil {
+				if err := versionLock.Write(flags.VersionLock); err != nil {
+					return err
+				}
+			}
 			logger.Infof("Successfully created builder image %s", style.Symbol(imageName))
 			logging.Tip(logger, "Run %s to use this builder", style.Symbol(fmt.Sprintf("pack build <image-name> --builder %s", imageName)))
 			return nil
@@ -127,6 +143,8 @@ Creating a custom builder allows you to control what buildpacks are used and wha
 	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
 	cmd.Flags().StringArrayVar(&flags.Flatten, "flatten", nil, "List of buildpacks to flatten together into a single layer (format: '<buildpack-id>@<buildpack-version>,<buildpack-id>@<buildpack-version>'")
 	cmd.Flags().StringToStringVarP(&flags.Label, "label", "l", nil, "Labels to add to the builder image, in the form of '<name>=<value>'")
+	cmd.Flags().StringVar(&flags.VersionLock, "version-lock", "", "Path to a file pinning the versions that version constraints (ie. '^1.4') resolve to; created or updated after a successful run")
+	cmd.Flags().BoolVar(&flags.Strict, "strict", false, "Fail instead of warning when the builder config uses deprecated buildpacks or extensions")
 	cmd.Flags().StringSliceVarP(&flags.Targets, "target", "t", nil,
 		`Target platforms to build for.\nTargets should be in the format '[os][/arch][/variant]:[distroname@osversion@anotherversion];[distroname@osversion]'.
 - To specify two different architectures:  '--target "linux/amd64" --target "linux/arm64"'
@@ -2,6 +2,7 @@ package commands_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -18,6 +19,7 @@ import (
 	"github.com/buildpacks/pack/internal/commands"
 	"github.com/buildpacks/pack/internal/commands/testmocks"
 	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -445,6 +447,60 @@ func testCreateCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("--version-lock", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(validConfig), 0666))
+			})
+
+			it("passes the lock and writes the resolved versions", func() {
+				lockPath := filepath.Join(tmpDir, "pack.lock")
+				h.AssertNil(t, os.WriteFile(lockPath, []byte(`[[modules]]
+  id = "some/bp"
+  constraint = "^1.0"
+  version = "1.0.0"
+`), 0666))
+
+				mockClient.EXPECT().CreateBuilder(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, opts client.CreateBuilderOptions) error {
+						h.AssertEq(t, opts.VersionLock.Modules, []buildpack.LockedModule{{ID: "some/bp", Constraint: "^1.0", Version: "1.0.0"}})
+						opts.VersionLock.Pin("some/other-bp", "~2.1", "2.1.5")
+						return nil
+					})
+
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--version-lock", lockPath,
+				})
+				h.AssertNil(t, command.Execute())
+
+				lock, err := buildpack.ReadVersionLock(lockPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, lock.Modules, []buildpack.LockedModule{
+					{ID: "some/bp", Constraint: "^1.0", Version: "1.0.0"},
+					{ID: "some/other-bp", Constraint: "~2.1", Version: "2.1.5"},
+				})
+			})
+		})
+
+		when("--strict", func() {
+			it("fails on deprecated modules", func() {
+				h.AssertNil(t, os.WriteFile(builderConfigPath, []byte(validConfig), 0666))
+				mockClient.EXPECT().CreateBuilder(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, opts client.CreateBuilderOptions) error {
+						h.AssertTrue(t, opts.Strict)
+						return nil
+					})
+
+				command.SetArgs([]string{
+					"some/builder",
+					"--config", builderConfigPath,
+					"--strict",
+				})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
 		when("--label", func() {
 			when("can not be parsed", func() {
 				it("errors with a descriptive message", func() {
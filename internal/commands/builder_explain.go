@@ -0,0 +1,214 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// detectOutcomesFile is looked up in the app directory when no outcomes file is provided
+const detectOutcomesFile = "detect-outcomes.toml"
+
+type BuilderExplainFlags struct {
+	AppPath        string
+	OutcomesPath   string
+	DefaultOutcome string
+	Policy         string
+	Pass           []string
+	Fail           []string
+}
+
+// BuilderExplain simulates detection against the order of a builder
+func BuilderExplain(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuilderExplainFlags
+
+	cmd := &cobra.Command{
+		Use:   "explain <builder-image-name>",
+		Args:  cobra.ExactArgs(1),
+		Short: "Show which group of the builder order would pass detection",
+		Example: "pack builder explain cnbs/sample-builder:bionic --pass paketo-buildpacks/node-engine --pass paketo-buildpacks/npm-install\n" +
+			"pack builder explain cnbs/sample-builder:bionic --path apps/test-app",
+		Long: "Evaluates the order of a builder against assumed detect outcomes, without running any containers.\n\n" +
+			"Outcomes are read from the file provided with --outcomes, or from '" + detectOutcomesFile + "' in the app directory, " +
+			"and can be extended with --pass and --fail. The file has the form:\n\n" +
+			"  default = \"fail\"\n" +
+			"  pass = [\"some/buildpack\", \"other/buildpack@1.2.3\"]\n" +
+			"  fail = [\"failing/buildpack\"]\n\n" +
+			"Buildpacks are referenced by id, matching any version, or by id@version. Buildpacks that are not referenced " +
+			"get the default outcome. The build plan is not considered, so results may differ from a real build when " +
+			"buildpacks have unmet requirements.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			outcomes, err := detectOutcomes(flags)
+			if err != nil {
+				return err
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy := image.PullIfNotPresent
+			if stringPolicy != "" {
+				if pullPolicy, err = image.ParsePullPolicy(stringPolicy); err != nil {
+					return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+				}
+			}
+
+			simulation, err := pack.ExplainBuilder(cmd.Context(), client.ExplainBuilderOptions{
+				BuilderName: args[0],
+				PullPolicy:  pullPolicy,
+				Outcomes:    outcomes,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "explaining builder %s", style.Symbol(args[0]))
+			}
+
+			return writeDetectionSimulation(logger, args[0], simulation)
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.AppPath, "path", "p", "", "Path to app dir to look up '"+detectOutcomesFile+"' in (defaults to current working directory)")
+	cmd.Flags().StringVar(&flags.OutcomesPath, "outcomes", "", "Path to a file with the assumed detect outcome of each buildpack")
+	cmd.Flags().StringSliceVar(&flags.Pass, "pass", nil, "Buildpack assumed to pass detection, in the form '<buildpack>' or '<buildpack>@<version>'"+stringSliceHelp("pass"))
+	cmd.Flags().StringSliceVar(&flags.Fail, "fail", nil, "Buildpack assumed to fail detection, in the form '<buildpack>' or '<buildpack>@<version>'"+stringSliceHelp("fail"))
+	cmd.Flags().StringVar(&flags.DefaultOutcome, "default-outcome", "", `Outcome of buildpacks that are not referenced, either pass or fail (default "fail")`)
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "if-not-present")`)
+	AddHelpFlag(cmd, "explain")
+	return cmd
+}
+
+func detectOutcomes(flags BuilderExplainFlags) (builder.DetectOutcomes, error) {
+	var (
+		outcomes builder.DetectOutcomes
+		err      error
+	)
+
+	path := flags.OutcomesPath
+	if path == "" {
+		path = filepath.Join(flags.AppPath, detectOutcomesFile)
+		if _, statErr := os.Stat(path); statErr != nil {
+			path = ""
+		}
+	}
+
+	if path != "" {
+		if outcomes, err = builder.ReadDetectOutcomes(path); err != nil {
+			return builder.DetectOutcomes{}, err
+		}
+	}
+
+	outcomes.Pass = append(outcomes.Pass, flags.Pass...)
+	outcomes.Fail = append(outcomes.Fail, flags.Fail...)
+	if flags.DefaultOutcome != "" {
+		outcomes.Default = flags.DefaultOutcome
+	}
+
+	return outcomes, outcomes.Validate()
+}
+
+func writeDetectionSimulation(logger logging.Logger, builderName string, simulation *builder.DetectionSimulation) error {
+	logger.Infof("Simulating detection for builder %s\n", style.Symbol(builderName))
+
+	buf := &bytes.Buffer{}
+	tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	for i, group := range simulation.Groups {
+		fmt.Fprintf(tw, "Group %d [%s]: %s\n", i+1, groupNames(group.Group), groupStatus(group, simulation.Chosen))
+
+		for j, trial := range group.Trials {
+			result := "passed"
+			if !trial.Passed {
+				result = "failed, " + trial.Reason
+			}
+			if len(group.Trials) > 1 {
+				fmt.Fprintf(tw, "  Trial %d: %s\n", j+1, result)
+			} else {
+				fmt.Fprintf(tw, "  %s\n", strings.ToUpper(result[:1])+result[1:])
+			}
+
+			for _, bp := range trial.Buildpacks {
+				fmt.Fprintf(tw, "    %s\t%s%s\n", outcomeName(bp.Passed), bp.FullName(), trialBuildpackNotes(bp, trial.Passed))
+			}
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, "flushing tab writer")
+	}
+	logger.Info(strings.TrimSuffix(buf.String(), "\n"))
+
+	if simulation.Chosen == -1 {
+		logger.Info("\nResult: no group would pass detection")
+		return nil
+	}
+
+	logger.Infof("\nResult: group %d would be chosen, running:", simulation.Chosen+1)
+	for _, bp := range simulation.Result {
+		logger.Infof("  %s", bp.FullName())
+	}
+
+	return nil
+}
+
+func groupStatus(group builder.SimulatedGroup, chosen int) string {
+	switch {
+	case group.Passed:
+		return "chosen"
+	case group.Skipped:
+		return fmt.Sprintf("skipped, group %d was chosen first", chosen+1)
+	default:
+		return "failed"
+	}
+}
+
+func groupNames(group dist.OrderEntry) string {
+	var names []string
+	for _, ref := range group.Group {
+		name := ref.FullName()
+		if ref.Optional {
+			name += " (optional)"
+		}
+		names = append(names, name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func outcomeName(passed bool) string {
+	if passed {
+		return builder.OutcomePass
+	}
+
+	return builder.OutcomeFail
+}
+
+func trialBuildpackNotes(bp builder.TrialBuildpack, trialPassed bool) string {
+	var notes []string
+	if bp.Optional {
+		notes = append(notes, "optional")
+		if !bp.Passed && trialPassed {
+			notes = append(notes, "dropped")
+		}
+	}
+	for _, via := range bp.Via {
+		notes = append(notes, "via "+via.FullName())
+	}
+
+	if len(notes) == 0 {
+		return ""
+	}
+
+	return " (" + strings.Join(notes, ", ") + ")"
+}
@@ -0,0 +1,136 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuilderExplainCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuilderExplainCommand", testBuilderExplainCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuilderExplainCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		receivedOpts   client.ExplainBuilderOptions
+
+		bpA = dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: "some/bp-a", Version: "1.0.0"}}
+		bpB = dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: "some/bp-b", Version: "1.0.0"}, Optional: true}
+		bpC = dist.ModuleRef{ModuleInfo: dist.ModuleInfo{ID: "some/bp-c", Version: "1.0.0"}}
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		mockClient.EXPECT().
+			ExplainBuilder(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, opts client.ExplainBuilderOptions) (*builder.DetectionSimulation, error) {
+				receivedOpts = opts
+				return &builder.DetectionSimulation{
+					Groups: []builder.SimulatedGroup{
+						{
+							Group: dist.OrderEntry{Group: []dist.ModuleRef{bpC}},
+							Trials: []builder.DetectionTrial{
+								{
+									Buildpacks: []builder.TrialBuildpack{{ModuleRef: bpC}},
+									Reason:     "required buildpack 'some/bp-c@1.0.0' failed detection",
+								},
+							},
+						},
+						{
+							Group: dist.OrderEntry{Group: []dist.ModuleRef{bpA, bpB}},
+							Trials: []builder.DetectionTrial{
+								{
+									Buildpacks: []builder.TrialBuildpack{{ModuleRef: bpA, Passed: true}, {ModuleRef: bpB}},
+									Passed:     true,
+								},
+							},
+							Passed: true,
+						},
+						{
+							Group:   dist.OrderEntry{Group: []dist.ModuleRef{bpA}},
+							Skipped: true,
+						},
+					},
+					Chosen: 1,
+					Result: []dist.ModuleRef{bpA},
+				}, nil
+			}).
+			AnyTimes()
+
+		command = commands.BuilderExplain(logger, config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("explains which group would be chosen", func() {
+		command.SetArgs([]string{"some/builder", "--pass", "some/bp-a"})
+		h.AssertNil(t, command.Execute())
+
+		h.AssertEq(t, receivedOpts.BuilderName, "some/builder")
+		h.AssertEq(t, receivedOpts.PullPolicy, image.PullIfNotPresent)
+		h.AssertEq(t, receivedOpts.Outcomes.Pass, []string{"some/bp-a"})
+
+		output := outBuf.String()
+		h.AssertContains(t, output, "Group 1 [some/bp-c@1.0.0]: failed\n  Failed, required buildpack 'some/bp-c@1.0.0' failed detection\n    fail  some/bp-c@1.0.0")
+		h.AssertContains(t, output, "Group 2 [some/bp-a@1.0.0, some/bp-b@1.0.0 (optional)]: chosen\n  Passed\n    pass  some/bp-a@1.0.0\n    fail  some/bp-b@1.0.0 (optional, dropped)")
+		h.AssertContains(t, output, "Group 3 [some/bp-a@1.0.0]: skipped, group 2 was chosen first")
+		h.AssertContains(t, output, "Result: group 2 would be chosen, running:\n  some/bp-a@1.0.0")
+	})
+
+	when("the app dir has an outcomes file", func() {
+		it("reads outcomes from it, extended by flags", func() {
+			appDir := t.TempDir()
+			h.AssertNil(t, os.WriteFile(filepath.Join(appDir, "detect-outcomes.toml"), []byte(`pass = ["some/bp-b"]`), 0600))
+
+			command.SetArgs([]string{"some/builder", "--path", appDir, "--pass", "some/bp-a", "--default-outcome", "pass"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertEq(t, receivedOpts.Outcomes.Pass, []string{"some/bp-b", "some/bp-a"})
+			h.AssertEq(t, receivedOpts.Outcomes.Default, "pass")
+		})
+	})
+
+	when("the outcomes file doesn't exist", func() {
+		it("errors", func() {
+			command.SetArgs([]string{"some/builder", "--outcomes", filepath.Join(t.TempDir(), "missing.toml")})
+			h.AssertError(t, command.Execute(), "reading detect outcomes")
+		})
+	})
+
+	when("the default outcome is invalid", func() {
+		it("errors", func() {
+			command.SetArgs([]string{"some/builder", "--default-outcome", "maybe"})
+			h.AssertError(t, command.Execute(), "invalid default outcome 'maybe'")
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/builder"
@@ -12,11 +13,14 @@ import (
 
 type BuilderInspector interface {
 	InspectBuilder(name string, daemon bool, modifiers ...client.BuilderInspectionModifier) (*client.BuilderInfo, error)
+	InspectBuilderLayers(name string, opts client.InspectBuilderLayersOptions) (*client.BuilderLayersInfo, error)
 }
 
 type BuilderInspectFlags struct {
 	Depth        int
 	OutputFormat string
+	Layers       bool
+	Flatten      []string
 }
 
 func BuilderInspect(logger logging.Logger,
@@ -43,12 +47,22 @@ func BuilderInspect(logger logging.Logger,
 				return client.NewSoftError()
 			}
 
+			if flags.Layers {
+				return inspectBuilderLayers(logger, imageName, flags, inspector)
+			}
+
+			if len(flags.Flatten) > 0 {
+				return errors.New("'flatten' flag can only be used with 'layers' flag")
+			}
+
 			return inspectBuilder(logger, imageName, flags, cfg, inspector, writerFactory)
 		}),
 	}
 
 	cmd.Flags().IntVarP(&flags.Depth, "depth", "d", builder.OrderDetectionMaxDepth, "Max depth to display for Detection Order.\nOmission of this flag or values < 0 will display the entire tree.")
-	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display builder detail (json, yaml, toml, human-readable).\nOmission of this flag will display as human-readable.")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", "human-readable", "Output format to display builder detail (json, yaml, toml, dot, mermaid, human-readable).\nThe dot and mermaid formats render the detection order as a graph.\nOmission of this flag will display as human-readable.")
+	cmd.Flags().BoolVar(&flags.Layers, "layers", false, "List the layers of the builder with their size and owner instead of the builder metadata.")
+	cmd.Flags().StringArrayVar(&flags.Flatten, "flatten", nil, "With --layers, estimate the savings of flattening buildpacks together into a single layer (format: '<buildpack-id>@<buildpack-version>,<buildpack-id>@<buildpack-version>'")
 	AddHelpFlag(cmd, "inspect")
 	return cmd
 }
@@ -0,0 +1,236 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type builderLayerOutput struct {
+	DiffID  string   `json:"diff_id"`
+	Size    int64    `json:"size"`
+	Owner   string   `json:"owner"`
+	Modules []string `json:"modules,omitempty"`
+}
+
+type flattenEstimateOutput struct {
+	Modules          []string `json:"modules"`
+	Missing          []string `json:"missing,omitempty"`
+	CurrentLayers    int      `json:"current_layers"`
+	CurrentSize      int64    `json:"current_size"`
+	LayersSaved      int      `json:"layers_saved"`
+	ApproximateSaved int64    `json:"approximate_saved"`
+}
+
+type builderLayersOutput struct {
+	BuilderName      string                  `json:"builder_name"`
+	Location         string                  `json:"location"`
+	TotalSize        int64                   `json:"total_size"`
+	Layers           []builderLayerOutput    `json:"layers"`
+	FlattenEstimates []flattenEstimateOutput `json:"flatten_estimates,omitempty"`
+}
+
+func inspectBuilderLayers(
+	logger logging.Logger,
+	imageName string,
+	flags BuilderInspectFlags,
+	inspector BuilderInspector,
+) error {
+	if flags.OutputFormat != "human-readable" && flags.OutputFormat != "json" {
+		return errors.Errorf("output format %s is not supported with the 'layers' flag", style.Symbol(flags.OutputFormat))
+	}
+
+	toFlatten, err := buildpack.ParseFlattenBuildModules(flags.Flatten)
+	if err != nil {
+		return err
+	}
+
+	location := "remote"
+	info, err := inspector.InspectBuilderLayers(imageName, client.InspectBuilderLayersOptions{Flatten: toFlatten})
+	if err != nil {
+		return errors.Wrapf(err, "inspecting remote builder %s", style.Symbol(imageName))
+	}
+
+	if info == nil {
+		location = "local"
+		info, err = inspector.InspectBuilderLayers(imageName, client.InspectBuilderLayersOptions{Daemon: true, Flatten: toFlatten})
+		if err != nil {
+			return errors.Wrapf(err, "inspecting local builder %s", style.Symbol(imageName))
+		}
+	}
+
+	if info == nil {
+		return errors.Errorf("unable to find builder %s locally or remotely", style.Symbol(imageName))
+	}
+
+	output := toBuilderLayersOutput(imageName, location, info)
+
+	if flags.OutputFormat == "json" {
+		buf, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "marshaling builder layers")
+		}
+		logger.Info(string(buf))
+		return nil
+	}
+
+	return writeBuilderLayers(logger, output)
+}
+
+func toBuilderLayersOutput(imageName, location string, info *client.BuilderLayersInfo) builderLayersOutput {
+	output := builderLayersOutput{
+		BuilderName: imageName,
+		Location:    location,
+	}
+
+	for _, layer := range info.Layers {
+		output.Layers = append(output.Layers, builderLayerOutput{
+			DiffID:  layer.DiffID,
+			Size:    layer.Size,
+			Owner:   layerOwner(layer),
+			Modules: moduleNames(layer.Modules),
+		})
+
+		if layer.Size > 0 {
+			output.TotalSize += layer.Size
+		}
+	}
+
+	for _, estimate := range info.FlattenEstimates {
+		output.FlattenEstimates = append(output.FlattenEstimates, flattenEstimateOutput{
+			Modules:          moduleNames(estimate.Modules),
+			Missing:          moduleNames(estimate.Missing),
+			CurrentLayers:    estimate.CurrentLayers,
+			CurrentSize:      estimate.CurrentSize,
+			LayersSaved:      estimate.LayersSaved,
+			ApproximateSaved: estimate.ApproximateSaved,
+		})
+	}
+
+	return output
+}
+
+func writeBuilderLayers(logger logging.Logger, output builderLayersOutput) error {
+	logger.Infof("Inspecting layers of %s builder: %s\n\n", output.Location, style.Symbol(output.BuilderName))
+
+	buf := &bytes.Buffer{}
+	tw := tabwriter.NewWriter(buf, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "  #\tSIZE\tDIFF ID\tOWNER")
+	for i, layer := range output.Layers {
+		owner := layer.Owner
+		if len(layer.Modules) > 0 {
+			owner = fmt.Sprintf("%s: %s", owner, strings.Join(layer.Modules, ", "))
+		}
+		fmt.Fprintf(tw, "  %d\t%s\t%s\t%s\n", i+1, layerSize(layer.Size), shortDiffID(layer.DiffID), owner)
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, "flushing tab writer")
+	}
+
+	logger.Info("Layers:")
+	logger.Info(strings.TrimSuffix(buf.String(), "\n"))
+	logger.Infof("\nTotal: %s in %d layers", humanize.Bytes(uint64(output.TotalSize)), len(output.Layers))
+
+	buf.Reset()
+	tw = tabwriter.NewWriter(buf, 0, 0, 3, ' ', 0)
+	totals, owners := sizeByOwner(output.Layers)
+	for _, owner := range owners {
+		fmt.Fprintf(tw, "  %s\t%s\n", owner, humanize.Bytes(uint64(totals[owner])))
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, "flushing tab writer")
+	}
+
+	logger.Info("\nSize by owner:")
+	logger.Info(strings.TrimSuffix(buf.String(), "\n"))
+
+	if len(output.FlattenEstimates) == 0 {
+		return nil
+	}
+
+	logger.Info("\nFlatten estimates:")
+	for _, estimate := range output.FlattenEstimates {
+		logger.Infof("  %s", strings.Join(estimate.Modules, ", "))
+		if len(estimate.Missing) > 0 {
+			logger.Warnf("    not found on builder: %s", strings.Join(estimate.Missing, ", "))
+		}
+		if estimate.LayersSaved == 0 {
+			logger.Infof("    already in %d layer(s) totaling %s, nothing to save", estimate.CurrentLayers, humanize.Bytes(uint64(estimate.CurrentSize)))
+			continue
+		}
+		logger.Infof(
+			"    %d layers totaling %s would become 1 layer, saving %d layer(s) and approximately %s",
+			estimate.CurrentLayers,
+			humanize.Bytes(uint64(estimate.CurrentSize)),
+			estimate.LayersSaved,
+			humanize.Bytes(uint64(estimate.ApproximateSaved)),
+		)
+	}
+	logger.Infof("\nSavings are approximated as %s of tar headers per layer removed, they are not computed from the layer contents.",
+		humanize.Bytes(builder.TarLayerOverhead))
+
+	return nil
+}
+
+func layerOwner(layer builder.LayerInfo) string {
+	if layer.Flattened() {
+		return fmt.Sprintf("flattened %ss", layer.Owner)
+	}
+
+	return string(layer.Owner)
+}
+
+// sizeByOwner sums the size of layers per owner, preserving the order in which owners first appear
+func sizeByOwner(layers []builderLayerOutput) (map[string]int64, []string) {
+	totals := map[string]int64{}
+	var owners []string
+	for _, layer := range layers {
+		if _, ok := totals[layer.Owner]; !ok {
+			owners = append(owners, layer.Owner)
+			totals[layer.Owner] = 0
+		}
+		if layer.Size > 0 {
+			totals[layer.Owner] += layer.Size
+		}
+	}
+
+	return totals, owners
+}
+
+func moduleNames(modules []dist.ModuleInfo) []string {
+	var names []string
+	for _, m := range modules {
+		names = append(names, m.FullName())
+	}
+
+	return names
+}
+
+func layerSize(size int64) string {
+	if size < 0 {
+		return "unknown"
+	}
+
+	return humanize.Bytes(uint64(size))
+}
+
+func shortDiffID(diffID string) string {
+	const shortLength = len("sha256:") + 12
+	if len(diffID) > shortLength {
+		return diffID[:shortLength]
+	}
+
+	return diffID
+}
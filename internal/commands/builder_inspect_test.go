@@ -19,6 +19,7 @@ import (
 	"github.com/buildpacks/pack/internal/commands/fakes"
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/logging"
 	h "github.com/buildpacks/pack/testhelpers"
 )
@@ -276,6 +277,139 @@ func testBuilderInspectCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("layers flag is provided", func() {
+			var layersInfo *client.BuilderLayersInfo
+
+			it.Before(func() {
+				layersInfo = &client.BuilderLayersInfo{
+					Layers: []builder.LayerInfo{
+						{DiffID: "sha256:1111111111111111111111111111", Size: 2000000, Owner: builder.LayerOwnerBaseImage},
+						{DiffID: "sha256:2222222222222222222222222222", Size: 512, Owner: builder.LayerOwnerDirs},
+						{DiffID: "sha256:3333333333333333333333333333", Size: 30000000, Owner: builder.LayerOwnerLifecycle},
+						{
+							DiffID:  "sha256:4444444444444444444444444444",
+							Size:    1000000,
+							Owner:   builder.LayerOwnerBuildpack,
+							Modules: []dist.ModuleInfo{{ID: "some/bp", Version: "1.2.3"}},
+						},
+						{
+							DiffID:  "sha256:5555555555555555555555555555",
+							Size:    -1,
+							Owner:   builder.LayerOwnerBuildpack,
+							Modules: []dist.ModuleInfo{{ID: "other/bp", Version: "1.0.0"}, {ID: "third/bp", Version: "2.0.0"}},
+						},
+					},
+				}
+			})
+
+			it("lists the layers of the remote builder with their size and owner", func() {
+				builderInspector := newBuilderInspector(returnsLayersForRemote(layersInfo))
+				writerFactory := newDefaultWriterFactory()
+
+				command := commands.BuilderInspect(logger, cfg, builderInspector, writerFactory)
+				command.SetArgs([]string{"some/builder", "--layers"})
+				assert.Nil(command.Execute())
+
+				assert.Equal(builderInspector.ReceivedForRemoteName, "some/builder")
+				assert.Equal(writerFactory.ReceivedForKind, "")
+				assert.Contains(outBuf.String(), "Inspecting layers of remote builder: 'some/builder'")
+				assert.ContainsAll(outBuf.String(),
+					"sha256:111111111111   base image",
+					"sha256:333333333333   lifecycle",
+					"buildpack: some/bp@1.2.3",
+					"unknown   sha256:555555555555   flattened buildpacks: other/bp@1.0.0, third/bp@2.0.0",
+					"Total: 33 MB in 5 layers",
+				)
+				assert.Contains(outBuf.String(), "Size by owner:\n  base image")
+			})
+
+			it("falls back to the local builder", func() {
+				builderInspector := newBuilderInspector(returnsLayersForLocal(layersInfo))
+
+				command := commands.BuilderInspect(logger, cfg, builderInspector, newDefaultWriterFactory())
+				command.SetArgs([]string{"some/builder", "--layers"})
+				assert.Nil(command.Execute())
+
+				assert.Equal(len(builderInspector.ReceivedLayersOptions), 2)
+				assert.Equal(builderInspector.ReceivedLayersOptions[0].Daemon, false)
+				assert.Equal(builderInspector.ReceivedLayersOptions[1].Daemon, true)
+				assert.Contains(outBuf.String(), "Inspecting layers of local builder: 'some/builder'")
+			})
+
+			it("prints flatten estimates", func() {
+				layersInfo.FlattenEstimates = []builder.FlattenEstimate{
+					{
+						Modules:          []dist.ModuleInfo{{ID: "some/bp", Version: "1.2.3"}, {ID: "other/bp", Version: "1.0.0"}},
+						CurrentLayers:    2,
+						CurrentSize:      1000000,
+						LayersSaved:      1,
+						ApproximateSaved: 2048,
+					},
+				}
+				builderInspector := newBuilderInspector(returnsLayersForRemote(layersInfo))
+
+				command := commands.BuilderInspect(logger, cfg, builderInspector, newDefaultWriterFactory())
+				command.SetArgs([]string{"some/builder", "--layers", "--flatten", "some/bp@1.2.3,other/bp@1.0.0"})
+				assert.Nil(command.Execute())
+
+				flatten := builderInspector.ReceivedLayersOptions[0].Flatten.FlattenModules()
+				assert.Equal(len(flatten), 1)
+				assert.Equal(flatten[0].BuildModule(), []dist.ModuleInfo{{ID: "some/bp", Version: "1.2.3"}, {ID: "other/bp", Version: "1.0.0"}})
+				assert.ContainsAll(outBuf.String(),
+					"Flatten estimates:\n  some/bp@1.2.3, other/bp@1.0.0",
+					"2 layers totaling 1.0 MB would become 1 layer, saving 1 layer(s) and approximately 2.0 kB",
+					"Savings are approximated as 2.0 kB of tar headers per layer removed, they are not computed from the layer contents.",
+				)
+			})
+
+			it("supports json output", func() {
+				builderInspector := newBuilderInspector(returnsLayersForRemote(layersInfo))
+
+				command := commands.BuilderInspect(logger, cfg, builderInspector, newDefaultWriterFactory())
+				command.SetArgs([]string{"some/builder", "--layers", "--output", "json"})
+				assert.Nil(command.Execute())
+
+				assert.ContainsJSON(outBuf.String(), `{
+  "builder_name": "some/builder",
+  "location": "remote",
+  "total_size": 33000512,
+  "layers": [
+    {"diff_id": "sha256:1111111111111111111111111111", "size": 2000000, "owner": "base image"},
+    {"diff_id": "sha256:2222222222222222222222222222", "size": 512, "owner": "default directories"},
+    {"diff_id": "sha256:3333333333333333333333333333", "size": 30000000, "owner": "lifecycle"},
+    {"diff_id": "sha256:4444444444444444444444444444", "size": 1000000, "owner": "buildpack", "modules": ["some/bp@1.2.3"]},
+    {"diff_id": "sha256:5555555555555555555555555555", "size": -1, "owner": "flattened buildpacks", "modules": ["other/bp@1.0.0", "third/bp@2.0.0"]}
+  ]
+}`)
+			})
+
+			it("errors when the builder can't be found", func() {
+				command := commands.BuilderInspect(logger, cfg, newDefaultBuilderInspector(), newDefaultWriterFactory())
+				command.SetArgs([]string{"some/builder", "--layers"})
+
+				err := command.Execute()
+				assert.ErrorWithMessage(err, "unable to find builder 'some/builder' locally or remotely")
+			})
+
+			it("errors for unsupported output formats", func() {
+				command := commands.BuilderInspect(logger, cfg, newDefaultBuilderInspector(), newDefaultWriterFactory())
+				command.SetArgs([]string{"some/builder", "--layers", "--output", "dot"})
+
+				err := command.Execute()
+				assert.ErrorWithMessage(err, "output format 'dot' is not supported with the 'layers' flag")
+			})
+		})
+
+		when("flatten flag is provided without the layers flag", func() {
+			it("returns an error", func() {
+				command := commands.BuilderInspect(logger, cfg, newDefaultBuilderInspector(), newDefaultWriterFactory())
+				command.SetArgs([]string{"some/builder", "--flatten", "some/bp@1.2.3"})
+
+				err := command.Execute()
+				assert.ErrorWithMessage(err, "'flatten' flag can only be used with 'layers' flag")
+			})
+		})
+
 		when("writer factory returns an error", func() {
 			it("returns that error", func() {
 				baseError := errors.New("invalid output format")
@@ -367,6 +501,18 @@ func errorsForRemote(err error) BuilderInspectorModifier {
 	}
 }
 
+func returnsLayersForLocal(info *client.BuilderLayersInfo) BuilderInspectorModifier {
+	return func(i *fakes.FakeBuilderInspector) {
+		i.LayersForLocal = info
+	}
+}
+
+func returnsLayersForRemote(info *client.BuilderLayersInfo) BuilderInspectorModifier {
+	return func(i *fakes.FakeBuilderInspector) {
+		i.LayersForRemote = info
+	}
+}
+
 func newBuilderInspector(modifiers ...BuilderInspectorModifier) *fakes.FakeBuilderInspector {
 	i := newDefaultBuilderInspector()
 
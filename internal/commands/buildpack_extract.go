@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildpackExtractFlags define flags provided to the BuildpackExtract command
+type BuildpackExtractFlags struct {
+	Registry string
+}
+
+// BuildpackExtract writes the buildpacks of a buildpack package into per-buildpack directories
+func BuildpackExtract(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuildpackExtractFlags
+
+	cmd := &cobra.Command{
+		Use:   "extract <package> <destination>",
+		Args:  cobra.ExactArgs(2),
+		Short: "Extract the buildpacks of a buildpack package into directories",
+		Example: "pack buildpack extract ./my-buildpack.cnb ./out\n" +
+			"pack buildpack extract docker://cnbs/sample-package:hello-universe ./out",
+		Long: "Extract the buildpacks of a buildpack package file, OCI layout, image or registry buildpack " +
+			"into <destination>/<buildpack id>/<version>, keeping the modes of the files.\n\n" +
+			"Images are looked up in the daemon first, and then in the registry.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			registry := flags.Registry
+			if registry == "" {
+				registry = cfg.DefaultRegistryName
+			}
+
+			opts := client.ExtractBuildpackOptions{
+				BuildpackName: args[0],
+				Destination:   args[1],
+				Daemon:        true,
+				Registry:      registry,
+			}
+			extracted, err := pack.ExtractBuildpack(cmd.Context(), opts)
+			if err != nil && errors.Is(err, image.ErrNotFound) {
+				opts.Daemon = false
+				extracted, err = pack.ExtractBuildpack(cmd.Context(), opts)
+			}
+			if err != nil {
+				return errors.Wrapf(err, "extracting buildpack %s", style.Symbol(args[0]))
+			}
+
+			for _, bp := range extracted {
+				logger.Infof("Extracted %s to %s", style.Symbol(bp.FullName()),
+					style.Symbol(filepath.Join(args[1], strings.ReplaceAll(bp.ID, "/", "_"), bp.Version)))
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.Registry, "registry", "r", "", "buildpack registry that may be searched")
+	AddHelpFlag(cmd, "extract")
+	return cmd
+}
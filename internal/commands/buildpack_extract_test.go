@@ -0,0 +1,96 @@
+package commands_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackExtractCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildpackExtractCommand", testBuildpackExtractCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackExtractCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         *logging.LogWithWriters
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		extracted      []dist.ModuleInfo
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		extracted = []dist.ModuleInfo{{ID: "some/bp", Version: "1.0.0"}}
+
+		command = commands.BuildpackExtract(logger, config.Config{DefaultRegistryName: "default-registry"}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#BuildpackExtract", func() {
+		it("extracts the buildpacks to the destination", func() {
+			mockClient.EXPECT().ExtractBuildpack(gomock.Any(), client.ExtractBuildpackOptions{
+				BuildpackName: "some-package.cnb",
+				Destination:   "some-dir",
+				Daemon:        true,
+				Registry:      "default-registry",
+			}).Return(extracted, nil)
+
+			command.SetArgs([]string{"some-package.cnb", "some-dir"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertContains(t, outBuf.String(), "Extracted 'some/bp@1.0.0' to '"+filepath.Join("some-dir", "some_bp", "1.0.0")+"'")
+		})
+
+		it("looks for the image in the registry when it is not in the daemon", func() {
+			gomock.InOrder(
+				mockClient.EXPECT().ExtractBuildpack(gomock.Any(), client.ExtractBuildpackOptions{
+					BuildpackName: "some/package",
+					Destination:   "some-dir",
+					Daemon:        true,
+					Registry:      "some-registry",
+				}).Return(nil, errors.Wrap(image.ErrNotFound, "image not found")),
+				mockClient.EXPECT().ExtractBuildpack(gomock.Any(), client.ExtractBuildpackOptions{
+					BuildpackName: "some/package",
+					Destination:   "some-dir",
+					Daemon:        false,
+					Registry:      "some-registry",
+				}).Return(extracted, nil),
+			)
+
+			command.SetArgs([]string{"some/package", "some-dir", "--registry", "some-registry"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("returns other errors", func() {
+			mockClient.EXPECT().ExtractBuildpack(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+
+			command.SetArgs([]string{"some-package.cnb", "some-dir"})
+			h.AssertError(t, command.Execute(), "extracting buildpack 'some-package.cnb': some error")
+		})
+	})
+}
@@ -15,6 +15,7 @@ type BuildpackInspectFlags struct {
 	Depth    int
 	Registry string
 	Verbose  bool
+	Files    bool
 }
 
 func BuildpackInspect(logger logging.Logger, cfg config.Config, client PackClient) *cobra.Command {
@@ -38,6 +39,7 @@ func BuildpackInspect(logger logging.Logger, cfg config.Config, client PackClien
 	cmd.Flags().IntVarP(&flags.Depth, "depth", "d", -1, "Max depth to display for Detection Order.\nOmission of this flag or values < 0 will display the entire tree.")
 	cmd.Flags().StringVarP(&flags.Registry, "registry", "r", "", "buildpack registry that may be searched")
 	cmd.Flags().BoolVarP(&flags.Verbose, "verbose", "v", false, "show more output")
+	cmd.Flags().BoolVar(&flags.Files, "files", false, "list the files in the layer of each buildpack, with their modes and sizes")
 	AddHelpFlag(cmd, "inspect")
 	return cmd
 }
@@ -52,11 +54,13 @@ func buildpackInspect(logger logging.Logger, buildpackName, registryName string,
 			BuildpackName: buildpackName,
 			Daemon:        true,
 			Registry:      registryName,
+			Files:         flags.Files,
 		},
 		client.InspectBuildpackOptions{
 			BuildpackName: buildpackName,
 			Daemon:        false,
 			Registry:      registryName,
+			Files:         flags.Files,
 		})
 	if err != nil {
 		return fmt.Errorf("error writing buildpack output: %q", err)
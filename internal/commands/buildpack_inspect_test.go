@@ -3,6 +3,7 @@ package commands_test
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"testing"
 
 	"github.com/buildpacks/lifecycle/api"
@@ -104,6 +105,15 @@ const simpleMixinOutputSection = `
       mixin1
       mixin2`
 
+const filesOutputSection = `
+Files:
+  some/single-buildpack@0.0.1:
+    drwxr-xr-x     0  /cnb/buildpacks/some_single-buildpack/0.0.1/bin
+    -rwxr-xr-x  1024  /cnb/buildpacks/some_single-buildpack/0.0.1/bin/build
+    Lrwxrwxrwx     0  /cnb/buildpacks/some_single-buildpack/0.0.1/bin/detect -> build
+  some/buildpack-no-homepage@0.0.2:
+    (none)`
+
 func TestBuildpackInspectCommand(t *testing.T) {
 	color.Disable(true)
 	defer color.Disable(false)
@@ -578,6 +588,38 @@ func testBuildpackInspectCommand(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("files flag is passed", func() {
+		it.Before(func() {
+			simpleInfo.Location = buildpack.URILocator
+			simpleInfo.Files = []client.ModuleFiles{
+				{
+					Module: dist.ModuleInfo{ID: "some/single-buildpack", Version: "0.0.1"},
+					Files: []client.ModuleFile{
+						{Path: "/cnb/buildpacks/some_single-buildpack/0.0.1/bin", Mode: os.ModeDir | 0755},
+						{Path: "/cnb/buildpacks/some_single-buildpack/0.0.1/bin/build", Mode: 0755, Size: 1024},
+						{Path: "/cnb/buildpacks/some_single-buildpack/0.0.1/bin/detect", Mode: os.ModeSymlink | 0777, Linkname: "build"},
+					},
+				},
+				{
+					Module: dist.ModuleInfo{ID: "some/buildpack-no-homepage", Version: "0.0.2"},
+				},
+			}
+			mockClient.EXPECT().InspectBuildpack(client.InspectBuildpackOptions{
+				BuildpackName: "/some/path/to/test/buildpack",
+				Daemon:        true,
+				Registry:      "default-registry",
+				Files:         true,
+			}).Return(simpleInfo, nil)
+		})
+
+		it("displays the files of each buildpack", func() {
+			command.SetArgs([]string{"/some/path/to/test/buildpack", "--files"})
+			assert.Nil(command.Execute())
+
+			assert.AssertTrimmedContains(outBuf.String(), filesOutputSection)
+		})
+	})
+
 	when("verbose flag is passed", func() {
 		it.Before(func() {
 			simpleInfo.Location = buildpack.URILocator
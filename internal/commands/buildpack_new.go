@@ -22,9 +22,10 @@ type BuildpackNewFlags struct {
 	API  string
 	Path string
 	// Deprecated: Stacks are deprecated
-	Stacks  []string
-	Targets []string
-	Version string
+	Stacks   []string
+	Targets  []string
+	Template string
+	Version  string
 }
 
 // BuildpackCreator creates buildpacks
@@ -39,8 +40,10 @@ func BuildpackNew(logger logging.Logger, creator BuildpackCreator) *cobra.Comman
 		Use:     "new <id>",
 		Short:   "Creates basic scaffolding of a buildpack.",
 		Args:    cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
-		Example: "pack buildpack new sample/my-buildpack",
-		Long:    "buildpack new generates the basic scaffolding of a buildpack repository. It creates a new directory `name` in the current directory (or at `path`, if passed as a flag), and initializes a buildpack.toml, and two executable bash scripts, `bin/detect` and `bin/build`. ",
+		Example: "pack buildpack new sample/my-buildpack\npack buildpack new sample/my-buildpack --template go",
+		Long: "buildpack new generates the basic scaffolding of a buildpack repository. It creates a new directory `name` in the current directory (or at `path`, if passed as a flag), and initializes a buildpack.toml, and two executable bash scripts, `bin/detect` and `bin/build`. \n\n" +
+			"When a template is provided, it also generates a working detect and build skeleton using the file formats of the buildpack API, a package.toml, sample apps to test against, and a README. " +
+			"Custom templates are directories or tar archives, where files ending with `.tmpl` are rendered as Go templates, and all other files are copied as is.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			id := args[0]
 			idParts := strings.Split(id, "/")
@@ -83,12 +86,13 @@ func BuildpackNew(logger logging.Logger, creator BuildpackCreator) *cobra.Comman
 			}
 
 			if err := creator.NewBuildpack(cmd.Context(), client.NewBuildpackOptions{
-				API:     flags.API,
-				ID:      id,
-				Path:    path,
-				Stacks:  stacks,
-				Targets: targets,
-				Version: flags.Version,
+				API:      flags.API,
+				ID:       id,
+				Path:     path,
+				Stacks:   stacks,
+				Targets:  targets,
+				Template: flags.Template,
+				Version:  flags.Version,
 			}); err != nil {
 				return err
 			}
@@ -101,6 +105,7 @@ func BuildpackNew(logger logging.Logger, creator BuildpackCreator) *cobra.Comman
 	cmd.Flags().StringVarP(&flags.API, "api", "a", "0.8", "Buildpack API compatibility of the generated buildpack")
 	cmd.Flags().StringVarP(&flags.Path, "path", "p", "", "Path to generate the buildpack")
 	cmd.Flags().StringVarP(&flags.Version, "version", "V", "1.0.0", "Version of the generated buildpack")
+	cmd.Flags().StringVar(&flags.Template, "template", "", fmt.Sprintf("Template to generate the buildpack from, one of %s, or a path to a template directory or archive (use ./<name> for a local template named like a builtin one)", strings.Join(client.BuildpackTemplates(), ", ")))
 	cmd.Flags().StringSliceVarP(&flags.Stacks, "stacks", "s", nil, "Stack(s) this buildpack will be compatible with"+stringSliceHelp("stack"))
 	cmd.Flags().MarkDeprecated("stacks", "prefer `--targets` instead: https://github.com/buildpacks/rfcs/blob/main/text/0096-remove-stacks-mixins.md")
 	cmd.Flags().StringSliceVarP(&flags.Targets, "targets", "t", nil,
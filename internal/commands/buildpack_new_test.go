@@ -75,6 +75,23 @@ func testBuildpackNewCommand(t *testing.T, when spec.G, it spec.S) {
 			h.AssertNil(t, err)
 		})
 
+		it("passes the template to the client", func() {
+			mockClient.EXPECT().NewBuildpack(gomock.Any(), client.NewBuildpackOptions{
+				API:      "0.8",
+				ID:       "example/some-cnb",
+				Path:     filepath.Join(tmpDir, "some-cnb"),
+				Version:  "1.0.0",
+				Targets:  targets,
+				Template: "go",
+			}).Return(nil).MaxTimes(1)
+
+			path := filepath.Join(tmpDir, "some-cnb")
+			command.SetArgs([]string{"--path", path, "--template", "go", "example/some-cnb"})
+
+			err := command.Execute()
+			h.AssertNil(t, err)
+		})
+
 		it("stops if the directory already exists", func() {
 			err := os.MkdirAll(tmpDir, 0600)
 			h.AssertNil(t, err)
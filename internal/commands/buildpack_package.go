@@ -11,6 +11,7 @@ import (
 
 	pubbldpkg "github.com/buildpacks/pack/buildpackage"
 	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/paths"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/dist"
@@ -30,6 +31,7 @@ type BuildpackPackageFlags struct {
 	Label             map[string]string
 	Publish           bool
 	Flatten           bool
+	VendorDeps        bool
 }
 
 // BuildpackPackager packages buildpacks
@@ -55,7 +57,9 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 			"image repositories or persisted on disk as a '.cnb' file. You can also package a number of buildpacks " +
 			"together, to enable easier distribution of a set of buildpacks. " +
 			"Packaged buildpacks can be used as inputs to `pack build` (using the `--buildpack` flag), " +
-			"and they can be included in the configs used in `pack builder create` and `pack buildpack package`. For more " +
+			"and they can be included in the configs used in `pack builder create` and `pack buildpack package`. " +
+			"When package.toml has a [build] section, its command is run on the host once per target (with GOOS, GOARCH " +
+			"and {{.TargetDir}} set for the target) before every target is packaged. For more " +
 			"on how to package a buildpack, see: https://buildpacks.io/docs/buildpack-author-guide/package-a-buildpack/.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
 			if err := validateBuildpackPackageFlags(cfg, &flags); err != nil {
@@ -104,6 +108,12 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 				logger.Warn("Flattening a buildpack package could break the distribution specification. Please use it with caution.")
 			}
 
+			if bpPath == "" {
+				if bpPath, err = localBuildpackPath(bpPackageCfg.Buildpack.URI, relativeBaseDir); err != nil {
+					return err
+				}
+			}
+
 			targets, isCompositeBP, err := processBuildpackPackageTargets(flags.Path, packageConfigReader, bpPackageCfg)
 			if err != nil {
 				return err
@@ -115,13 +125,28 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 				return err
 			}
 
+			if bpPackageCfg.Build.Command != "" {
+				if isCompositeBP {
+					return errors.Errorf("%s is not supported for composite buildpacks", style.Symbol("build.command"))
+				}
+				if len(multiArchCfg.Targets()) == 0 {
+					return errors.Errorf("%s requires targets; use --target flag OR [[targets]] in buildpack.toml", style.Symbol("build.command"))
+				}
+				if bpPath == "" {
+					return errors.Errorf("%s requires a local buildpack, %s is a remote URI", style.Symbol("build.command"), style.Symbol(bpPackageCfg.Buildpack.URI))
+				}
+				if err := multiArchCfg.BuildTargets(cmd.Context(), bpPath, bpPackageCfg.Build.Command, bpPackageCfg.Build.Env); err != nil {
+					return err
+				}
+			}
+
 			if len(multiArchCfg.Targets()) == 0 {
 				if isCompositeBP {
 					logger.Infof("Pro tip: use --targets flag OR [[targets]] in package.toml to specify the desired platform (os/arch/variant); using os %s", style.Symbol(bpPackageCfg.Platform.OS))
 				} else {
 					logger.Infof("Pro tip: use --targets flag OR [[targets]] in buildpack.toml to specify the desired platform (os/arch/variant); using os %s", style.Symbol(bpPackageCfg.Platform.OS))
 				}
-			} else if !isCompositeBP {
+			} else if !isCompositeBP && bpPath != "" {
 				// FIXME: Check if we can copy the config files during layers creation.
 				filesToClean, err := multiArchCfg.CopyConfigFiles(bpPath)
 				if err != nil {
@@ -131,17 +156,18 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 			}
 
 			if err := packager.PackageBuildpack(cmd.Context(), client.PackageBuildpackOptions{
-				RelativeBaseDir: relativeBaseDir,
-				Name:            name,
-				Format:          flags.Format,
-				Config:          bpPackageCfg,
-				Publish:         flags.Publish,
-				PullPolicy:      pullPolicy,
-				Registry:        flags.BuildpackRegistry,
-				Flatten:         flags.Flatten,
-				FlattenExclude:  flags.FlattenExclude,
-				Labels:          flags.Label,
-				Targets:         multiArchCfg.Targets(),
+				RelativeBaseDir:    relativeBaseDir,
+				Name:               name,
+				Format:             flags.Format,
+				Config:             bpPackageCfg,
+				Publish:            flags.Publish,
+				PullPolicy:         pullPolicy,
+				Registry:           flags.BuildpackRegistry,
+				Flatten:            flags.Flatten,
+				FlattenExclude:     flags.FlattenExclude,
+				Labels:             flags.Label,
+				Targets:            multiArchCfg.Targets(),
+				VendorDependencies: flags.VendorDeps,
 			}); err != nil {
 				return err
 			}
@@ -168,6 +194,7 @@ func BuildpackPackage(logger logging.Logger, cfg config.Config, packager Buildpa
 	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name")
 	cmd.Flags().BoolVar(&flags.Flatten, "flatten", false, "Flatten the buildpack into a single layer")
 	cmd.Flags().StringSliceVarP(&flags.FlattenExclude, "flatten-exclude", "e", nil, "Buildpacks to exclude from flattening, in the form of '<buildpack-id>@<buildpack-version>'")
+	cmd.Flags().BoolVar(&flags.VendorDeps, "vendor-deps", false, "Download the dependencies declared under [[metadata.dependencies]] in buildpack.toml, verify their sha256 and embed them into the buildpack, for offline use")
 	cmd.Flags().StringToStringVarP(&flags.Label, "label", "l", nil, "Labels to add to packaged Buildpack, in the form of '<name>=<value>'")
 	cmd.Flags().StringSliceVarP(&flags.Targets, "target", "t", nil,
 		`Target platforms to build for.
@@ -245,3 +272,23 @@ func clean(paths []string) error {
 	}
 	return nil
 }
+
+// localBuildpackPath returns the local directory of the buildpack uri of package.toml, which is either a path
+// relative to the config file or a file:// URI. It returns an empty path for remote URIs.
+func localBuildpackPath(uri, relativeBaseDir string) (string, error) {
+	if paths.IsURI(uri) {
+		if !strings.HasPrefix(uri, "file://") {
+			return "", nil
+		}
+		bpPath, err := paths.URIToFilePath(uri)
+		if err != nil {
+			return "", errors.Wrapf(err, "resolving buildpack uri %s", style.Symbol(uri))
+		}
+		return bpPath, nil
+	}
+
+	if filepath.IsAbs(uri) {
+		return uri, nil
+	}
+	return filepath.Join(relativeBaseDir, uri), nil
+}
@@ -3,7 +3,9 @@ package commands_test
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/heroku/color"
@@ -88,6 +90,14 @@ func testPackageCommand(t *testing.T, when spec.G, it spec.S) {
 				h.AssertEq(t, receivedOptions.Config, myConfig)
 			})
 
+			it("vendors dependencies when --vendor-deps is set", func() {
+				cmd := packageCommand(withBuildpackPackager(fakeBuildpackPackager))
+				cmd.SetArgs([]string{"some-image-name", "--config", "/path/to/some/file", "--vendor-deps"})
+				h.AssertNil(t, cmd.Execute())
+
+				h.AssertEq(t, fakeBuildpackPackager.CreateCalledWithOptions.VendorDependencies, true)
+			})
+
 			when("file format", func() {
 				when("extension is .cnb", func() {
 					it("does not modify the name", func() {
@@ -267,6 +277,75 @@ func testPackageCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("build command is configured", func() {
+			var (
+				bpDir  string
+				config pubbldpkg.Config
+			)
+
+			it.Before(func() {
+				h.SkipIf(t, runtime.GOOS == "windows", "build commands are run with sh")
+
+				bpDir = t.TempDir()
+				h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "buildpack.toml"), []byte{}, 0600))
+				config = pubbldpkg.Config{
+					Buildpack: dist.BuildpackURI{URI: "."},
+					Build: pubbldpkg.BuildConfig{
+						Command: "echo $GOOS/$GOARCH > {{.TargetDir}}/built",
+					},
+				}
+			})
+
+			it("builds each target in the buildpack directory before packaging", func() {
+				cmd := packageCommand(
+					withBuildpackPackager(fakeBuildpackPackager),
+					withPackageConfigReader(fakes.NewFakePackageConfigReader(whereReadReturns(config, nil))),
+					withPackageConfigPath(filepath.Join(bpDir, "package.toml")),
+				)
+				cmd.SetArgs([]string{"some-name", "--config", filepath.Join(bpDir, "package.toml"), "--target", "linux/arm64"})
+				h.AssertNil(t, cmd.Execute())
+
+				contents, err := os.ReadFile(filepath.Join(bpDir, "linux", "arm64", "built"))
+				h.AssertNil(t, err)
+				h.AssertEq(t, string(contents), "linux/arm64\n")
+				h.AssertEq(t, fakeBuildpackPackager.CreateCalledWithOptions.Targets, []dist.Target{{OS: "linux", Arch: "arm64"}})
+			})
+
+			it("errors when no targets are defined", func() {
+				cmd := packageCommand(
+					withBuildpackPackager(fakeBuildpackPackager),
+					withPackageConfigReader(fakes.NewFakePackageConfigReader(whereReadReturns(config, nil))),
+				)
+				cmd.SetArgs([]string{"some-name", "--config", filepath.Join(bpDir, "package.toml")})
+				h.AssertError(t, cmd.Execute(), "'build.command' requires targets")
+			})
+
+			it("builds each target in the directory of a file:// buildpack uri", func() {
+				workDir := t.TempDir()
+				config.Buildpack.URI = "file://" + filepath.ToSlash(bpDir)
+				cmd := packageCommand(
+					withBuildpackPackager(fakeBuildpackPackager),
+					withPackageConfigReader(fakes.NewFakePackageConfigReader(whereReadReturns(config, nil))),
+					withPackageConfigPath(filepath.Join(workDir, "package.toml")),
+				)
+				cmd.SetArgs([]string{"some-name", "--config", filepath.Join(workDir, "package.toml"), "--target", "linux/arm64"})
+				h.AssertNil(t, cmd.Execute())
+
+				h.AssertPathExists(t, filepath.Join(bpDir, "linux", "arm64", "built"))
+				h.AssertPathDoesNotExists(t, filepath.Join(workDir, "linux"))
+			})
+
+			it("errors when the buildpack uri is remote", func() {
+				config.Buildpack.URI = "https://example.com/bp.tgz"
+				cmd := packageCommand(
+					withBuildpackPackager(fakeBuildpackPackager),
+					withPackageConfigReader(fakes.NewFakePackageConfigReader(whereReadReturns(config, nil))),
+				)
+				cmd.SetArgs([]string{"some-name", "--config", filepath.Join(bpDir, "package.toml"), "--target", "linux/arm64"})
+				h.AssertError(t, cmd.Execute(), "'build.command' requires a local buildpack, 'https://example.com/bp.tgz' is a remote URI")
+			})
+		})
+
 		when("no config path is specified", func() {
 			when("no path is specified", func() {
 				it("creates a default config with the uri set to the current working directory", func() {
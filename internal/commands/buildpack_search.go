@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+const (
+	registryOutputHumanReadable = "human-readable"
+	registryOutputJSON          = "json"
+)
+
+// BuildpackSearchFlags define flags provided to the BuildpackSearch command
+type BuildpackSearchFlags struct {
+	BuildpackRegistry string
+	OutputFormat      string
+}
+
+// BuildpackSearch looks up buildpacks in a buildpack registry
+func BuildpackSearch(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuildpackSearchFlags
+
+	cmd := &cobra.Command{
+		Use:     "search <term>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Search a buildpack registry for buildpacks",
+		Example: "pack buildpack search nodejs",
+		Long: "Search a buildpack registry for buildpacks whose <namespace>/<name> contains <term>, ignoring case.\n\n" +
+			"The latest version of each buildpack that is not yanked is shown, with its address.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateRegistryOutputFormat(flags.OutputFormat); err != nil {
+				return err
+			}
+
+			registry, err := config.GetRegistry(cfg, flags.BuildpackRegistry)
+			if err != nil {
+				return err
+			}
+
+			results, err := pack.SearchBuildpacks(client.SearchBuildpacksOptions{
+				Term:     args[0],
+				Registry: registry.Name,
+			})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == registryOutputJSON {
+				return printRegistryJSON(logger, results)
+			}
+
+			if len(results) == 0 {
+				logger.Infof("No buildpacks matching %s were found in registry %s", style.Symbol(args[0]), style.Symbol(registry.Name))
+				return nil
+			}
+			return printRegistryTable(logger, "ID\tVERSION\tADDRESS", results, func(v client.RegistryBuildpackVersion) string {
+				return fmt.Sprintf("%s\t%s\t%s", v.ID, v.Version, v.Address)
+			})
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", registryOutputHumanReadable, "Output format (json, human-readable)")
+	AddHelpFlag(cmd, "search")
+	return cmd
+}
+
+func validateRegistryOutputFormat(format string) error {
+	if format != registryOutputHumanReadable && format != registryOutputJSON {
+		return errors.Errorf("invalid output format %s, must be one of %s or %s",
+			style.Symbol(format), style.Symbol(registryOutputHumanReadable), style.Symbol(registryOutputJSON))
+	}
+	return nil
+}
+
+func printRegistryJSON(logger logging.Logger, versions []client.RegistryBuildpackVersion) error {
+	if versions == nil {
+		versions = []client.RegistryBuildpackVersion{}
+	}
+	out, err := json.MarshalIndent(versions, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding output")
+	}
+	logger.Info(string(out))
+	return nil
+}
+
+func printRegistryTable(logger logging.Logger, header string, versions []client.RegistryBuildpackVersion, row func(v client.RegistryBuildpackVersion) string) error {
+	buf := &bytes.Buffer{}
+	tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, header)
+	for _, v := range versions {
+		fmt.Fprintln(tw, row(v))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	logger.Info(strings.TrimSuffix(buf.String(), "\n"))
+	return nil
+}
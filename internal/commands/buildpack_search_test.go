@@ -0,0 +1,112 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackSearchCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildpackSearchCommand", testBuildpackSearchCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackSearchCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd            *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		results        = []client.RegistryBuildpackVersion{
+			{ID: "example/nodejs", Version: "1.10.0", Address: "example.com/nodejs@sha256:abc"},
+			{ID: "other/node-engine", Version: "0.1.0", Address: "example.com/node-engine@sha256:def"},
+		}
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+		cfg := config.Config{
+			Registries: []config.Registry{{Name: "private", Type: "dir", URL: "/some/registry"}},
+		}
+
+		cmd = commands.BuildpackSearch(logger, cfg, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("prints a table of matching buildpacks", func() {
+		mockClient.EXPECT().
+			SearchBuildpacks(client.SearchBuildpacksOptions{Term: "node", Registry: "official"}).
+			Return(results, nil)
+
+		cmd.SetArgs([]string{"node"})
+		h.AssertNil(t, cmd.Execute())
+		h.AssertContains(t, outBuf.String(), `ID                 VERSION  ADDRESS
+example/nodejs     1.10.0   example.com/nodejs@sha256:abc
+other/node-engine  0.1.0    example.com/node-engine@sha256:def`)
+	})
+
+	it("searches the given registry", func() {
+		mockClient.EXPECT().
+			SearchBuildpacks(client.SearchBuildpacksOptions{Term: "node", Registry: "private"}).
+			Return(nil, nil)
+
+		cmd.SetArgs([]string{"node", "--buildpack-registry", "private"})
+		h.AssertNil(t, cmd.Execute())
+		h.AssertContains(t, outBuf.String(), "No buildpacks matching 'node' were found in registry 'private'")
+	})
+
+	it("prints json", func() {
+		mockClient.EXPECT().
+			SearchBuildpacks(client.SearchBuildpacksOptions{Term: "node", Registry: "official"}).
+			Return(results[:1], nil)
+
+		cmd.SetArgs([]string{"node", "--output", "json"})
+		h.AssertNil(t, cmd.Execute())
+		h.AssertContains(t, outBuf.String(), `[
+  {
+    "id": "example/nodejs",
+    "version": "1.10.0",
+    "address": "example.com/nodejs@sha256:abc",
+    "yanked": false
+  }
+]`)
+	})
+
+	it("prints an empty json array when nothing matches", func() {
+		mockClient.EXPECT().
+			SearchBuildpacks(client.SearchBuildpacksOptions{Term: "ruby", Registry: "official"}).
+			Return(nil, nil)
+
+		cmd.SetArgs([]string{"ruby", "-o", "json"})
+		h.AssertNil(t, cmd.Execute())
+		h.AssertEq(t, outBuf.String(), "[]\n")
+	})
+
+	it("fails for an invalid output format", func() {
+		cmd.SetArgs([]string{"node", "--output", "yaml"})
+		h.AssertError(t, cmd.Execute(), "invalid output format 'yaml', must be one of 'human-readable' or 'json'")
+	})
+
+	it("fails for an unknown registry", func() {
+		cmd.SetArgs([]string{"node", "-r", "missing"})
+		h.AssertError(t, cmd.Execute(), "registry 'missing' is not defined in your config file")
+	})
+}
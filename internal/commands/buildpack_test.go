@@ -43,7 +43,7 @@ func testBuildpackCommand(t *testing.T, when spec.G, it spec.S) {
 			h.AssertNil(t, cmd.Execute())
 			output := outBuf.String()
 			h.AssertContains(t, output, "Interact with buildpacks")
-			for _, command := range []string{"Usage", "package", "register", "yank", "pull", "inspect"} {
+			for _, command := range []string{"Usage", "package", "register", "yank", "pull", "inspect", "extract", "test", "search", "versions"} {
 				h.AssertContains(t, output, command)
 			}
 		})
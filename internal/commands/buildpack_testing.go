@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildpackTestFlags define flags provided to the BuildpackTest command
+type BuildpackTestFlags struct {
+	Fixtures   string
+	BuildImage string
+	Report     string
+	Policy     string
+}
+
+// BuildpackTest runs a buildpack against fixture apps and checks the outcome
+func BuildpackTest(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuildpackTestFlags
+
+	cmd := &cobra.Command{
+		Use:     "test <buildpack-dir>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Test a buildpack against fixture apps",
+		Example: "pack buildpack test ./my-buildpack --fixtures ./my-buildpack/testdata/apps --build-image cnbs/sample-base-build:jammy",
+		Long: "Runs bin/detect and bin/build of a buildpack against each app in the fixtures directory, inside the build image, " +
+			"and checks the outcome against the '" + client.ExpectationsFile + "' of the app. For example:\n\n" +
+			"  detect = \"pass\"\n" +
+			"  layers = [\"my-layer\"]\n" +
+			"  processes = [\"web\"]\n\n" +
+			"  [env]\n" +
+			"  MY_VAR = \"my-value\"\n\n" +
+			"Apps without '" + client.ExpectationsFile + "' are expected to pass detection and build.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.BuildImage == "" {
+				return errors.New("build image must be provided with --build-image")
+			}
+
+			fixtures := flags.Fixtures
+			if fixtures == "" {
+				fixtures = filepath.Join(args[0], "testdata", "apps")
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+			}
+
+			report, err := pack.TestBuildpack(cmd.Context(), client.TestBuildpackOptions{
+				BuildpackPath: args[0],
+				FixturesPath:  fixtures,
+				BuildImage:    flags.BuildImage,
+				PullPolicy:    pullPolicy,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "testing buildpack %s", style.Symbol(args[0]))
+			}
+
+			writeBuildpackTestReport(logger, report)
+
+			if flags.Report != "" {
+				if err := writeJUnitReport(flags.Report, report); err != nil {
+					return err
+				}
+				logger.Infof("Wrote JUnit report to %s", style.Symbol(flags.Report))
+			}
+
+			if failed := report.Failed(); failed > 0 {
+				return errors.Errorf("%d of %d fixtures failed", failed, len(report.Results))
+			}
+
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.Fixtures, "fixtures", "", "Directory with one fixture app per subdirectory (defaults to testdata/apps in the buildpack directory)")
+	cmd.Flags().StringVar(&flags.BuildImage, "build-image", "", "Image to run bin/detect and bin/build in")
+	cmd.Flags().StringVar(&flags.Report, "report", "", "Path to write a JUnit XML report to")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. The default is always`)
+	AddHelpFlag(cmd, "test")
+	return cmd
+}
+
+func writeBuildpackTestReport(logger logging.Logger, report *client.BuildpackTestReport) {
+	logger.Infof("Testing buildpack %s\n", style.Symbol(report.Buildpack.FullName()))
+
+	for _, result := range report.Results {
+		if result.Passed() {
+			logger.Infof("  PASS  %s (%.2fs)", result.Fixture, result.Duration.Seconds())
+			continue
+		}
+
+		logger.Infof("  FAIL  %s (%.2fs)", result.Fixture, result.Duration.Seconds())
+		for _, failure := range result.Failures {
+			logger.Infof("        %s", failure)
+		}
+		if output := strings.TrimSpace(result.Output); output != "" {
+			logger.Debugf("        Output:\n%s", output)
+		}
+	}
+
+	logger.Infof("\n%d passed, %d failed", len(report.Results)-report.Failed(), report.Failed())
+}
+
+func writeJUnitReport(path string, report *client.BuildpackTestReport) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "creating report %s", style.Symbol(path))
+	}
+	defer f.Close()
+
+	return report.WriteJUnit(f)
+}
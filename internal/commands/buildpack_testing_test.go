@@ -0,0 +1,121 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackTestCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "BuildpackTestCommand", testBuildpackTestCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackTestCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         *logging.LogWithWriters
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		receivedOpts   client.TestBuildpackOptions
+		testReport     *client.BuildpackTestReport
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		testReport = &client.BuildpackTestReport{
+			Buildpack: dist.ModuleInfo{ID: "some/bp", Version: "1.0.0"},
+			Results: []client.BuildpackTestResult{
+				{Fixture: "app-a", Duration: time.Second},
+				{Fixture: "app-b", Duration: 2 * time.Second},
+			},
+		}
+
+		mockClient.EXPECT().
+			TestBuildpack(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, opts client.TestBuildpackOptions) (*client.BuildpackTestReport, error) {
+				receivedOpts = opts
+				return testReport, nil
+			}).
+			AnyTimes()
+
+		command = commands.BuildpackTest(logger, config.Config{}, mockClient)
+	})
+
+	when("#BuildpackTest", func() {
+		it("tests the buildpack against the fixtures", func() {
+			command.SetArgs([]string{"some-bp", "--fixtures", "some-fixtures", "--build-image", "some/build", "--pull-policy", "never"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertEq(t, receivedOpts.BuildpackPath, "some-bp")
+			h.AssertEq(t, receivedOpts.FixturesPath, "some-fixtures")
+			h.AssertEq(t, receivedOpts.BuildImage, "some/build")
+			h.AssertEq(t, receivedOpts.PullPolicy, image.PullNever)
+
+			h.AssertContains(t, outBuf.String(), "PASS  app-a (1.00s)")
+			h.AssertContains(t, outBuf.String(), "2 passed, 0 failed")
+		})
+
+		it("defaults the fixtures to testdata/apps of the buildpack", func() {
+			command.SetArgs([]string{"some-bp", "--build-image", "some/build"})
+			h.AssertNil(t, command.Execute())
+
+			h.AssertEq(t, receivedOpts.FixturesPath, filepath.Join("some-bp", "testdata", "apps"))
+			h.AssertEq(t, receivedOpts.PullPolicy, image.PullAlways)
+		})
+
+		it("requires a build image", func() {
+			command.SetArgs([]string{"some-bp"})
+			h.AssertError(t, command.Execute(), "build image must be provided with --build-image")
+		})
+
+		when("a fixture fails", func() {
+			it.Before(func() {
+				testReport.Results[1].Failures = []string{"expected process 'web' in launch.toml"}
+			})
+
+			it("prints the failures and returns an error", func() {
+				command.SetArgs([]string{"some-bp", "--build-image", "some/build"})
+				h.AssertError(t, command.Execute(), "1 of 2 fixtures failed")
+
+				h.AssertContains(t, outBuf.String(), "FAIL  app-b (2.00s)\n        expected process 'web' in launch.toml")
+			})
+		})
+
+		when("--report is provided", func() {
+			it("writes a JUnit report", func() {
+				reportPath := filepath.Join(t.TempDir(), "report.xml")
+				command.SetArgs([]string{"some-bp", "--build-image", "some/build", "--report", reportPath})
+				h.AssertNil(t, command.Execute())
+
+				contents, err := os.ReadFile(reportPath)
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(contents), `<testsuite name="some/bp@1.0.0" tests="2" failures="0"`)
+			})
+		})
+	})
+}
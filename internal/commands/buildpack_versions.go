@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// BuildpackVersionsFlags define flags provided to the BuildpackVersions command
+type BuildpackVersionsFlags struct {
+	BuildpackRegistry string
+	OutputFormat      string
+}
+
+// BuildpackVersions lists the versions of a buildpack in a buildpack registry
+func BuildpackVersions(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags BuildpackVersionsFlags
+
+	cmd := &cobra.Command{
+		Use:     "versions <namespace>/<name>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "List the versions of a buildpack in a buildpack registry",
+		Example: "pack buildpack versions paketo-buildpacks/nodejs",
+		Long:    "List every version of a buildpack in a buildpack registry, from the highest to the lowest, including yanked versions.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateRegistryOutputFormat(flags.OutputFormat); err != nil {
+				return err
+			}
+
+			registry, err := config.GetRegistry(cfg, flags.BuildpackRegistry)
+			if err != nil {
+				return err
+			}
+
+			versions, err := pack.BuildpackVersions(client.BuildpackVersionsOptions{
+				ID:       args[0],
+				Registry: registry.Name,
+			})
+			if err != nil {
+				return err
+			}
+
+			if flags.OutputFormat == registryOutputJSON {
+				return printRegistryJSON(logger, versions)
+			}
+			return printRegistryTable(logger, "VERSION\tYANKED\tADDRESS", versions, func(v client.RegistryBuildpackVersion) string {
+				return fmt.Sprintf("%s\t%t\t%s", v.Version, v.Yanked, v.Address)
+			})
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.BuildpackRegistry, "buildpack-registry", "r", "", "Buildpack Registry name")
+	cmd.Flags().StringVarP(&flags.OutputFormat, "output", "o", registryOutputHumanReadable, "Output format (json, human-readable)")
+	AddHelpFlag(cmd, "versions")
+	return cmd
+}
@@ -0,0 +1,83 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBuildpackVersionsCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "BuildpackVersionsCommand", testBuildpackVersionsCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testBuildpackVersionsCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		cmd            *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		cmd = commands.BuildpackVersions(logger, config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("prints a table of versions", func() {
+		mockClient.EXPECT().
+			BuildpackVersions(client.BuildpackVersionsOptions{ID: "example/nodejs", Registry: "official"}).
+			Return([]client.RegistryBuildpackVersion{
+				{ID: "example/nodejs", Version: "2.0.0", Address: "example.com/nodejs@sha256:abc", Yanked: true},
+				{ID: "example/nodejs", Version: "1.10.0", Address: "example.com/nodejs@sha256:def"},
+			}, nil)
+
+		cmd.SetArgs([]string{"example/nodejs"})
+		h.AssertNil(t, cmd.Execute())
+		h.AssertContains(t, outBuf.String(), `VERSION  YANKED  ADDRESS
+2.0.0    true    example.com/nodejs@sha256:abc
+1.10.0   false   example.com/nodejs@sha256:def`)
+	})
+
+	it("prints json", func() {
+		mockClient.EXPECT().
+			BuildpackVersions(client.BuildpackVersionsOptions{ID: "example/nodejs", Registry: "official"}).
+			Return([]client.RegistryBuildpackVersion{
+				{ID: "example/nodejs", Version: "2.0.0", Address: "example.com/nodejs@sha256:abc", Yanked: true},
+			}, nil)
+
+		cmd.SetArgs([]string{"example/nodejs", "--output", "json"})
+		h.AssertNil(t, cmd.Execute())
+		h.AssertContains(t, outBuf.String(), `"yanked": true`)
+	})
+
+	it("returns the client error", func() {
+		mockClient.EXPECT().
+			BuildpackVersions(client.BuildpackVersionsOptions{ID: "example/ruby", Registry: "official"}).
+			Return(nil, errors.New("no versions of 'example/ruby' were found"))
+
+		cmd.SetArgs([]string{"example/ruby"})
+		h.AssertError(t, cmd.Execute(), "no versions of 'example/ruby' were found")
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
+	registrytypes "github.com/buildpacks/pack/registry"
 )
 
 type BuildpackYankFlags struct {
@@ -44,6 +45,10 @@ func BuildpackYank(logger logging.Logger, cfg config.Config, pack PackClient) *c
 				URL:     registry.URL,
 				Yank:    !flags.Undo,
 			}
+			if registry.Type == registrytypes.TypeDir || registry.Type == registrytypes.TypeOCI {
+				opts.Type = registry.Type
+				opts.Name = registry.Name
+			}
 
 			if err := pack.YankBuildpack(opts); err != nil {
 				return err
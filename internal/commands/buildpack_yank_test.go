@@ -112,6 +112,34 @@ func testYankCommand(t *testing.T, when spec.G, it spec.S) {
 				h.AssertNil(t, cmd.Execute())
 			})
 
+			it("should pass the registry name for registries written to directly", func() {
+				cfg = config.Config{
+					Registries: []config.Registry{
+						{
+							Name: "private",
+							Type: "dir",
+							URL:  "/mnt/registry-index",
+						},
+					},
+				}
+
+				opts := client.YankBuildpackOptions{
+					ID:      "heroku/rust",
+					Version: "0.0.1",
+					Type:    "dir",
+					URL:     "/mnt/registry-index",
+					Name:    "private",
+					Yank:    true,
+				}
+				mockClient.EXPECT().
+					YankBuildpack(opts).
+					Return(nil)
+
+				cmd = commands.BuildpackYank(logger, cfg, mockClient)
+				cmd.SetArgs([]string{buildpackIDVersion, "--buildpack-registry", "private"})
+				h.AssertNil(t, cmd.Execute())
+			})
+
 			it("should undo", func() {
 				opts := client.YankBuildpackOptions{
 					ID:      "heroku/rust",
@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
+	"github.com/buildpacks/pack/internal/builder"
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/internal/target"
@@ -23,18 +24,30 @@ import (
 //go:generate mockgen -package testmocks -destination testmocks/mock_pack_client.go github.com/buildpacks/pack/internal/commands PackClient
 type PackClient interface {
 	InspectBuilder(string, bool, ...client.BuilderInspectionModifier) (*client.BuilderInfo, error)
+	InspectBuilderLayers(string, client.InspectBuilderLayersOptions) (*client.BuilderLayersInfo, error)
+	ExplainBuilder(context.Context, client.ExplainBuilderOptions) (*builder.DetectionSimulation, error)
 	InspectImage(string, bool) (*client.ImageInfo, error)
 	Rebase(context.Context, client.RebaseOptions) error
+	BulkRebase(context.Context, client.BulkRebaseOptions) ([]client.RebaseResult, error)
+	CheckRebase(context.Context, client.RebaseOptions) (client.RebaseCheck, error)
+	VerifyRunImageMirrors(context.Context, client.VerifyRunImageMirrorsOptions) ([]client.RunImageMirrorsReport, error)
 	CreateBuilder(context.Context, client.CreateBuilderOptions) error
 	NewBuildpack(context.Context, client.NewBuildpackOptions) error
+	NewExtension(context.Context, client.NewExtensionOptions) error
 	PackageBuildpack(ctx context.Context, opts client.PackageBuildpackOptions) error
 	PackageExtension(ctx context.Context, opts client.PackageBuildpackOptions) error
 	Build(context.Context, client.BuildOptions) error
+	Detect(context.Context, client.DetectOptions) (*client.DetectResult, error)
+	TestBuildpack(context.Context, client.TestBuildpackOptions) (*client.BuildpackTestReport, error)
+	ValidateExtension(context.Context, client.ValidateExtensionOptions) (*client.ExtensionValidationReport, error)
 	RegisterBuildpack(context.Context, client.RegisterBuildpackOptions) error
 	YankBuildpack(client.YankBuildpackOptions) error
 	InspectBuildpack(client.InspectBuildpackOptions) (*client.BuildpackInfo, error)
+	ExtractBuildpack(context.Context, client.ExtractBuildpackOptions) ([]dist.ModuleInfo, error)
 	InspectExtension(client.InspectExtensionOptions) (*client.ExtensionInfo, error)
 	PullBuildpack(context.Context, client.PullBuildpackOptions) error
+	SearchBuildpacks(client.SearchBuildpacksOptions) ([]client.RegistryBuildpackVersion, error)
+	BuildpackVersions(client.BuildpackVersionsOptions) ([]client.RegistryBuildpackVersion, error)
 	DownloadSBOM(name string, options client.DownloadSBOMOptions) error
 	CreateManifest(ctx context.Context, opts client.CreateManifestOptions) error
 	AnnotateManifest(ctx context.Context, opts client.ManifestAnnotateOptions) error
@@ -1,7 +1,10 @@
 package commands
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
@@ -9,10 +12,11 @@ import (
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
-func NewConfigCommand(logger logging.Logger, cfg config.Config, cfgPath string, client PackClient) *cobra.Command {
+func NewConfigCommand(logger logging.Logger, cfg config.Config, cfgPath string, client PackClient, effective config.Layered) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "config",
 		Short: "Interact with your local pack config file",
+		Long:  "Interact with your local pack config file.\n\n" + configEnvHelp(),
 		RunE:  nil,
 	}
 
@@ -20,15 +24,28 @@ func NewConfigCommand(logger logging.Logger, cfg config.Config, cfgPath string,
 	cmd.AddCommand(ConfigExperimental(logger, cfg, cfgPath))
 	cmd.AddCommand(ConfigPullPolicy(logger, cfg, cfgPath))
 	cmd.AddCommand(ConfigRegistries(logger, cfg, cfgPath))
-	cmd.AddCommand(ConfigRunImagesMirrors(logger, cfg, cfgPath))
+	cmd.AddCommand(ConfigRunImagesMirrors(logger, cfg, cfgPath, client))
 	cmd.AddCommand(ConfigTrustedBuilder(logger, cfg, cfgPath))
 	cmd.AddCommand(ConfigLifecycleImage(logger, cfg, cfgPath))
 	cmd.AddCommand(ConfigRegistryMirrors(logger, cfg, cfgPath))
+	cmd.AddCommand(ConfigShow(logger, effective))
 
 	AddHelpFlag(cmd, "config")
 	return cmd
 }
 
+// configEnvHelp documents the environment variables overriding config settings.
+func configEnvHelp() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString("Settings can also be overridden with the following environment variables, which take precedence over the project and user configs:\n\n")
+	tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	for _, envVar := range config.EnvVars {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", envVar.Name, envVar.Key, envVar.Format)
+	}
+	_ = tw.Flush()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
 type editCfgFunc func(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error
 
 func generateAdd(cmdName string, logger logging.Logger, cfg config.Config, cfgPath string, addFunc editCfgFunc) *cobra.Command {
@@ -43,10 +43,14 @@ func ConfigRegistries(logger logging.Logger, cfg config.Config, cfgPath string)
 
 	addCmd := generateAdd("registries", logger, cfg, cfgPath, addRegistry)
 	addCmd.Args = cobra.ExactArgs(2)
-	addCmd.Example = "pack config registries add my-registry https://github.com/buildpacks/my-registry"
-	addCmd.Long = bpRegistryExplanation + "Users can add registries from the config by using registries remove, and publish/yank buildpacks from it, as well as use those buildpacks when building applications."
+	addCmd.Example = "pack config registries add my-registry https://github.com/buildpacks/my-registry\n" +
+		"pack config registries add my-registry /mnt/shared/registry-index --type dir\n" +
+		"pack config registries add my-registry registry.example.com/buildpacks/registry-index:latest --type oci"
+	addCmd.Long = bpRegistryExplanation + "Users can add registries from the config by using registries remove, and publish/yank buildpacks from it, as well as use those buildpacks when building applications.\n\n" +
+		"Registries of type 'dir' keep index files in a directory, either on the filesystem or served read-only over HTTP; " +
+		"registries of type 'oci' keep them in an OCI artifact. Buildpacks are registered in and yanked from both directly."
 	addCmd.Flags().BoolVar(&setDefault, "default", false, "Set this buildpack registry as the default")
-	addCmd.Flags().StringVar(&registryType, "type", "github", "Type of buildpack registry [git|github]")
+	addCmd.Flags().StringVar(&registryType, "type", "github", "Type of buildpack registry [git|github|dir|oci]")
 	cmd.AddCommand(addCmd)
 
 	rmCmd := generateRemove("registries", logger, cfg, cfgPath, removeRegistry)
@@ -8,11 +8,15 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/internal/config"
+	pname "github.com/buildpacks/pack/internal/name"
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
-var registryMirror string
+var (
+	registryMirror     string
+	registryRuleMirror []string
+)
 
 func ConfigRegistryMirrors(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
 	cmd := &cobra.Command{
@@ -45,6 +49,22 @@ func ConfigRegistryMirrors(logger logging.Logger, cfg config.Config, cfgPath str
 	rmCmd.Example = "pack config registry-mirrors remove index.docker.io"
 	cmd.AddCommand(rmCmd)
 
+	addRuleCmd := generateAdd("mirror rule", logger, cfg, cfgPath, addRegistryMirrorRule)
+	addRuleCmd.Use = "add-rule <match> -m <mirror...>"
+	addRuleCmd.Long = "Rewrite images matching a registry and repository, or a repository prefix ending in '/*', to mirrors tried in order.\n\n" +
+		"When <match> ends in '/*', every mirror must end in '/*' too, and the '*' is replaced by the rest of the repository. " +
+		"Rules are used in the order they were added, the first matching rule wins, and rules take precedence over registry mirrors. " +
+		"Adding a rule for an existing <match> replaces its mirrors."
+	addRuleCmd.Example = "pack config registry-mirrors add-rule 'docker.io/paketobuildpacks/*' --mirror 'artifactory.corp/pb-remote/*' --mirror 'docker.io/paketobuildpacks/*'"
+	addRuleCmd.Flags().StringSliceVarP(&registryRuleMirror, "mirror", "m", nil, "Mirror to try, in order"+stringSliceHelp("mirror"))
+	cmd.AddCommand(addRuleCmd)
+
+	rmRuleCmd := generateRemove("mirror rule", logger, cfg, cfgPath, removeRegistryMirrorRule)
+	rmRuleCmd.Use = "remove-rule <match>"
+	rmRuleCmd.Long = "Remove the mirror rule for a given match."
+	rmRuleCmd.Example = "pack config registry-mirrors remove-rule 'docker.io/paketobuildpacks/*'"
+	cmd.AddCommand(rmRuleCmd)
+
 	AddHelpFlag(cmd, "run-image-mirrors")
 	return cmd
 }
@@ -86,16 +106,82 @@ func removeRegistryMirror(args []string, logger logging.Logger, cfg config.Confi
 	return nil
 }
 
+func addRegistryMirrorRule(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+	rule := config.RegistryMirrorRule{Match: args[0], Mirrors: registryRuleMirror}
+	if len(rule.Mirrors) == 0 {
+		logger.Infof("No mirrors were provided.")
+		return nil
+	}
+	if err := pname.ValidateMirrorRule(pname.MirrorRule{Match: rule.Match, Mirrors: rule.Mirrors}); err != nil {
+		return err
+	}
+
+	var rules []config.RegistryMirrorRule
+	replaced := false
+	for _, existing := range cfg.RegistryMirrorRules {
+		if existing.Match == rule.Match {
+			existing = rule
+			replaced = true
+		}
+		rules = append(rules, existing)
+	}
+	if !replaced {
+		rules = append(rules, rule)
+	}
+
+	cfg.RegistryMirrorRules = rules
+	if err := config.Write(cfg, cfgPath); err != nil {
+		return errors.Wrapf(err, "failed to write to %s", cfgPath)
+	}
+
+	logger.Infof("Images matching %s configured with mirrors %s", style.Symbol(rule.Match), strings.Join(rule.Mirrors, ", "))
+	return nil
+}
+
+func removeRegistryMirrorRule(args []string, logger logging.Logger, cfg config.Config, cfgPath string) error {
+	match := args[0]
+
+	var rules []config.RegistryMirrorRule
+	for _, rule := range cfg.RegistryMirrorRules {
+		if rule.Match != match {
+			rules = append(rules, rule)
+		}
+	}
+	if len(rules) == len(cfg.RegistryMirrorRules) {
+		logger.Infof("No mirror rule has been set for %s", style.Symbol(match))
+		return nil
+	}
+
+	cfg.RegistryMirrorRules = rules
+	if err := config.Write(cfg, cfgPath); err != nil {
+		return errors.Wrapf(err, "failed to write to %s", cfgPath)
+	}
+
+	logger.Infof("Removed mirror rule for %s", style.Symbol(match))
+	return nil
+}
+
 func listRegistryMirrors(args []string, logger logging.Logger, cfg config.Config) {
-	if len(cfg.RegistryMirrors) == 0 {
+	if len(cfg.RegistryMirrors) == 0 && len(cfg.RegistryMirrorRules) == 0 {
 		logger.Info("No registry mirrors have been set")
 		return
 	}
 
 	buf := strings.Builder{}
-	buf.WriteString("Registry Mirrors:\n")
-	for registry, mirror := range cfg.RegistryMirrors {
-		buf.WriteString(fmt.Sprintf("  %s: %s\n", registry, style.Symbol(mirror)))
+	if len(cfg.RegistryMirrorRules) > 0 {
+		buf.WriteString("Registry Mirror Rules:\n")
+		for _, rule := range cfg.RegistryMirrorRules {
+			buf.WriteString(fmt.Sprintf("  %s:\n", rule.Match))
+			for _, mirror := range rule.Mirrors {
+				buf.WriteString(fmt.Sprintf("    %s\n", style.Symbol(mirror)))
+			}
+		}
+	}
+	if len(cfg.RegistryMirrors) > 0 {
+		buf.WriteString("Registry Mirrors:\n")
+		for registry, mirror := range cfg.RegistryMirrors {
+			buf.WriteString(fmt.Sprintf("  %s: %s\n", registry, style.Symbol(mirror)))
+		}
 	}
 
 	logger.Info(buf.String())
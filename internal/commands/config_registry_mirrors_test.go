@@ -186,7 +186,86 @@ func testConfigRegistryMirrorsCommand(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("add-rule", func() {
+		it("appends the rule to the config", func() {
+			cmd = commands.ConfigRegistryMirrors(logger, config.Config{
+				RegistryMirrorRules: []config.RegistryMirrorRule{{Match: "gcr.io/*", Mirrors: []string{"mirror.corp/gcr/*"}}},
+			}, configPath)
+			cmd.SetArgs([]string{"add-rule", "docker.io/paketobuildpacks/*", "-m", "artifactory.corp/pb-remote/*", "-m", "docker.io/paketobuildpacks/*"})
+			h.AssertNil(t, cmd.Execute())
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.RegistryMirrorRules, []config.RegistryMirrorRule{
+				{Match: "gcr.io/*", Mirrors: []string{"mirror.corp/gcr/*"}},
+				{Match: "docker.io/paketobuildpacks/*", Mirrors: []string{"artifactory.corp/pb-remote/*", "docker.io/paketobuildpacks/*"}},
+			})
+		})
+
+		it("replaces the mirrors of an existing rule in place", func() {
+			cmd = commands.ConfigRegistryMirrors(logger, config.Config{
+				RegistryMirrorRules: []config.RegistryMirrorRule{
+					{Match: "gcr.io/*", Mirrors: []string{"mirror.corp/gcr/*"}},
+					{Match: "quay.io/*", Mirrors: []string{"mirror.corp/quay/*"}},
+				},
+			}, configPath)
+			cmd.SetArgs([]string{"add-rule", "gcr.io/*", "-m", "other.corp/gcr/*"})
+			h.AssertNil(t, cmd.Execute())
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.RegistryMirrorRules, []config.RegistryMirrorRule{
+				{Match: "gcr.io/*", Mirrors: []string{"other.corp/gcr/*"}},
+				{Match: "quay.io/*", Mirrors: []string{"mirror.corp/quay/*"}},
+			})
+		})
+
+		it("fails when a mirror of a wildcard rule doesn't end in a wildcard", func() {
+			cmd.SetArgs([]string{"add-rule", "docker.io/paketobuildpacks/*", "-m", "artifactory.corp/pb-remote"})
+			h.AssertError(t, cmd.Execute(), "mirrors must end in '/*'")
+		})
+	})
+
+	when("remove-rule", func() {
+		it("removes the rule from the config", func() {
+			cmd = commands.ConfigRegistryMirrors(logger, config.Config{
+				RegistryMirrorRules: []config.RegistryMirrorRule{
+					{Match: "gcr.io/*", Mirrors: []string{"mirror.corp/gcr/*"}},
+					{Match: "quay.io/*", Mirrors: []string{"mirror.corp/quay/*"}},
+				},
+			}, configPath)
+			cmd.SetArgs([]string{"remove-rule", "gcr.io/*"})
+			h.AssertNil(t, cmd.Execute())
+
+			cfg, err := config.Read(configPath)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg.RegistryMirrorRules, []config.RegistryMirrorRule{
+				{Match: "quay.io/*", Mirrors: []string{"mirror.corp/quay/*"}},
+			})
+		})
+
+		it("prints a clear message when the rule isn't present", func() {
+			cmd.SetArgs([]string{"remove-rule", "gcr.io/*"})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), "No mirror rule has been set for 'gcr.io/*'")
+		})
+	})
+
 	when("list", func() {
+		it("lists mirror rules in order", func() {
+			cmd = commands.ConfigRegistryMirrors(logger, config.Config{
+				RegistryMirrorRules: []config.RegistryMirrorRule{
+					{Match: "docker.io/paketobuildpacks/*", Mirrors: []string{"artifactory.corp/pb-remote/*", "docker.io/paketobuildpacks/*"}},
+				},
+			}, configPath)
+			cmd.SetArgs([]string{"list"})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), `Registry Mirror Rules:
+  docker.io/paketobuildpacks/*:
+    'artifactory.corp/pb-remote/*'
+    'docker.io/paketobuildpacks/*'`)
+		})
+
 		when("mirrors were previously set", func() {
 			it("lists registry mirrors", func() {
 				cmd.SetArgs([]string{"list"})
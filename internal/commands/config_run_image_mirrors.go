@@ -1,9 +1,13 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -11,12 +15,13 @@ import (
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/internal/stringset"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
 var mirrors []string
 
-func ConfigRunImagesMirrors(logger logging.Logger, cfg config.Config, cfgPath string) *cobra.Command {
+func ConfigRunImagesMirrors(logger logging.Logger, cfg config.Config, cfgPath string, pack PackClient) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "run-image-mirrors",
 		Short: "List, add and remove run image mirrors",
@@ -48,6 +53,8 @@ func ConfigRunImagesMirrors(logger logging.Logger, cfg config.Config, cfgPath st
 	rmCmd.Flags().StringSliceVarP(&mirrors, "mirror", "m", nil, "Run image mirror"+stringSliceHelp("mirror"))
 	cmd.AddCommand(rmCmd)
 
+	cmd.AddCommand(verifyRunImageMirrorsCmd(logger, cfg, pack))
+
 	AddHelpFlag(cmd, "run-image-mirrors")
 	return cmd
 }
@@ -155,6 +162,101 @@ func listRunImageMirror(args []string, logger logging.Logger, cfg config.Config)
 	}
 }
 
+func verifyRunImageMirrorsCmd(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var (
+		outputFormat string
+		timeout      time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "verify [<run-image>]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Verify that run image mirrors have the same digest as their run image",
+		Long: "Fetch every configured run image, or only the given run image, and its mirrors from their registries " +
+			"and report whether each mirror is in sync with its run image. Fails when any mirror is stale or unreachable.",
+		Example: "pack config run-image-mirrors verify cnbs/sample-stack-run:bionic",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if err := validateRegistryOutputFormat(outputFormat); err != nil {
+				return err
+			}
+
+			mirrorsByImage := map[string][]string{}
+			for _, runImage := range cfg.RunImages {
+				if len(args) == 0 || runImage.Image == args[0] {
+					mirrorsByImage[runImage.Image] = runImage.Mirrors
+				}
+			}
+			if len(mirrorsByImage) == 0 {
+				if len(args) > 0 {
+					return errors.Errorf("no run image mirrors have been set for %s", style.Symbol(args[0]))
+				}
+				return errors.New("no run image mirrors have been set")
+			}
+
+			reports, err := pack.VerifyRunImageMirrors(cmd.Context(), client.VerifyRunImageMirrorsOptions{
+				Mirrors: mirrorsByImage,
+				Timeout: timeout,
+			})
+			if err != nil {
+				return err
+			}
+
+			if outputFormat == registryOutputJSON {
+				out, err := json.MarshalIndent(reports, "", "  ")
+				if err != nil {
+					return errors.Wrap(err, "encoding output")
+				}
+				logger.Info(string(out))
+			} else if err := printRunImageMirrorsReports(logger, reports); err != nil {
+				return err
+			}
+
+			var failed int
+			for _, report := range reports {
+				for _, mirror := range report.Mirrors {
+					if mirror.Status != client.RunImageMirrorInSync {
+						failed++
+					}
+				}
+			}
+			if failed > 0 {
+				return errors.Errorf("%d run image mirrors are not in sync with their run image", failed)
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", registryOutputHumanReadable, "Output format (json, human-readable)")
+	cmd.Flags().DurationVar(&timeout, "timeout", client.DefaultRunImageMirrorTimeout, "How long each run image and mirror may take to respond")
+	AddHelpFlag(cmd, "verify")
+	return cmd
+}
+
+func printRunImageMirrorsReports(logger logging.Logger, reports []client.RunImageMirrorsReport) error {
+	buf := &bytes.Buffer{}
+	tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "RUN IMAGE\tMIRROR\tSTATUS\tDIGEST")
+	for _, report := range reports {
+		digest := report.Digest
+		if report.Error != "" {
+			digest = "error: " + report.Error
+		}
+		fmt.Fprintf(tw, "%s\t\t\t%s\n", report.RunImage, digest)
+		for _, mirror := range report.Mirrors {
+			digest := mirror.Digest
+			if mirror.Error != "" {
+				digest = "error: " + mirror.Error
+			}
+			fmt.Fprintf(tw, "\t%s\t%s\t%s\n", mirror.Mirror, mirror.Status, digest)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	logger.Info(strings.TrimSuffix(buf.String(), "\n"))
+	return nil
+}
+
 func dedupAndSortSlice(slice []string) []string {
 	set := stringset.FromSlice(slice)
 	var newSlice []string
@@ -7,15 +7,19 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/heroku/color"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 	"github.com/spf13/cobra"
 
 	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
 	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
 	h "github.com/buildpacks/pack/testhelpers"
 )
@@ -28,15 +32,17 @@ func TestConfigRunImageMirrors(t *testing.T) {
 
 func testConfigRunImageMirrorsCommand(t *testing.T, when spec.G, it spec.S) {
 	var (
-		cmd          *cobra.Command
-		logger       logging.Logger
-		outBuf       bytes.Buffer
-		tempPackHome string
-		configPath   string
-		runImage     = "test/image"
-		testMirror1  = "example.com/some/run1"
-		testMirror2  = "example.com/some/run2"
-		testCfg      = config.Config{
+		cmd            *cobra.Command
+		logger         logging.Logger
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		tempPackHome   string
+		configPath     string
+		runImage       = "test/image"
+		testMirror1    = "example.com/some/run1"
+		testMirror2    = "example.com/some/run2"
+		testCfg        = config.Config{
 			Experimental: true,
 			RunImages: []config.RunImage{{
 				Image:   runImage,
@@ -58,12 +64,15 @@ func testConfigRunImageMirrorsCommand(t *testing.T, when spec.G, it spec.S) {
 		tempPackHome, err = os.MkdirTemp("", "pack-home")
 		h.AssertNil(t, err)
 		configPath = filepath.Join(tempPackHome, "config.toml")
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
 
-		cmd = commands.ConfigRunImagesMirrors(logger, testCfg, configPath)
+		cmd = commands.ConfigRunImagesMirrors(logger, testCfg, configPath, mockClient)
 		cmd.SetOut(logging.GetWriterForLevel(logger, logging.InfoLevel))
 	})
 
 	it.After(func() {
+		mockController.Finish()
 		h.AssertNil(t, os.RemoveAll(tempPackHome))
 	})
 
@@ -73,7 +82,7 @@ func testConfigRunImageMirrorsCommand(t *testing.T, when spec.G, it spec.S) {
 			h.AssertNil(t, cmd.Execute())
 			output := outBuf.String()
 			h.AssertContains(t, output, "Usage:")
-			for _, command := range []string{"add", "remove", "list"} {
+			for _, command := range []string{"add", "remove", "list", "verify"} {
 				h.AssertContains(t, output, command)
 			}
 		})
@@ -104,7 +113,7 @@ func testConfigRunImageMirrorsCommand(t *testing.T, when spec.G, it spec.S) {
 			it("fails to run", func() {
 				fakePath := filepath.Join(tempPackHome, "not-exist.toml")
 				h.AssertNil(t, os.WriteFile(fakePath, []byte("something"), 0001))
-				cmd = commands.ConfigRunImagesMirrors(logger, config.Config{}, fakePath)
+				cmd = commands.ConfigRunImagesMirrors(logger, config.Config{}, fakePath, mockClient)
 				cmd.SetArgs([]string{"add", runImage, "-m", testMirror1})
 
 				err := cmd.Execute()
@@ -156,7 +165,7 @@ func testConfigRunImageMirrorsCommand(t *testing.T, when spec.G, it spec.S) {
 			it("fails to run", func() {
 				fakePath := filepath.Join(tempPackHome, "not-exist.toml")
 				h.AssertNil(t, os.WriteFile(fakePath, []byte("something"), 0001))
-				cmd = commands.ConfigRunImagesMirrors(logger, testCfg, fakePath)
+				cmd = commands.ConfigRunImagesMirrors(logger, testCfg, fakePath, mockClient)
 				cmd.SetArgs([]string{"remove", runImage, "-m", testMirror1})
 
 				err := cmd.Execute()
@@ -197,7 +206,7 @@ func testConfigRunImageMirrorsCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 
 			it("preserves all mirrors aside from the given run image", func() {
-				cmd = commands.ConfigRunImagesMirrors(logger, expandedCfg, configPath)
+				cmd = commands.ConfigRunImagesMirrors(logger, expandedCfg, configPath, mockClient)
 				cmd.SetArgs([]string{"remove", runImage})
 				h.AssertNil(t, cmd.Execute())
 
@@ -224,7 +233,7 @@ func testConfigRunImageMirrorsCommand(t *testing.T, when spec.G, it spec.S) {
 
 		when("no run image mirrors were set", func() {
 			it("prints a clear message", func() {
-				cmd = commands.ConfigRunImagesMirrors(logger, config.Config{}, configPath)
+				cmd = commands.ConfigRunImagesMirrors(logger, config.Config{}, configPath, mockClient)
 				cmd.SetArgs([]string{"list"})
 				h.AssertNil(t, cmd.Execute())
 				output := outBuf.String()
@@ -238,7 +247,7 @@ func testConfigRunImageMirrorsCommand(t *testing.T, when spec.G, it spec.S) {
 		when("run image provided", func() {
 			when("mirrors are set", func() {
 				it("returns image mirrors", func() {
-					cmd = commands.ConfigRunImagesMirrors(logger, expandedCfg, configPath)
+					cmd = commands.ConfigRunImagesMirrors(logger, expandedCfg, configPath, mockClient)
 					cmd.SetArgs([]string{"list", "new-image"})
 					h.AssertNil(t, cmd.Execute())
 					output := outBuf.String()
@@ -263,4 +272,54 @@ func testConfigRunImageMirrorsCommand(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+	when("verify", func() {
+		it("reports the status of every mirror", func() {
+			mockClient.EXPECT().
+				VerifyRunImageMirrors(gomock.Any(), client.VerifyRunImageMirrorsOptions{
+					Mirrors: map[string][]string{runImage: {testMirror1, testMirror2}},
+					Timeout: client.DefaultRunImageMirrorTimeout,
+				}).
+				Return([]client.RunImageMirrorsReport{{
+					RunImage: runImage,
+					Digest:   "sha256:aaa",
+					Mirrors: []client.RunImageMirrorInfo{
+						{Mirror: testMirror1, Digest: "sha256:aaa", Status: client.RunImageMirrorInSync},
+						{Mirror: testMirror2, Digest: "sha256:bbb", Status: client.RunImageMirrorStale},
+					},
+				}}, nil)
+
+			cmd.SetArgs([]string{"verify"})
+			err := cmd.Execute()
+			h.AssertError(t, err, "1 run image mirrors are not in sync with their run image")
+			output := outBuf.String()
+			h.AssertContains(t, output, "RUN IMAGE   MIRROR                 STATUS   DIGEST")
+			h.AssertContains(t, output, "test/image                                  sha256:aaa")
+			h.AssertContains(t, output, "            example.com/some/run1  in-sync  sha256:aaa")
+			h.AssertContains(t, output, "            example.com/some/run2  stale    sha256:bbb")
+		})
+
+		it("succeeds when every mirror is in sync", func() {
+			mockClient.EXPECT().
+				VerifyRunImageMirrors(gomock.Any(), client.VerifyRunImageMirrorsOptions{
+					Mirrors: map[string][]string{runImage: {testMirror1, testMirror2}},
+					Timeout: 3 * time.Second,
+				}).
+				Return([]client.RunImageMirrorsReport{{
+					RunImage: runImage,
+					Digest:   "sha256:aaa",
+					Mirrors: []client.RunImageMirrorInfo{
+						{Mirror: testMirror1, Digest: "sha256:aaa", Status: client.RunImageMirrorInSync},
+					},
+				}}, nil)
+
+			cmd.SetArgs([]string{"verify", runImage, "--timeout", "3s", "-o", "json"})
+			h.AssertNil(t, cmd.Execute())
+			h.AssertContains(t, outBuf.String(), `"status": "in-sync"`)
+		})
+
+		it("fails when no mirrors are set for the run image", func() {
+			cmd.SetArgs([]string{"verify", "other/image"})
+			h.AssertError(t, cmd.Execute(), "no run image mirrors have been set for 'other/image'")
+		})
+	})
 }
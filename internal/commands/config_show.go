@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ConfigShow shows the effective configuration, and optionally where each of its values came from
+func ConfigShow(logger logging.Logger, effective config.Layered) *cobra.Command {
+	var showOrigin bool
+
+	cmd := &cobra.Command{
+		Use:   "show",
+		Args:  cobra.NoArgs,
+		Short: "Show the effective configuration",
+		Long: "Show the configuration used by pack commands run from the current directory.\n\n" +
+			fmt.Sprintf("Values from the project config (%s in the current directory or one of its parents) ", config.ProjectConfigPath) +
+			"take precedence over the user config, and config environment variables take precedence over both. " +
+			"Command flags take precedence over all of them. See `pack config --help` for the environment variables.\n\n" +
+			"`pack build` and `pack detect` look up the project config from the app --path instead of the current directory. " +
+			"A project config can't trust builders, and pack warns when it changes where images are pulled from.",
+		Example: "pack config show --origin",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if len(effective.Values) == 0 {
+				logger.Info("No configuration values have been set")
+				return nil
+			}
+
+			buf := &bytes.Buffer{}
+			tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+			if showOrigin {
+				fmt.Fprintln(tw, "KEY\tVALUE\tORIGIN")
+			} else {
+				fmt.Fprintln(tw, "KEY\tVALUE")
+			}
+			for _, value := range effective.Values {
+				if showOrigin {
+					fmt.Fprintf(tw, "%s\t%s\t%s\n", value.Key, value.Value, value.Origin)
+				} else {
+					fmt.Fprintf(tw, "%s\t%s\n", value.Key, value.Value)
+				}
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+
+			logger.Info(strings.TrimSuffix(buf.String(), "\n"))
+			return nil
+		}),
+	}
+
+	cmd.Flags().BoolVar(&showOrigin, "origin", false, "Show where each value came from")
+	AddHelpFlag(cmd, "show")
+	return cmd
+}
@@ -0,0 +1,69 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestConfigShow(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ConfigShowCommand", testConfigShowCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testConfigShowCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		logger    logging.Logger
+		outBuf    bytes.Buffer
+		effective = config.Merge(
+			config.Layer{
+				Origin: config.Origin{Source: config.SourceUser, Path: "/home/user/.pack/config.toml"},
+				Config: config.Config{DefaultBuilder: "user/builder", PullPolicy: "always"},
+			},
+			config.Layer{
+				Origin: config.Origin{Source: config.SourceProject, Path: "/repo/.pack/config.toml"},
+				Config: config.Config{DefaultBuilder: "project/builder"},
+			},
+		)
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+	})
+
+	it("shows the effective values", func() {
+		cmd := commands.ConfigShow(logger, effective)
+		cmd.SetArgs([]string{})
+		h.AssertNil(t, cmd.Execute())
+		h.AssertEq(t, outBuf.String(), `KEY                    VALUE
+default-builder-image  project/builder
+pull-policy            always
+`)
+	})
+
+	it("shows where each value came from with --origin", func() {
+		cmd := commands.ConfigShow(logger, effective)
+		cmd.SetArgs([]string{"--origin"})
+		h.AssertNil(t, cmd.Execute())
+		h.AssertEq(t, outBuf.String(), `KEY                    VALUE            ORIGIN
+default-builder-image  project/builder  project config /repo/.pack/config.toml
+pull-policy            always           user config /home/user/.pack/config.toml
+`)
+	})
+
+	it("explains when nothing has been set", func() {
+		cmd := commands.ConfigShow(logger, config.Layered{})
+		cmd.SetArgs([]string{})
+		h.AssertNil(t, cmd.Execute())
+		h.AssertContains(t, outBuf.String(), "No configuration values have been set")
+	})
+}
@@ -46,7 +46,7 @@ func testConfigCommand(t *testing.T, when spec.G, it spec.S) {
 		h.AssertNil(t, err)
 		configPath = filepath.Join(tempPackHome, "config.toml")
 
-		command = commands.NewConfigCommand(logger, config.Config{Experimental: true}, configPath, mockClient)
+		command = commands.NewConfigCommand(logger, config.Config{Experimental: true}, configPath, mockClient, config.Layered{})
 		command.SetOut(logging.GetWriterForLevel(logger, logging.InfoLevel))
 	})
 
@@ -60,10 +60,18 @@ func testConfigCommand(t *testing.T, when spec.G, it spec.S) {
 			h.AssertNil(t, command.Execute())
 			output := outBuf.String()
 			h.AssertContains(t, output, "Usage:")
-			for _, command := range []string{"trusted-builders", "run-image-mirrors", "default-builder", "experimental", "registries", "pull-policy", "registry-mirrors"} {
+			for _, command := range []string{"trusted-builders", "run-image-mirrors", "default-builder", "experimental", "registries", "pull-policy", "registry-mirrors", "show"} {
 				h.AssertContains(t, output, command)
 			}
 		})
+
+		it("documents the config environment variables", func() {
+			command.SetArgs([]string{"--help"})
+			h.AssertNil(t, command.Execute())
+			output := outBuf.String()
+			h.AssertContains(t, output, "PACK_DEFAULT_BUILDER        default-builder-image  <builder>")
+			h.AssertContains(t, output, "PACK_REGISTRY_MIRRORS       registry-mirrors       <registry>=<mirror>,...")
+		})
 	})
 }
 
@@ -0,0 +1,285 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+type DetectFlags struct {
+	TrustBuilder   bool
+	DockerHost     string
+	AppPath        string
+	Builder        string
+	RunImage       string
+	Platform       string
+	Policy         string
+	Network        string
+	DescriptorPath string
+	Profile        string
+	LifecycleImage string
+	OutputDir      string
+	Env            []string
+	EnvFiles       []string
+	Secrets        []string
+	Volumes        []string
+}
+
+// Detect runs only the detect phase of the lifecycle against the app
+func Detect(logger logging.Logger, cfg config.Config, packClient PackClient) *cobra.Command {
+	var flags DetectFlags
+
+	cmd := &cobra.Command{
+		Use:     "detect",
+		Args:    cobra.NoArgs,
+		Short:   "Run only the detect phase against the app",
+		Example: "pack detect --path apps/test-app --builder cnbs/sample-builder:bionic",
+		Long: "Pack Detect runs the detect phase of the lifecycle against source code, without building an image.\n\n" +
+			"It reports which group of the builder order passed, the status of each buildpack, and the resulting build plan. " +
+			"Use `--output-dir` to keep the group.toml and plan.toml written by the detector.",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			descriptor, actualDescriptorPath, err := parseProjectToml(flags.AppPath, flags.DescriptorPath, flags.Profile, logger)
+			if err != nil {
+				return err
+			}
+
+			if actualDescriptorPath != "" {
+				logger.Debugf("Using project descriptor located at %s", style.Symbol(actualDescriptorPath))
+			}
+
+			builder := flags.Builder
+			// We only override the builder to the one in the project descriptor
+			// if it was not explicitly set by the user
+			if !cmd.Flags().Changed("builder") && descriptor.Build.Builder != "" {
+				builder = descriptor.Build.Builder
+			}
+
+			if builder == "" {
+				suggestSettingBuilder(logger, packClient)
+				return client.NewSoftError()
+			}
+
+			env, err := parseEnv(flags.EnvFiles, flags.Env)
+			if err != nil {
+				return err
+			}
+
+			trustBuilder := isTrustedBuilder(cfg, builder) || flags.TrustBuilder
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+			}
+
+			var lifecycleImage string
+			if flags.LifecycleImage != "" {
+				ref, err := name.ParseReference(flags.LifecycleImage)
+				if err != nil {
+					return errors.Wrapf(err, "parsing lifecycle image %s", flags.LifecycleImage)
+				}
+				lifecycleImage = ref.Name()
+			}
+
+			result, err := packClient.Detect(cmd.Context(), client.DetectOptions{
+				BuildOptions: client.BuildOptions{
+					AppPath:           flags.AppPath,
+					Builder:           builder,
+					AdditionalMirrors: getMirrors(cfg),
+					RunImage:          flags.RunImage,
+					Env:               env,
+					Secrets:           flags.Secrets,
+					DockerHost:        flags.DockerHost,
+					Platform:          flags.Platform,
+					PullPolicy:        pullPolicy,
+					TrustBuilder: func(string) bool {
+						return trustBuilder
+					},
+					ContainerConfig: client.ContainerConfig{
+						Network: flags.Network,
+						Volumes: flags.Volumes,
+					},
+					ProjectDescriptorBaseDir: filepath.Dir(actualDescriptorPath),
+					ProjectDescriptor:        descriptor,
+					LifecycleImage:           lifecycleImage,
+					GroupID:                  -1,
+					UserID:                   -1,
+				},
+				DestinationDir: flags.OutputDir,
+			})
+			if err != nil {
+				return errors.Wrap(err, "failed to detect")
+			}
+
+			if err := writeDetectResult(logger, builder, result); err != nil {
+				return err
+			}
+
+			if flags.OutputDir != "" {
+				logger.Infof("\nWrote group.toml and plan.toml to %s", style.Symbol(flags.OutputDir))
+			}
+			return nil
+		}),
+	}
+
+	cmd.Flags().StringVarP(&flags.AppPath, "path", "p", "", "Path to app dir or zip-formatted file (defaults to current working directory)")
+	cmd.Flags().StringVarP(&flags.Builder, "builder", "B", cfg.DefaultBuilder, "Builder image")
+	cmd.Flags().StringVarP(&flags.DescriptorPath, "descriptor", "d", "", "Path to the project descriptor file")
+	cmd.Flags().StringVar(&flags.Profile, "profile", "", "Build profile declared in the project descriptor as [io.buildpacks.profiles.<name>] to use")
+	cmd.Flags().StringArrayVarP(&flags.Env, "env", "e", []string{}, "Build-time environment variable, in the form 'VAR=VALUE' or 'VAR'.\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed.\nThis flag may be specified multiple times and will override\n  individual values defined by --env-file."+stringArrayHelp("env"))
+	cmd.Flags().StringArrayVar(&flags.Secrets, "secret", []string{}, "Secret of the project descriptor allowed to be read from an environment variable, by name.\nSecrets are only provided to the build and are never stored in an image."+stringArrayHelp("secret"))
+	cmd.Flags().StringArrayVar(&flags.EnvFiles, "env-file", []string{}, "Build-time environment variables file\nOne variable per line, of the form 'VAR=VALUE' or 'VAR'\nWhen using latter value-less form, value will be taken from current\n  environment at the time this command is executed")
+	cmd.Flags().StringVar(&flags.Network, "network", "", "Connect detect container to network")
+	cmd.Flags().StringVar(&flags.DockerHost, "docker-host", "", "Address to docker daemon that will be exposed to the detect container.\nSpecial value 'inherit' may be used in which case DOCKER_HOST environment variable will be used.")
+	cmd.Flags().StringVar(&flags.LifecycleImage, "lifecycle-image", cfg.LifecycleImage, `Custom lifecycle image to use for detection when builder is untrusted.`)
+	cmd.Flags().StringVar(&flags.Platform, "platform", "", `Platform to detect on (e.g., "linux/amd64").`)
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. (default "always")`)
+	cmd.Flags().StringVar(&flags.RunImage, "run-image", "", "Run image (defaults to default stack's run image)")
+	cmd.Flags().BoolVar(&flags.TrustBuilder, "trust-builder", false, "Trust the provided builder")
+	cmd.Flags().StringArrayVar(&flags.Volumes, "volume", nil, "Mount host volume into the detect container, in the form '<host path>:<target path>[:<options>]'."+stringArrayHelp("volume"))
+	cmd.Flags().StringVar(&flags.OutputDir, "output-dir", "", "Path to write the group.toml and plan.toml produced by detection to")
+	AddHelpFlag(cmd, "detect")
+	return cmd
+}
+
+func writeDetectResult(logger logging.Logger, builder string, result *client.DetectResult) error {
+	for i, group := range result.Groups {
+		if group.Status == client.DetectStatusPass {
+			logger.Infof("Detection passed using group %d of builder %s\n", i+1, style.Symbol(builder))
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	if len(result.Groups) > 0 {
+		for i, group := range result.Groups {
+			fmt.Fprintf(tw, "Group %d: %s\n", i+1, group.Status)
+			for _, bp := range group.Buildpacks {
+				fmt.Fprintf(tw, "  %s\t%s%s\n", bp.Status, bp.FullName(), optionalSuffix(bp.Optional))
+			}
+		}
+	} else {
+		fmt.Fprintln(tw, "Passing group:")
+		for _, bp := range result.Group.Group {
+			fmt.Fprintf(tw, "  %s\t%s\n", client.DetectStatusPass, groupElementName(bp))
+		}
+	}
+
+	if len(result.Extensions) > 0 {
+		fmt.Fprintln(tw, "Extensions:")
+		for _, ext := range result.Extensions {
+			fmt.Fprintf(tw, "  %s\t%s\n", ext.Status, ext.FullName())
+		}
+	} else if len(result.Groups) == 0 && len(result.Group.GroupExtensions) > 0 {
+		fmt.Fprintln(tw, "Extensions:")
+		for _, ext := range result.Group.GroupExtensions {
+			fmt.Fprintf(tw, "  %s\t%s\n", client.DetectStatusPass, groupElementName(ext))
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, "flushing tab writer")
+	}
+	logger.Info(strings.TrimSuffix(buf.String(), "\n"))
+
+	if len(result.Groups) > 0 {
+		logger.Info("\nStatuses are inferred from group.toml, the lifecycle only records the buildpacks that passed in the passing group.")
+	}
+
+	logger.Info("\nBuild plan:")
+	if len(result.Plan.Entries) == 0 {
+		logger.Info("  (none)")
+		return nil
+	}
+
+	buf.Reset()
+	tw = tabwriter.NewWriter(buf, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "  NAME\tPROVIDED BY\tREQUIRED VERSIONS")
+	for _, entry := range result.Plan.Entries {
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", planEntryName(entry), planEntryProviders(entry), planEntryVersions(entry))
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, "flushing tab writer")
+	}
+	logger.Info(strings.TrimSuffix(buf.String(), "\n"))
+
+	return nil
+}
+
+func optionalSuffix(optional bool) string {
+	if optional {
+		return " (optional)"
+	}
+
+	return ""
+}
+
+func groupElementName(el buildpack.GroupElement) string {
+	if el.Version == "" {
+		return el.ID
+	}
+
+	return fmt.Sprintf("%s@%s", el.ID, el.Version)
+}
+
+func planEntryName(entry files.BuildPlanEntry) string {
+	var names []string
+	seen := map[string]bool{}
+	for _, req := range entry.Requires {
+		if !seen[req.Name] {
+			seen[req.Name] = true
+			names = append(names, req.Name)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func planEntryProviders(entry files.BuildPlanEntry) string {
+	var providers []string
+	for _, provider := range entry.Providers {
+		providers = append(providers, groupElementName(provider))
+	}
+
+	return strings.Join(providers, ", ")
+}
+
+func planEntryVersions(entry files.BuildPlanEntry) string {
+	var versions []string
+	seen := map[string]bool{}
+	for _, req := range entry.Requires {
+		version := req.Version
+		if version == "" {
+			if v, ok := req.Metadata["version"].(string); ok {
+				version = v
+			}
+		}
+		if version != "" && !seen[version] {
+			seen[version] = true
+			versions = append(versions, version)
+		}
+	}
+
+	if len(versions) == 0 {
+		return "-"
+	}
+
+	return strings.Join(versions, ", ")
+}
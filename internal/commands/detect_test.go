@@ -0,0 +1,186 @@
+package commands_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/pkg/errors"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDetectCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "DetectCommand", testDetectCommand, spec.Random(), spec.Report(report.Terminal{}))
+}
+
+func testDetectCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		logger         *logging.LogWithWriters
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		cfg            config.Config
+		receivedOpts   client.DetectOptions
+		detectResult   *client.DetectResult
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+		cfg = config.Config{}
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		detectResult = &client.DetectResult{
+			Groups: []client.DetectGroupResult{
+				{
+					Status: client.DetectStatusFail,
+					Buildpacks: []client.DetectModuleResult{
+						{ModuleInfo: dist.ModuleInfo{ID: "some/bp-a", Version: "1.0.0"}, Status: client.DetectStatusUnknown},
+					},
+				},
+				{
+					Status: client.DetectStatusPass,
+					Buildpacks: []client.DetectModuleResult{
+						{ModuleInfo: dist.ModuleInfo{ID: "some/bp-b", Version: "2.0.0"}, Status: client.DetectStatusPass},
+						{ModuleInfo: dist.ModuleInfo{ID: "some/bp-c", Version: "3.0.0"}, Optional: true, Status: client.DetectStatusNotParticipating},
+					},
+				},
+				{
+					Status: client.DetectStatusNotEvaluated,
+					Buildpacks: []client.DetectModuleResult{
+						{ModuleInfo: dist.ModuleInfo{ID: "some/bp-d", Version: "4.0.0"}, Status: client.DetectStatusNotEvaluated},
+					},
+				},
+			},
+			Group: buildpack.Group{Group: []buildpack.GroupElement{{ID: "some/bp-b", Version: "2.0.0"}}},
+			Plan: files.Plan{Entries: []files.BuildPlanEntry{
+				{
+					Providers: []buildpack.GroupElement{{ID: "some/bp-b", Version: "2.0.0"}},
+					Requires:  []buildpack.Require{{Name: "node", Version: "18.x"}},
+				},
+			}},
+		}
+
+		mockClient.EXPECT().
+			Detect(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, opts client.DetectOptions) (*client.DetectResult, error) {
+				receivedOpts = opts
+				return detectResult, nil
+			}).
+			AnyTimes()
+
+		command = commands.Detect(logger, cfg, mockClient)
+	})
+
+	when("#Detect", func() {
+		when("no builder is specified", func() {
+			it("returns a soft error", func() {
+				mockClient.EXPECT().
+					InspectBuilder(gomock.Any(), false).
+					Return(&client.BuilderInfo{Description: ""}, nil).
+					AnyTimes()
+
+				command.SetArgs([]string{})
+				err := command.Execute()
+				h.AssertError(t, err, client.NewSoftError().Error())
+			})
+		})
+
+		when("a builder and path are set", func() {
+			it("runs detection with the builder against the path", func() {
+				command.SetArgs([]string{"--builder", "my-builder", "--path", "some-app"})
+				h.AssertNil(t, command.Execute())
+
+				h.AssertEq(t, receivedOpts.Builder, "my-builder")
+				h.AssertEq(t, receivedOpts.AppPath, "some-app")
+				h.AssertEq(t, receivedOpts.PullPolicy, image.PullAlways)
+				h.AssertEq(t, receivedOpts.TrustBuilder("my-builder"), false)
+				h.AssertEq(t, receivedOpts.DestinationDir, "")
+			})
+
+			it("prints the status of each group and buildpack", func() {
+				command.SetArgs([]string{"--builder", "my-builder"})
+				h.AssertNil(t, command.Execute())
+
+				h.AssertContains(t, outBuf.String(), "Detection passed using group 2 of builder 'my-builder'")
+				h.AssertContains(t, outBuf.String(), "Group 1: fail\n  unknown  some/bp-a@1.0.0")
+				h.AssertContains(t, outBuf.String(), "Group 2: pass\n  pass               some/bp-b@2.0.0\n  not participating  some/bp-c@3.0.0 (optional)")
+				h.AssertContains(t, outBuf.String(), "Group 3: not evaluated\n  not evaluated  some/bp-d@4.0.0")
+				h.AssertContains(t, outBuf.String(), "Statuses are inferred from group.toml")
+			})
+
+			it("prints the build plan", func() {
+				command.SetArgs([]string{"--builder", "my-builder"})
+				h.AssertNil(t, command.Execute())
+
+				h.AssertContains(t, outBuf.String(), "Build plan:")
+				h.AssertContainsMatch(t, outBuf.String(), `node\s+some/bp-b@2.0.0\s+18.x`)
+			})
+		})
+
+		when("the order is not known", func() {
+			it.Before(func() {
+				detectResult.Groups = nil
+			})
+
+			it("prints the passing group", func() {
+				command.SetArgs([]string{"--builder", "my-builder"})
+				h.AssertNil(t, command.Execute())
+
+				h.AssertContains(t, outBuf.String(), "Passing group:\n  pass  some/bp-b@2.0.0")
+			})
+		})
+
+		when("--output-dir is provided", func() {
+			it("passes it to the client", func() {
+				command.SetArgs([]string{"--builder", "my-builder", "--output-dir", "some-dir"})
+				h.AssertNil(t, command.Execute())
+
+				h.AssertEq(t, receivedOpts.DestinationDir, "some-dir")
+				h.AssertContains(t, outBuf.String(), "Wrote group.toml and plan.toml to 'some-dir'")
+			})
+		})
+
+		when("the builder is trusted", func() {
+			it("sets the trust builder option", func() {
+				command = commands.Detect(logger, config.Config{TrustedBuilders: []config.TrustedBuilder{{Name: "my-builder"}}}, mockClient)
+				command.SetArgs([]string{"--builder", "my-builder"})
+				h.AssertNil(t, command.Execute())
+
+				h.AssertEq(t, receivedOpts.TrustBuilder("my-builder"), true)
+			})
+		})
+
+		when("detection fails", func() {
+			it("returns an error", func() {
+				mockClient = testmocks.NewMockPackClient(mockController)
+				mockClient.EXPECT().
+					Detect(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("no buildpacks participating"))
+
+				command = commands.Detect(logger, cfg, mockClient)
+				command.SetArgs([]string{"--builder", "my-builder"})
+				h.AssertError(t, command.Execute(), "failed to detect: no buildpacks participating")
+			})
+		})
+	})
+}
@@ -1,35 +1,81 @@
 package commands
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
 	"github.com/spf13/cobra"
 
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
 // ExtensionNewFlags define flags provided to the ExtensionNew command
 type ExtensionNewFlags struct {
-	API     string
-	Path    string
-	Stacks  []string
-	Version string
+	API      string
+	Path     string
+	Template string
+	Version  string
 }
 
-// extensioncreator type to be added here and argument also to be added in the function
+// ExtensionCreator creates extensions
+type ExtensionCreator interface {
+	NewExtension(ctx context.Context, options client.NewExtensionOptions) error
+}
 
 // ExtensionNew generates the scaffolding of an extension
-func ExtensionNew(logger logging.Logger) *cobra.Command {
+func ExtensionNew(logger logging.Logger, creator ExtensionCreator) *cobra.Command {
+	var flags ExtensionNewFlags
 	cmd := &cobra.Command{
 		Use:     "new <id>",
 		Short:   "Creates basic scaffolding of an extension",
 		Args:    cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
-		Example: "pack extension new <example-extension>",
+		Example: "pack extension new sample/my-extension\npack extension new sample/my-extension --template apt-install",
+		Long: "extension new generates the basic scaffolding of an image extension repository. It creates a new directory `name` in the current directory (or at `path`, if passed as a flag), and initializes an extension.toml, and two executable bash scripts, `bin/detect` and `bin/generate`. \n\n" +
+			"When a template is provided, it also generates a working detect and generate skeleton writing Dockerfiles for a common pattern, a package.toml and a README. " +
+			"The `apt-install` template installs packages on the build image, and the `run-image` template switches the run image. " +
+			"Custom templates are directories or tar archives, where files ending with `.tmpl` are rendered as Go templates, and all other files are copied as is.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			// logic will go here
+			id := args[0]
+			idParts := strings.Split(id, "/")
+			dirName := idParts[len(idParts)-1]
+
+			path := flags.Path
+			if path == "" {
+				cwd, err := os.Getwd()
+				if err != nil {
+					return err
+				}
+				path = filepath.Join(cwd, dirName)
+			}
+
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				return fmt.Errorf("directory %s exists", style.Symbol(path))
+			}
+
+			if err := creator.NewExtension(cmd.Context(), client.NewExtensionOptions{
+				API:      flags.API,
+				ID:       id,
+				Path:     path,
+				Template: flags.Template,
+				Version:  flags.Version,
+			}); err != nil {
+				return err
+			}
+
+			logger.Infof("Successfully created %s", style.Symbol(id))
 			return nil
 		}),
 	}
 
-	// flags will go here
+	cmd.Flags().StringVarP(&flags.API, "api", "a", "0.10", "Buildpack API compatibility of the generated extension")
+	cmd.Flags().StringVarP(&flags.Path, "path", "p", "", "Path to generate the extension")
+	cmd.Flags().StringVarP(&flags.Version, "version", "V", "1.0.0", "Version of the generated extension")
+	cmd.Flags().StringVar(&flags.Template, "template", "", fmt.Sprintf("Template to generate the extension from, one of %s, or a path to a template directory or archive (use ./<name> for a local template named like a builtin one)", strings.Join(client.ExtensionTemplates(), ", ")))
 
 	AddHelpFlag(cmd, "new")
 	return cmd
@@ -0,0 +1,87 @@
+package commands_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExtensionNewCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ExtensionNewCommand", testExtensionNewCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testExtensionNewCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		tmpDir         string
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "extension-new-test")
+		h.AssertNil(t, err)
+
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		command = commands.ExtensionNew(logger, mockClient)
+	})
+
+	it.After(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	when("ExtensionNew#Execute", func() {
+		it("uses the args to generate artifacts", func() {
+			path := filepath.Join(tmpDir, "some-extension")
+			mockClient.EXPECT().NewExtension(gomock.Any(), client.NewExtensionOptions{
+				API:     "0.10",
+				ID:      "example/some-extension",
+				Path:    path,
+				Version: "1.0.0",
+			}).Return(nil)
+
+			command.SetArgs([]string{"--path", path, "example/some-extension"})
+			h.AssertNil(t, command.Execute())
+			h.AssertContains(t, outBuf.String(), "Successfully created 'example/some-extension'")
+		})
+
+		it("passes the template to the client", func() {
+			path := filepath.Join(tmpDir, "some-extension")
+			mockClient.EXPECT().NewExtension(gomock.Any(), client.NewExtensionOptions{
+				API:      "0.9",
+				ID:       "example/some-extension",
+				Path:     path,
+				Version:  "1.0.0",
+				Template: "apt-install",
+			}).Return(nil)
+
+			command.SetArgs([]string{"--path", path, "--api", "0.9", "--template", "apt-install", "example/some-extension"})
+			h.AssertNil(t, command.Execute())
+		})
+
+		it("stops if the directory already exists", func() {
+			command.SetArgs([]string{"--path", tmpDir, "example/some-extension"})
+			h.AssertError(t, command.Execute(), "exists")
+		})
+	})
+}
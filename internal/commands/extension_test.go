@@ -43,7 +43,7 @@ func testExtensionCommand(t *testing.T, when spec.G, it spec.S) {
 			h.AssertNil(t, cmd.Execute())
 			output := outBuf.String()
 			h.AssertContains(t, output, "Interact with extensions")
-			for _, command := range []string{"Usage", "package", "register", "yank", "pull", "inspect"} {
+			for _, command := range []string{"Usage", "package", "register", "yank", "pull", "inspect", "new", "validate"} {
 				h.AssertContains(t, output, command)
 			}
 		})
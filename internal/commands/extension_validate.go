@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// ExtensionValidateFlags define flags provided to the ExtensionValidate command
+type ExtensionValidateFlags struct {
+	App        string
+	BuildImage string
+	Env        []string
+	Policy     string
+}
+
+// ExtensionValidate runs an extension and checks the Dockerfiles it generates
+func ExtensionValidate(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
+	var flags ExtensionValidateFlags
+
+	cmd := &cobra.Command{
+		Use:     "validate <extension-dir>",
+		Args:    cobra.ExactArgs(1),
+		Short:   "Validate the Dockerfiles an extension generates",
+		Example: "pack extension validate ./my-extension --build-image cnbs/sample-base-build:jammy",
+		Long: "Runs bin/detect and bin/generate of an extension against an app, inside the build image, and checks the files it writes " +
+			"the same way the lifecycle does:\n\n" +
+			"  - build.Dockerfile must start with a single 'ARG base_image' followed by a single 'FROM ${base_image}'\n" +
+			"  - run.Dockerfile must have a single FROM instruction, either 'FROM <image>' to switch the run image or 'FROM ${base_image}' to extend it\n" +
+			"  - instructions other than ADD, ARG, COPY, ENV, FROM, LABEL, RUN, SHELL, USER and WORKDIR are reported as warnings\n" +
+			"  - extend-config.toml may only set [[build.args]] and [[run.args]] not provided by the lifecycle",
+		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
+			if flags.BuildImage == "" {
+				return errors.New("build image must be provided with --build-image")
+			}
+
+			env, err := parseEnv(nil, flags.Env)
+			if err != nil {
+				return err
+			}
+
+			stringPolicy := flags.Policy
+			if stringPolicy == "" {
+				stringPolicy = cfg.PullPolicy
+			}
+			pullPolicy, err := image.ParsePullPolicy(stringPolicy)
+			if err != nil {
+				return errors.Wrapf(err, "parsing pull policy %s", flags.Policy)
+			}
+
+			report, err := pack.ValidateExtension(cmd.Context(), client.ValidateExtensionOptions{
+				ExtensionPath: args[0],
+				AppPath:       flags.App,
+				Env:           env,
+				BuildImage:    flags.BuildImage,
+				PullPolicy:    pullPolicy,
+			})
+			if err != nil {
+				return errors.Wrapf(err, "validating extension %s", style.Symbol(args[0]))
+			}
+
+			logger.Infof("Validating extension %s\n", style.Symbol(report.Extension.FullName()))
+			if len(report.Generated) == 0 {
+				logger.Info("  generate did not write any files")
+			}
+			for _, name := range report.Generated {
+				logger.Infof("  generated  %s", name)
+			}
+			if len(report.Warnings) > 0 {
+				logger.Info("")
+				for _, warning := range report.Warnings {
+					logger.Warn(warning)
+				}
+			}
+
+			if report.Valid() {
+				logger.Infof("\nExtension %s is valid", style.Symbol(report.Extension.FullName()))
+				return nil
+			}
+
+			logger.Info("")
+			for _, problem := range report.Problems {
+				logger.Infof("  %s", problem)
+			}
+			if output := strings.TrimSpace(report.Output); output != "" {
+				logger.Debugf("\nOutput:\n%s", output)
+			}
+			return errors.Errorf("found %d problem(s) with extension %s", len(report.Problems), style.Symbol(report.Extension.FullName()))
+		}),
+	}
+
+	cmd.Flags().StringVar(&flags.App, "app", "", "Path to the app to run bin/detect and bin/generate against (defaults to an empty app)")
+	cmd.Flags().StringVar(&flags.BuildImage, "build-image", "", "Image to run bin/detect and bin/generate in")
+	cmd.Flags().StringArrayVarP(&flags.Env, "env", "e", []string{}, "Build-time environment variable provided to the extension, in the form 'VAR=VALUE'")
+	cmd.Flags().StringVar(&flags.Policy, "pull-policy", "", `Pull policy to use. Accepted values are always, never, and if-not-present. The default is always`)
+	AddHelpFlag(cmd, "validate")
+	return cmd
+}
@@ -0,0 +1,96 @@
+package commands_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/spf13/cobra"
+
+	"github.com/buildpacks/pack/internal/commands"
+	"github.com/buildpacks/pack/internal/commands/testmocks"
+	"github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExtensionValidateCommand(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ExtensionValidateCommand", testExtensionValidateCommand, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testExtensionValidateCommand(t *testing.T, when spec.G, it spec.S) {
+	var (
+		command        *cobra.Command
+		outBuf         bytes.Buffer
+		mockController *gomock.Controller
+		mockClient     *testmocks.MockPackClient
+		extension      = dist.ModuleInfo{ID: "example/some-extension", Version: "1.0.0"}
+	)
+
+	it.Before(func() {
+		logger := logging.NewLogWithWriters(&outBuf, &outBuf)
+		mockController = gomock.NewController(t)
+		mockClient = testmocks.NewMockPackClient(mockController)
+
+		command = commands.ExtensionValidate(logger, config.Config{}, mockClient)
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("requires a build image", func() {
+		command.SetArgs([]string{"some/extension"})
+		h.AssertError(t, command.Execute(), "build image must be provided with --build-image")
+	})
+
+	it("reports a valid extension", func() {
+		mockClient.EXPECT().ValidateExtension(gomock.Any(), client.ValidateExtensionOptions{
+			ExtensionPath: "some/extension",
+			AppPath:       "some/app",
+			Env:           map[string]string{"BP_APT_PACKAGES": "curl git"},
+			BuildImage:    "some/build-image",
+			PullPolicy:    image.PullAlways,
+		}).Return(&client.ExtensionValidationReport{
+			Extension: extension,
+			Generated: []string{"build.Dockerfile"},
+		}, nil)
+
+		command.SetArgs([]string{"some/extension", "--build-image", "some/build-image", "--app", "some/app", "--env", "BP_APT_PACKAGES=curl git"})
+		h.AssertNil(t, command.Execute())
+		h.AssertContains(t, outBuf.String(), "generated  build.Dockerfile")
+		h.AssertContains(t, outBuf.String(), "Extension 'example/some-extension@1.0.0' is valid")
+	})
+
+	it("fails listing the problems found", func() {
+		mockClient.EXPECT().ValidateExtension(gomock.Any(), gomock.Any()).Return(&client.ExtensionValidationReport{
+			Extension: extension,
+			Generated: []string{"build.Dockerfile"},
+			Problems:  []string{"build.Dockerfile did not start with required ARG command"},
+		}, nil)
+
+		command.SetArgs([]string{"some/extension", "--build-image", "some/build-image"})
+		h.AssertError(t, command.Execute(), "found 1 problem(s) with extension 'example/some-extension@1.0.0'")
+		h.AssertContains(t, outBuf.String(), "build.Dockerfile did not start with required ARG command")
+	})
+	it("reports the warnings of a valid extension", func() {
+		mockClient.EXPECT().ValidateExtension(gomock.Any(), gomock.Any()).Return(&client.ExtensionValidationReport{
+			Extension: extension,
+			Generated: []string{"build.Dockerfile"},
+			Warnings:  []string{"build.Dockerfile command EXPOSE on line 3 is not recommended"},
+		}, nil)
+
+		command.SetArgs([]string{"some/extension", "--build-image", "some/build-image"})
+		h.AssertNil(t, command.Execute())
+		h.AssertContains(t, outBuf.String(), "Warning: build.Dockerfile command EXPOSE on line 3 is not recommended")
+		h.AssertContains(t, outBuf.String(), "Extension 'example/some-extension@1.0.0' is valid")
+	})
+}
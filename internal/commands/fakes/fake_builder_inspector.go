@@ -10,6 +10,12 @@ type FakeBuilderInspector struct {
 	ErrorForLocal  error
 	ErrorForRemote error
 
+	LayersForLocal        *client.BuilderLayersInfo
+	LayersForRemote       *client.BuilderLayersInfo
+	LayersErrorForLocal   error
+	LayersErrorForRemote  error
+	ReceivedLayersOptions []client.InspectBuilderLayersOptions
+
 	ReceivedForLocalName      string
 	ReceivedForRemoteName     string
 	CalculatedConfigForLocal  client.BuilderInspectionConfig
@@ -37,3 +43,17 @@ func (i *FakeBuilderInspector) InspectBuilder(
 	i.ReceivedForRemoteName = name
 	return i.InfoForRemote, i.ErrorForRemote
 }
+
+func (i *FakeBuilderInspector) InspectBuilderLayers(
+	name string,
+	opts client.InspectBuilderLayersOptions,
+) (*client.BuilderLayersInfo, error) {
+	i.ReceivedLayersOptions = append(i.ReceivedLayersOptions, opts)
+	if opts.Daemon {
+		i.ReceivedForLocalName = name
+		return i.LayersForLocal, i.LayersErrorForLocal
+	}
+
+	i.ReceivedForRemoteName = name
+	return i.LayersForRemote, i.LayersErrorForRemote
+}
@@ -48,6 +48,11 @@ Detection Order:
 {{- else }}
   (none)
 {{ end }}
+{{- if .ListFiles }}
+
+Files:
+{{ .Files }}
+{{- end }}
 `
 
 const (
@@ -127,6 +132,10 @@ func inspectBuildpackOutput(info *client.BuildpackInfo, prefix string, flags Bui
 	if err != nil {
 		return []byte{}, fmt.Errorf("error writing detection order output: %q", err)
 	}
+	filesOutput, err := moduleFilesOutput(info.Files)
+	if err != nil {
+		return []byte{}, fmt.Errorf("error writing files output: %q", err)
+	}
 	buf := bytes.NewBuffer(nil)
 
 	err = tpl.Execute(buf, &struct {
@@ -135,12 +144,16 @@ func inspectBuildpackOutput(info *client.BuildpackInfo, prefix string, flags Bui
 		ListMixins bool
 		Buildpacks string
 		Order      string
+		ListFiles  bool
+		Files      string
 	}{
 		Location:   prefix,
 		Metadata:   info.BuildpackMetadata,
 		ListMixins: flags.Verbose,
 		Buildpacks: bpOutput,
 		Order:      orderOutput,
+		ListFiles:  flags.Files,
+		Files:      filesOutput,
 	})
 
 	if err != nil {
@@ -188,6 +201,37 @@ func buildpacksOutput(bps []dist.ModuleInfo) (string, error) {
 	return strings.TrimSuffix(buf.String(), "\n"), nil
 }
 
+func moduleFilesOutput(modules []client.ModuleFiles) (string, error) {
+	buf := &bytes.Buffer{}
+
+	tabWriter := new(tabwriter.Writer).Init(buf, writerMinWidth, writerTabWidth, writerTabWidth, writerPadChar, tabwriter.AlignRight)
+	for _, module := range modules {
+		if _, err := fmt.Fprintf(tabWriter, "  %s:\n", module.Module.FullName()); err != nil {
+			return "", err
+		}
+		if len(module.Files) == 0 {
+			if _, err := fmt.Fprint(tabWriter, "    (none)\n"); err != nil {
+				return "", err
+			}
+		}
+		for _, file := range module.Files {
+			name := file.Path
+			if file.Linkname != "" {
+				name += " -> " + file.Linkname
+			}
+			if _, err := fmt.Fprintf(tabWriter, "    %s\t  %d\t  %s\n", file.Mode, file.Size, name); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if err := tabWriter.Flush(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
 // Unable to easily convert format makes this feel like a poor solution...
 func detectionOrderOutput(order dist.Order, layers dist.ModuleLayers, maxDepth int) (string, error) {
 	buf := strings.Builder{}
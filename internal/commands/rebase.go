@@ -1,6 +1,12 @@
 package commands
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
 	"github.com/pkg/errors"
 
 	"github.com/spf13/cobra"
@@ -15,17 +21,29 @@ import (
 
 func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Command {
 	var opts client.RebaseOptions
-	var policy string
+	var policy, fromFile, outputFormat string
+	var concurrency int
+	var check bool
 
 	cmd := &cobra.Command{
-		Use:     "rebase <image-name>",
-		Args:    cobra.ExactArgs(1),
+		Use: "rebase <image-name>",
+		Args: func(cmd *cobra.Command, args []string) error {
+			if fromFile != "" {
+				return cobra.NoArgs(cmd, args)
+			}
+			return cobra.ExactArgs(1)(cmd, args)
+		},
 		Short:   "Rebase app image with latest run image",
-		Example: "pack rebase buildpacksio/pack",
+		Example: "pack rebase buildpacksio/pack\npack rebase --from-file images.txt --publish\npack rebase --check --publish registry.example.com/app\npack rebase oci:/path/app --run-image oci:/path/run",
 		Long: "Rebase allows you to quickly swap out the underlying OS layers (run image) of an app image generated by `pack build` " +
-			"with a newer version of the run image, without re-building the application.",
+			"with a newer version of the run image, without re-building the application.\n\n" +
+			"With --from-file, every image listed in the file is rebased, several at a time. The file either lists one image per line, " +
+			"or, with a .yaml or .yml extension, holds an 'images' list whose entries have a 'name' and optionally a 'previous-image' and a 'run-image'. " +
+			"Images already based on the latest run image are skipped.\n\n" +
+			"With --check, nothing is rebased. Instead the command reports whether a newer run image is available, and fails when it is.\n\n" +
+			"Images in OCI layout format are referenced as 'oci:<path>' and rebased in place. A run image in OCI layout format stands in " +
+			"for the run image recorded in the metadata of the app image, which keeps its name.",
 		RunE: logError(logger, func(cmd *cobra.Command, args []string) error {
-			opts.RepoName = args[0]
 			opts.AdditionalMirrors = getMirrors(cfg)
 
 			var err error
@@ -38,6 +56,27 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 				return errors.Wrapf(err, "parsing pull policy %s", stringPolicy)
 			}
 
+			if err := validateRegistryOutputFormat(outputFormat); err != nil {
+				return err
+			}
+
+			if !cfg.Experimental && layoutReference(append([]string{opts.RunImage, opts.PreviousImage}, args...)...) {
+				return client.NewExperimentError("Rebasing images in OCI layout format is currently experimental.")
+			}
+
+			if check {
+				if fromFile != "" {
+					return errors.New("--check cannot be used with --from-file")
+				}
+				opts.RepoName = args[0]
+				return checkRebase(cmd, logger, pack, opts, outputFormat)
+			}
+
+			if fromFile != "" {
+				return bulkRebase(cmd, logger, pack, opts, fromFile, concurrency, outputFormat, cfg.Experimental)
+			}
+
+			opts.RepoName = args[0]
 			if err := pack.Rebase(cmd.Context(), opts); err != nil {
 				return err
 			}
@@ -47,12 +86,149 @@ func Rebase(logger logging.Logger, cfg config.Config, pack PackClient) *cobra.Co
 	}
 
 	cmd.Flags().BoolVar(&opts.Publish, "publish", false, "Publish the rebased application image directly to the container registry specified in <image-name>, instead of the daemon. The previous application image must also reside in the registry.")
-	cmd.Flags().StringVar(&opts.RunImage, "run-image", "", "Run image to use for rebasing")
+	cmd.Flags().BoolVar(&opts.VerifyRunImageMirrors, "verify-run-image-mirrors", false, "Only use a run image mirror with the same digest as the run image, skipping mirrors that time out")
+	cmd.Flags().DurationVar(&opts.RunImageMirrorTimeout, "run-image-mirror-timeout", 0, "How long the run image and each mirror may take to respond with --verify-run-image-mirrors (default 10s)")
+	cmd.Flags().StringVar(&opts.RunImage, "run-image", "", "Run image to use for rebasing, 'oci:<path>' for a run image in OCI layout format")
 	cmd.Flags().StringVar(&policy, "pull-policy", "", "Pull policy to use. Accepted values are always, never, and if-not-present. The default is always")
 	cmd.Flags().StringVar(&opts.PreviousImage, "previous-image", "", "Image to rebase. Set to a particular tag reference, digest reference, or (when performing a daemon build) image ID. Use this flag in combination with <image-name> to avoid replacing the original image.")
 	cmd.Flags().StringVar(&opts.ReportDestinationDir, "report-output-dir", "", "Path to export build report.toml.\nOmitting the flag yield no report file.")
 	cmd.Flags().BoolVar(&opts.Force, "force", false, "Perform rebase operation without target validation (only available for API >= 0.12)")
+	cmd.Flags().StringVar(&fromFile, "from-file", "", "Rebase every image listed in the given file instead of <image-name>")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 4, "Maximum number of images rebased at the same time with --from-file")
+	cmd.Flags().BoolVar(&check, "check", false, "Report whether a newer run image is available instead of rebasing, failing when it is")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", registryOutputHumanReadable, "Output format of the --from-file summary or the --check result (json, human-readable)")
 
 	AddHelpFlag(cmd, "rebase")
 	return cmd
 }
+
+// layoutReference reports whether any of refs refers to an image in OCI layout format.
+func layoutReference(refs ...string) bool {
+	for _, ref := range refs {
+		if client.ParseInputImageReference(ref).Layout() {
+			return true
+		}
+	}
+	return false
+}
+
+func checkRebase(cmd *cobra.Command, logger logging.Logger, pack PackClient, opts client.RebaseOptions, outputFormat string) error {
+	if outputFormat == registryOutputJSON {
+		quietForJSON(logger)
+	}
+	result, err := pack.CheckRebase(cmd.Context(), opts)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == registryOutputJSON {
+		if err := writeJSON(logger, result); err != nil {
+			return err
+		}
+	} else if !result.RebaseAvailable {
+		logger.Infof("Image %s is up to date with run image %s", style.Symbol(result.Image), style.Symbol(result.RunImage))
+	}
+
+	if !result.RebaseAvailable {
+		return nil
+	}
+	if !result.Rebasable && outputFormat != registryOutputJSON {
+		logger.Warnf("Image %s is labeled as not rebasable", style.Symbol(result.Image))
+	}
+	return errors.Errorf("a rebase is available for %s: run image %s has top layer %s, the image is based on %s",
+		style.Symbol(result.Image), style.Symbol(result.RunImage), style.Symbol(result.LatestTopLayer), style.Symbol(result.CurrentTopLayer))
+}
+
+func bulkRebase(cmd *cobra.Command, logger logging.Logger, pack PackClient, opts client.RebaseOptions, fromFile string, concurrency int, outputFormat string, experimental bool) error {
+	if opts.PreviousImage != "" {
+		return errors.New("--previous-image cannot be used with --from-file, set 'previous-image' for the image in the rebase plan instead")
+	}
+	if opts.ReportDestinationDir != "" {
+		return errors.New("--report-output-dir cannot be used with --from-file")
+	}
+	if concurrency < 1 {
+		return errors.Errorf("--concurrency must be at least 1, got %d", concurrency)
+	}
+
+	plan, err := readRebasePlan(fromFile)
+	if err != nil {
+		return err
+	}
+
+	bulkOpts := client.BulkRebaseOptions{Concurrency: concurrency}
+	for _, img := range plan.Images {
+		if !experimental && layoutReference(img.Name, img.PreviousImage, img.RunImage) {
+			return client.NewExperimentError("Rebasing images in OCI layout format is currently experimental.")
+		}
+		imgOpts := opts
+		imgOpts.RepoName = img.Name
+		imgOpts.PreviousImage = img.PreviousImage
+		if img.RunImage != "" {
+			imgOpts.RunImage = img.RunImage
+		}
+		bulkOpts.Images = append(bulkOpts.Images, imgOpts)
+	}
+
+	if outputFormat == registryOutputJSON {
+		quietForJSON(logger)
+	}
+	results, err := pack.BulkRebase(cmd.Context(), bulkOpts)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat == registryOutputJSON {
+		if err := writeJSON(logger, results); err != nil {
+			return err
+		}
+	} else if err := printRebaseSummary(logger, results); err != nil {
+		return err
+	}
+
+	var failed int
+	for _, result := range results {
+		if result.Status == client.RebaseStatusFailed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to rebase %d of %d images", failed, len(results))
+	}
+	return nil
+}
+
+// quietForJSON silences the info output of the logger, which the client shares, so that progress logs don't end up
+// in the middle of the JSON output
+func quietForJSON(logger logging.Logger) {
+	if l, ok := logger.(interface{ WantQuiet(bool) }); ok {
+		l.WantQuiet(true)
+	}
+}
+
+// writeJSON writes v as JSON on the base writer of the logger, which is not filtered by the log level
+func writeJSON(logger logging.Logger, v interface{}) error {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding output")
+	}
+	_, err = fmt.Fprintln(logger.Writer(), string(out))
+	return err
+}
+
+func printRebaseSummary(logger logging.Logger, results []client.RebaseResult) error {
+	counts := map[client.RebaseStatus]int{}
+	buf := &bytes.Buffer{}
+	tw := tabwriter.NewWriter(buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "IMAGE\tSTATUS\tRUN IMAGE")
+	for _, result := range results {
+		counts[result.Status]++
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Image, result.Status, result.RunImage)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	logger.Info(strings.TrimSuffix(buf.String(), "\n"))
+	logger.Infof("Rebased %d, skipped %d, failed %d", counts[client.RebaseStatusRebased], counts[client.RebaseStatusSkipped], counts[client.RebaseStatusFailed])
+	return nil
+}
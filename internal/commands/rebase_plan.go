@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// rebasePlan lists the images to rebase with `pack rebase --from-file`.
+type rebasePlan struct {
+	Images []rebasePlanImage `yaml:"images"`
+}
+
+type rebasePlanImage struct {
+	Name          string `yaml:"name"`
+	PreviousImage string `yaml:"previous-image"`
+	RunImage      string `yaml:"run-image"`
+}
+
+// readRebasePlan reads a rebase plan from path. Files with a .yaml or .yml extension hold a YAML plan,
+// any other file lists one image name per line, ignoring blank lines and lines starting with '#'.
+func readRebasePlan(path string) (rebasePlan, error) {
+	contents, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return rebasePlan{}, errors.Wrapf(err, "reading rebase plan %s", style.Symbol(path))
+	}
+
+	var plan rebasePlan
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(contents, &plan); err != nil {
+			return rebasePlan{}, errors.Wrapf(err, "parsing rebase plan %s", style.Symbol(path))
+		}
+	default:
+		scanner := bufio.NewScanner(bytes.NewReader(contents))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			plan.Images = append(plan.Images, rebasePlanImage{Name: line})
+		}
+		if err := scanner.Err(); err != nil {
+			return rebasePlan{}, errors.Wrapf(err, "reading rebase plan %s", style.Symbol(path))
+		}
+	}
+
+	for i, img := range plan.Images {
+		if img.Name == "" {
+			return rebasePlan{}, errors.Errorf("image %d in rebase plan %s is missing a name", i+1, style.Symbol(path))
+		}
+	}
+	if len(plan.Images) == 0 {
+		return rebasePlan{}, errors.Errorf("rebase plan %s does not list any images", style.Symbol(path))
+	}
+	return plan, nil
+}
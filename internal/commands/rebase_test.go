@@ -2,6 +2,10 @@ package commands_test
 
 import (
 	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/heroku/color"
@@ -197,5 +201,221 @@ func testRebaseCommand(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("images are in OCI layout format", func() {
+			it("errors when experimental features are disabled", func() {
+				command.SetArgs([]string{"oci:/some/app", "--run-image", "oci:/some/run"})
+				h.AssertError(t, command.Execute(), "Rebasing images in OCI layout format is currently experimental.")
+			})
+
+			it("passes the layout references through", func() {
+				cfg.Experimental = true
+				command = commands.Rebase(logger, cfg, mockClient)
+
+				mockClient.EXPECT().
+					Rebase(gomock.Any(), client.RebaseOptions{
+						RepoName:          "oci:/some/app",
+						RunImage:          "oci:/some/run",
+						PullPolicy:        image.PullAlways,
+						AdditionalMirrors: map[string][]string{},
+						Force:             true,
+					}).
+					Return(nil)
+
+				command.SetArgs([]string{"oci:/some/app", "--run-image", "oci:/some/run", "--force"})
+				h.AssertNil(t, command.Execute())
+			})
+		})
+
+		when("--check", func() {
+			var opts client.RebaseOptions
+
+			it.Before(func() {
+				opts = client.RebaseOptions{
+					RepoName:          "test/app",
+					Publish:           true,
+					PullPolicy:        image.PullAlways,
+					AdditionalMirrors: map[string][]string{},
+				}
+			})
+
+			it("reports an image that is up to date", func() {
+				mockClient.EXPECT().
+					CheckRebase(gomock.Any(), opts).
+					Return(client.RebaseCheck{Image: "test/app", RunImage: "test/run", Rebasable: true}, nil)
+
+				command.SetArgs([]string{"test/app", "--check", "--publish"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "Image 'test/app' is up to date with run image 'test/run'")
+			})
+
+			it("fails when a rebase is available", func() {
+				mockClient.EXPECT().
+					CheckRebase(gomock.Any(), opts).
+					Return(client.RebaseCheck{
+						Image:           "test/app",
+						RunImage:        "test/run",
+						CurrentTopLayer: "sha256:old",
+						LatestTopLayer:  "sha256:new",
+						RebaseAvailable: true,
+					}, nil)
+
+				command.SetArgs([]string{"test/app", "--check", "--publish"})
+				h.AssertError(t, command.Execute(), "a rebase is available for 'test/app': run image 'test/run' has top layer 'sha256:new', the image is based on 'sha256:old'")
+				h.AssertContains(t, outBuf.String(), "Warning: Image 'test/app' is labeled as not rebasable")
+			})
+
+			it("prints the result as JSON", func() {
+				mockClient.EXPECT().
+					CheckRebase(gomock.Any(), opts).
+					DoAndReturn(func(_ context.Context, _ client.RebaseOptions) (client.RebaseCheck, error) {
+						logger.Infof("Pulling image 'test/run'")
+						return client.RebaseCheck{
+							Image:            "test/app",
+							RunImage:         "test/run",
+							CurrentReference: "test/run@sha256:old-digest",
+							CurrentTopLayer:  "sha256:old",
+							LatestReference:  "test/run@sha256:new-digest",
+							LatestTopLayer:   "sha256:new",
+							RebaseAvailable:  true,
+							Rebasable:        true,
+						}, nil
+					})
+
+				command.SetArgs([]string{"test/app", "--check", "--publish", "-o", "json"})
+				h.AssertError(t, command.Execute(), "a rebase is available for 'test/app'")
+				h.AssertNotContains(t, outBuf.String(), "Pulling image")
+				h.AssertEq(t, strings.HasPrefix(outBuf.String(), "{\n"), true)
+				h.AssertContains(t, outBuf.String(), `{
+  "image": "test/app",
+  "runImage": "test/run",
+  "currentReference": "test/run@sha256:old-digest",
+  "currentTopLayer": "sha256:old",
+  "latestReference": "test/run@sha256:new-digest",
+  "latestTopLayer": "sha256:new",
+  "rebaseAvailable": true,
+  "rebasable": true
+}`)
+			})
+
+			it("does not accept --from-file", func() {
+				command.SetArgs([]string{"--check", "--from-file", "images.txt"})
+				h.AssertError(t, command.Execute(), "--check cannot be used with --from-file")
+			})
+		})
+
+		when("--from-file", func() {
+			var (
+				tmpDir   string
+				planPath string
+			)
+
+			it.Before(func() {
+				var err error
+				tmpDir, err = os.MkdirTemp("", "rebase-plan")
+				h.AssertNil(t, err)
+
+				planPath = filepath.Join(tmpDir, "images.txt")
+				h.AssertNil(t, os.WriteFile(planPath, []byte("# apps\ntest/app-a\n\ntest/app-b\n"), 0600))
+			})
+
+			it.After(func() {
+				h.AssertNil(t, os.RemoveAll(tmpDir))
+			})
+
+			it("rebases every listed image and prints a summary", func() {
+				mockClient.EXPECT().
+					BulkRebase(gomock.Any(), client.BulkRebaseOptions{
+						Images: []client.RebaseOptions{
+							{RepoName: "test/app-a", Publish: true, PullPolicy: image.PullAlways, RunImage: "test/run", AdditionalMirrors: map[string][]string{}},
+							{RepoName: "test/app-b", Publish: true, PullPolicy: image.PullAlways, RunImage: "test/run", AdditionalMirrors: map[string][]string{}},
+						},
+						Concurrency: 2,
+					}).
+					Return([]client.RebaseResult{
+						{Image: "test/app-a", Status: client.RebaseStatusRebased, RunImage: "test/run"},
+						{Image: "test/app-b", Status: client.RebaseStatusSkipped, RunImage: "test/run"},
+					}, nil)
+
+				command.SetArgs([]string{"--from-file", planPath, "--publish", "--run-image", "test/run", "--concurrency", "2"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), `IMAGE       STATUS   RUN IMAGE
+test/app-a  rebased  test/run
+test/app-b  skipped  test/run
+Rebased 1, skipped 1, failed 0`)
+			})
+
+			it("reads a YAML plan", func() {
+				planPath = filepath.Join(tmpDir, "plan.yaml")
+				h.AssertNil(t, os.WriteFile(planPath, []byte(`images:
+- name: test/app-a
+  run-image: test/other-run
+- name: test/app-b
+  previous-image: test/app-b:previous
+`), 0600))
+
+				mockClient.EXPECT().
+					BulkRebase(gomock.Any(), client.BulkRebaseOptions{
+						Images: []client.RebaseOptions{
+							{RepoName: "test/app-a", PullPolicy: image.PullAlways, RunImage: "test/other-run", AdditionalMirrors: map[string][]string{}},
+							{RepoName: "test/app-b", PullPolicy: image.PullAlways, PreviousImage: "test/app-b:previous", AdditionalMirrors: map[string][]string{}},
+						},
+						Concurrency: 4,
+					}).
+					Return([]client.RebaseResult{
+						{Image: "test/app-a", Status: client.RebaseStatusRebased, RunImage: "test/other-run"},
+						{Image: "test/app-b", Status: client.RebaseStatusRebased, RunImage: "test/run"},
+					}, nil)
+
+				command.SetArgs([]string{"--from-file", planPath})
+				h.AssertNil(t, command.Execute())
+			})
+
+			it("prints the results as JSON and fails when an image failed", func() {
+				mockClient.EXPECT().
+					BulkRebase(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ client.BulkRebaseOptions) ([]client.RebaseResult, error) {
+						logger.Infof("Rebasing 'test/app-a' on run image 'test/run'")
+						return []client.RebaseResult{
+							{Image: "test/app-a", Status: client.RebaseStatusRebased, RunImage: "test/run"},
+							{Image: "test/app-b", Status: client.RebaseStatusFailed, Error: "some error"},
+						}, nil
+					})
+
+				command.SetArgs([]string{"--from-file", planPath, "--output", "json"})
+				h.AssertError(t, command.Execute(), "failed to rebase 1 of 2 images")
+				h.AssertNotContains(t, outBuf.String(), "Rebasing 'test/app-a'")
+				h.AssertEq(t, strings.HasPrefix(outBuf.String(), "[\n"), true)
+				h.AssertContains(t, outBuf.String(), `[
+  {
+    "image": "test/app-a",
+    "status": "rebased",
+    "runImage": "test/run"
+  },
+  {
+    "image": "test/app-b",
+    "status": "failed",
+    "error": "some error"
+  }
+]`)
+			})
+
+			it("does not accept an image name", func() {
+				command.SetArgs([]string{"test/app", "--from-file", planPath})
+				h.AssertError(t, command.Execute(), "unknown command \"test/app\" for \"rebase\"")
+			})
+
+			it("does not accept --previous-image", func() {
+				command.SetArgs([]string{"--from-file", planPath, "--previous-image", "test/app:previous"})
+				h.AssertError(t, command.Execute(), "--previous-image cannot be used with --from-file")
+			})
+
+			it("errors when the plan lists no images", func() {
+				h.AssertNil(t, os.WriteFile(planPath, []byte("# nothing yet\n"), 0600))
+
+				command.SetArgs([]string{"--from-file", planPath})
+				h.AssertError(t, command.Execute(), "does not list any images")
+			})
+		})
 	})
 }
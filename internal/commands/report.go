@@ -15,6 +15,7 @@ import (
 
 	"github.com/buildpacks/pack/internal/build"
 	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/config"
 	"github.com/buildpacks/pack/pkg/logging"
 )
 
@@ -54,7 +55,10 @@ Default Lifecycle Version:  {{ .DefaultLifecycleVersion }}
 Supported Platform APIs:  {{ .SupportedPlatformAPIs }}
 
 Config:
-{{ .Config -}}`))
+{{ .Config }}
+
+Environment:
+{{ .Environment -}}`))
 
 	configData := ""
 	if data, err := os.ReadFile(filepath.Clean(cfgPath)); err != nil {
@@ -71,6 +75,21 @@ Config:
 		configData = strings.TrimRight(padded.String(), " \n")
 	}
 
+	var envData strings.Builder
+	for _, envVar := range config.EnvVars {
+		value, ok := os.LookupEnv(envVar.Name)
+		if !ok {
+			continue
+		}
+		if envVar.Sensitive && !explicit && value != "" {
+			value = "[REDACTED]"
+		}
+		_, _ = fmt.Fprintf(&envData, "  %s=%s\n", envVar.Name, value)
+	}
+	if envData.Len() == 0 {
+		envData.WriteString("(no config environment variables set)")
+	}
+
 	platformAPIs := strings.Join(build.SupportedPlatformAPIVersions.AsStrings(), ", ")
 
 	return tpl.Execute(writer, map[string]string{
@@ -80,6 +99,7 @@ Config:
 		"DefaultLifecycleVersion": builder.DefaultLifecycleVersion,
 		"SupportedPlatformAPIs":   platformAPIs,
 		"Config":                  configData,
+		"Environment":             strings.TrimRight(envData.String(), "\n"),
 	})
 }
 
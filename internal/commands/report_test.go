@@ -109,6 +109,29 @@ experimental = true
 			})
 		})
 
+		when("config environment variables are set", func() {
+			it.Before(func() {
+				h.AssertNil(t, os.Setenv("PACK_PULL_POLICY", "never"))
+				h.AssertNil(t, os.Setenv("PACK_DEFAULT_BUILDER", "super-secret-project/builder"))
+			})
+
+			it.After(func() {
+				h.AssertNil(t, os.Unsetenv("PACK_PULL_POLICY"))
+				h.AssertNil(t, os.Unsetenv("PACK_DEFAULT_BUILDER"))
+			})
+
+			it("presents them, redacting sensitive values", func() {
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "Environment:\n  PACK_DEFAULT_BUILDER=[REDACTED]\n  PACK_PULL_POLICY=never")
+			})
+
+			it("doesn't redact them if explicit", func() {
+				command.SetArgs([]string{"-e"})
+				h.AssertNil(t, command.Execute())
+				h.AssertContains(t, outBuf.String(), "PACK_DEFAULT_BUILDER=super-secret-project/builder")
+			})
+		})
+
 		when("config.toml is not present", func() {
 			it("logs a message", func() {
 				command = commands.Report(logger, testVersion, filepath.Join(tempPackEmptyHome, "/config.toml"))
@@ -10,7 +10,9 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 
+	builder "github.com/buildpacks/pack/internal/builder"
 	client "github.com/buildpacks/pack/pkg/client"
+	dist "github.com/buildpacks/pack/pkg/dist"
 )
 
 // MockPackClient is a mock of PackClient interface.
@@ -78,6 +80,51 @@ func (mr *MockPackClientMockRecorder) Build(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockPackClient)(nil).Build), arg0, arg1)
 }
 
+// BuildpackVersions mocks base method.
+func (m *MockPackClient) BuildpackVersions(arg0 client.BuildpackVersionsOptions) ([]client.RegistryBuildpackVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildpackVersions", arg0)
+	ret0, _ := ret[0].([]client.RegistryBuildpackVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BuildpackVersions indicates an expected call of BuildpackVersions.
+func (mr *MockPackClientMockRecorder) BuildpackVersions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildpackVersions", reflect.TypeOf((*MockPackClient)(nil).BuildpackVersions), arg0)
+}
+
+// BulkRebase mocks base method.
+func (m *MockPackClient) BulkRebase(arg0 context.Context, arg1 client.BulkRebaseOptions) ([]client.RebaseResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkRebase", arg0, arg1)
+	ret0, _ := ret[0].([]client.RebaseResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkRebase indicates an expected call of BulkRebase.
+func (mr *MockPackClientMockRecorder) BulkRebase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkRebase", reflect.TypeOf((*MockPackClient)(nil).BulkRebase), arg0, arg1)
+}
+
+// CheckRebase mocks base method.
+func (m *MockPackClient) CheckRebase(arg0 context.Context, arg1 client.RebaseOptions) (client.RebaseCheck, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckRebase", arg0, arg1)
+	ret0, _ := ret[0].(client.RebaseCheck)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckRebase indicates an expected call of CheckRebase.
+func (mr *MockPackClientMockRecorder) CheckRebase(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckRebase", reflect.TypeOf((*MockPackClient)(nil).CheckRebase), arg0, arg1)
+}
+
 // CreateBuilder mocks base method.
 func (m *MockPackClient) CreateBuilder(arg0 context.Context, arg1 client.CreateBuilderOptions) error {
 	m.ctrl.T.Helper()
@@ -120,6 +167,21 @@ func (mr *MockPackClientMockRecorder) DeleteManifest(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteManifest", reflect.TypeOf((*MockPackClient)(nil).DeleteManifest), arg0)
 }
 
+// Detect mocks base method.
+func (m *MockPackClient) Detect(arg0 context.Context, arg1 client.DetectOptions) (*client.DetectResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detect", arg0, arg1)
+	ret0, _ := ret[0].(*client.DetectResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Detect indicates an expected call of Detect.
+func (mr *MockPackClientMockRecorder) Detect(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detect", reflect.TypeOf((*MockPackClient)(nil).Detect), arg0, arg1)
+}
+
 // DownloadSBOM mocks base method.
 func (m *MockPackClient) DownloadSBOM(arg0 string, arg1 client.DownloadSBOMOptions) error {
 	m.ctrl.T.Helper()
@@ -134,6 +196,36 @@ func (mr *MockPackClientMockRecorder) DownloadSBOM(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadSBOM", reflect.TypeOf((*MockPackClient)(nil).DownloadSBOM), arg0, arg1)
 }
 
+// ExplainBuilder mocks base method.
+func (m *MockPackClient) ExplainBuilder(arg0 context.Context, arg1 client.ExplainBuilderOptions) (*builder.DetectionSimulation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainBuilder", arg0, arg1)
+	ret0, _ := ret[0].(*builder.DetectionSimulation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainBuilder indicates an expected call of ExplainBuilder.
+func (mr *MockPackClientMockRecorder) ExplainBuilder(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainBuilder", reflect.TypeOf((*MockPackClient)(nil).ExplainBuilder), arg0, arg1)
+}
+
+// ExtractBuildpack mocks base method.
+func (m *MockPackClient) ExtractBuildpack(arg0 context.Context, arg1 client.ExtractBuildpackOptions) ([]dist.ModuleInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtractBuildpack", arg0, arg1)
+	ret0, _ := ret[0].([]dist.ModuleInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExtractBuildpack indicates an expected call of ExtractBuildpack.
+func (mr *MockPackClientMockRecorder) ExtractBuildpack(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtractBuildpack", reflect.TypeOf((*MockPackClient)(nil).ExtractBuildpack), arg0, arg1)
+}
+
 // InspectBuilder mocks base method.
 func (m *MockPackClient) InspectBuilder(arg0 string, arg1 bool, arg2 ...client.BuilderInspectionModifier) (*client.BuilderInfo, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +246,21 @@ func (mr *MockPackClientMockRecorder) InspectBuilder(arg0, arg1 interface{}, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectBuilder", reflect.TypeOf((*MockPackClient)(nil).InspectBuilder), varargs...)
 }
 
+// InspectBuilderLayers mocks base method.
+func (m *MockPackClient) InspectBuilderLayers(arg0 string, arg1 client.InspectBuilderLayersOptions) (*client.BuilderLayersInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InspectBuilderLayers", arg0, arg1)
+	ret0, _ := ret[0].(*client.BuilderLayersInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InspectBuilderLayers indicates an expected call of InspectBuilderLayers.
+func (mr *MockPackClientMockRecorder) InspectBuilderLayers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InspectBuilderLayers", reflect.TypeOf((*MockPackClient)(nil).InspectBuilderLayers), arg0, arg1)
+}
+
 // InspectBuildpack mocks base method.
 func (m *MockPackClient) InspectBuildpack(arg0 client.InspectBuildpackOptions) (*client.BuildpackInfo, error) {
 	m.ctrl.T.Helper()
@@ -227,6 +334,20 @@ func (mr *MockPackClientMockRecorder) NewBuildpack(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewBuildpack", reflect.TypeOf((*MockPackClient)(nil).NewBuildpack), arg0, arg1)
 }
 
+// NewExtension mocks base method.
+func (m *MockPackClient) NewExtension(arg0 context.Context, arg1 client.NewExtensionOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewExtension", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NewExtension indicates an expected call of NewExtension.
+func (mr *MockPackClientMockRecorder) NewExtension(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewExtension", reflect.TypeOf((*MockPackClient)(nil).NewExtension), arg0, arg1)
+}
+
 // PackageBuildpack mocks base method.
 func (m *MockPackClient) PackageBuildpack(arg0 context.Context, arg1 client.PackageBuildpackOptions) error {
 	m.ctrl.T.Helper()
@@ -325,6 +446,66 @@ func (mr *MockPackClientMockRecorder) RemoveManifest(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveManifest", reflect.TypeOf((*MockPackClient)(nil).RemoveManifest), arg0, arg1)
 }
 
+// SearchBuildpacks mocks base method.
+func (m *MockPackClient) SearchBuildpacks(arg0 client.SearchBuildpacksOptions) ([]client.RegistryBuildpackVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBuildpacks", arg0)
+	ret0, _ := ret[0].([]client.RegistryBuildpackVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBuildpacks indicates an expected call of SearchBuildpacks.
+func (mr *MockPackClientMockRecorder) SearchBuildpacks(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBuildpacks", reflect.TypeOf((*MockPackClient)(nil).SearchBuildpacks), arg0)
+}
+
+// TestBuildpack mocks base method.
+func (m *MockPackClient) TestBuildpack(arg0 context.Context, arg1 client.TestBuildpackOptions) (*client.BuildpackTestReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TestBuildpack", arg0, arg1)
+	ret0, _ := ret[0].(*client.BuildpackTestReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TestBuildpack indicates an expected call of TestBuildpack.
+func (mr *MockPackClientMockRecorder) TestBuildpack(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TestBuildpack", reflect.TypeOf((*MockPackClient)(nil).TestBuildpack), arg0, arg1)
+}
+
+// ValidateExtension mocks base method.
+func (m *MockPackClient) ValidateExtension(arg0 context.Context, arg1 client.ValidateExtensionOptions) (*client.ExtensionValidationReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateExtension", arg0, arg1)
+	ret0, _ := ret[0].(*client.ExtensionValidationReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateExtension indicates an expected call of ValidateExtension.
+func (mr *MockPackClientMockRecorder) ValidateExtension(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateExtension", reflect.TypeOf((*MockPackClient)(nil).ValidateExtension), arg0, arg1)
+}
+
+// VerifyRunImageMirrors mocks base method.
+func (m *MockPackClient) VerifyRunImageMirrors(arg0 context.Context, arg1 client.VerifyRunImageMirrorsOptions) ([]client.RunImageMirrorsReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyRunImageMirrors", arg0, arg1)
+	ret0, _ := ret[0].([]client.RunImageMirrorsReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyRunImageMirrors indicates an expected call of VerifyRunImageMirrors.
+func (mr *MockPackClientMockRecorder) VerifyRunImageMirrors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyRunImageMirrors", reflect.TypeOf((*MockPackClient)(nil).VerifyRunImageMirrors), arg0, arg1)
+}
+
 // YankBuildpack mocks base method.
 func (m *MockPackClient) YankBuildpack(arg0 client.YankBuildpackOptions) error {
 	m.ctrl.T.Helper()
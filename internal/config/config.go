@@ -12,17 +12,18 @@ import (
 
 type Config struct {
 	// Deprecated: Use DefaultRegistryName instead. See https://github.com/buildpacks/pack/issues/747.
-	DefaultRegistry     string            `toml:"default-registry-url,omitempty"`
-	DefaultRegistryName string            `toml:"default-registry,omitempty"`
-	DefaultBuilder      string            `toml:"default-builder-image,omitempty"`
-	PullPolicy          string            `toml:"pull-policy,omitempty"`
-	Experimental        bool              `toml:"experimental,omitempty"`
-	RunImages           []RunImage        `toml:"run-images"`
-	TrustedBuilders     []TrustedBuilder  `toml:"trusted-builders,omitempty"`
-	Registries          []Registry        `toml:"registries,omitempty"`
-	LifecycleImage      string            `toml:"lifecycle-image,omitempty"`
-	RegistryMirrors     map[string]string `toml:"registry-mirrors,omitempty"`
-	LayoutRepositoryDir string            `toml:"layout-repo-dir,omitempty"`
+	DefaultRegistry     string               `toml:"default-registry-url,omitempty"`
+	DefaultRegistryName string               `toml:"default-registry,omitempty"`
+	DefaultBuilder      string               `toml:"default-builder-image,omitempty"`
+	PullPolicy          string               `toml:"pull-policy,omitempty"`
+	Experimental        bool                 `toml:"experimental,omitempty"`
+	RunImages           []RunImage           `toml:"run-images"`
+	TrustedBuilders     []TrustedBuilder     `toml:"trusted-builders,omitempty"`
+	Registries          []Registry           `toml:"registries,omitempty"`
+	LifecycleImage      string               `toml:"lifecycle-image,omitempty"`
+	RegistryMirrors     map[string]string    `toml:"registry-mirrors,omitempty"`
+	RegistryMirrorRules []RegistryMirrorRule `toml:"registry-mirror-rules,omitempty"`
+	LayoutRepositoryDir string               `toml:"layout-repo-dir,omitempty"`
 }
 
 type Registry struct {
@@ -36,6 +37,12 @@ type RunImage struct {
 	Mirrors []string `toml:"mirrors"`
 }
 
+// RegistryMirrorRule rewrites images matching a registry and repository, or a repository prefix ending in `/*`, to mirrors tried in order.
+type RegistryMirrorRule struct {
+	Match   string   `toml:"match"`
+	Mirrors []string `toml:"mirrors"`
+}
+
 type TrustedBuilder struct {
 	Name string `toml:"name"`
 }
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// SourceEnv is the source of values set with environment variables.
+const SourceEnv Source = "environment"
+
+// EnvVar maps an environment variable onto a configuration setting. Variables are named after the `pack config`
+// subcommand managing the same setting.
+type EnvVar struct {
+	Name   string
+	Key    string
+	Format string
+
+	// Whether values may contain private information, and should be redacted in reports.
+	Sensitive bool
+
+	set func(cfg *Config, value string) error
+}
+
+// EnvVars lists the environment variables overriding configuration settings. They take precedence over the project
+// and user configs. Variables set to an empty value are ignored.
+var EnvVars = []EnvVar{
+	{
+		Name: "PACK_DEFAULT_BUILDER", Key: "default-builder-image", Format: "<builder>", Sensitive: true,
+		set: func(cfg *Config, value string) error {
+			cfg.DefaultBuilder = value
+			return nil
+		},
+	},
+	{
+		Name: "PACK_PULL_POLICY", Key: "pull-policy", Format: "always|never|if-not-present",
+		set: func(cfg *Config, value string) error {
+			cfg.PullPolicy = value
+			return nil
+		},
+	},
+	{
+		Name: "PACK_EXPERIMENTAL", Key: "experimental", Format: "true|false",
+		set: func(cfg *Config, value string) error {
+			experimental, err := strconv.ParseBool(value)
+			if err != nil {
+				return errors.Errorf("expected %s or %s", style.Symbol("true"), style.Symbol("false"))
+			}
+			cfg.Experimental = experimental
+			return nil
+		},
+	},
+	{
+		Name: "PACK_LIFECYCLE_IMAGE", Key: "lifecycle-image", Format: "<image>",
+		set: func(cfg *Config, value string) error {
+			cfg.LifecycleImage = value
+			return nil
+		},
+	},
+	{
+		Name: "PACK_DEFAULT_REGISTRY", Key: "default-registry", Format: "<registry name>",
+		set: func(cfg *Config, value string) error {
+			cfg.DefaultRegistryName = value
+			return nil
+		},
+	},
+	{
+		Name: "PACK_REGISTRIES", Key: "registries", Format: "<name>=<github url>,...", Sensitive: true,
+		set: func(cfg *Config, value string) error {
+			pairs, err := parseEnvPairs(value)
+			if err != nil {
+				return err
+			}
+			for _, pair := range pairs {
+				cfg.Registries = append(cfg.Registries, Registry{Name: pair[0], Type: "github", URL: pair[1]})
+			}
+			return nil
+		},
+	},
+	{
+		Name: "PACK_RUN_IMAGE_MIRRORS", Key: "run-images", Format: "<run image>=<mirror>,...", Sensitive: true,
+		set: func(cfg *Config, value string) error {
+			pairs, err := parseEnvPairs(value)
+			if err != nil {
+				return err
+			}
+			for _, pair := range pairs {
+				var mirrors []string
+				for _, runImage := range cfg.RunImages {
+					if runImage.Image == pair[0] {
+						mirrors = runImage.Mirrors
+					}
+				}
+				*cfg = SetRunImageMirrors(*cfg, pair[0], append(mirrors, pair[1]))
+			}
+			return nil
+		},
+	},
+	{
+		Name: "PACK_TRUSTED_BUILDERS", Key: "trusted-builders", Format: "<builder>,...", Sensitive: true,
+		set: func(cfg *Config, value string) error {
+			for _, builder := range splitEnvList(value) {
+				cfg.TrustedBuilders = append(cfg.TrustedBuilders, TrustedBuilder{Name: builder})
+			}
+			return nil
+		},
+	},
+	{
+		Name: "PACK_REGISTRY_MIRRORS", Key: "registry-mirrors", Format: "<registry>=<mirror>,...", Sensitive: true,
+		set: func(cfg *Config, value string) error {
+			pairs, err := parseEnvPairs(value)
+			if err != nil {
+				return err
+			}
+			cfg.RegistryMirrors = map[string]string{}
+			for _, pair := range pairs {
+				cfg.RegistryMirrors[pair[0]] = pair[1]
+			}
+			return nil
+		},
+	},
+	{
+		Name: "PACK_REGISTRY_MIRROR_RULES", Key: "registry-mirror-rules", Format: "<match>=<mirror>,...", Sensitive: true,
+		set: func(cfg *Config, value string) error {
+			pairs, err := parseEnvPairs(value)
+			if err != nil {
+				return err
+			}
+			for _, pair := range pairs {
+				idx := len(cfg.RegistryMirrorRules)
+				for i, rule := range cfg.RegistryMirrorRules {
+					if rule.Match == pair[0] {
+						idx = i
+					}
+				}
+				if idx == len(cfg.RegistryMirrorRules) {
+					cfg.RegistryMirrorRules = append(cfg.RegistryMirrorRules, RegistryMirrorRule{Match: pair[0]})
+				}
+				cfg.RegistryMirrorRules[idx].Mirrors = append(cfg.RegistryMirrorRules[idx].Mirrors, pair[1])
+			}
+			return nil
+		},
+	},
+	{
+		Name: "PACK_LAYOUT_REPO_DIR", Key: "layout-repo-dir", Format: "<dir>",
+		set: func(cfg *Config, value string) error {
+			cfg.LayoutRepositoryDir = value
+			return nil
+		},
+	},
+}
+
+// ReadEnv returns a layer for every environment variable in EnvVars that lookupEnv finds set to a non-empty value,
+// in the order of EnvVars.
+func ReadEnv(lookupEnv func(string) (string, bool)) ([]Layer, error) {
+	var layers []Layer
+	for _, envVar := range EnvVars {
+		value, ok := lookupEnv(envVar.Name)
+		if !ok || strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		var cfg Config
+		if err := envVar.set(&cfg, strings.TrimSpace(value)); err != nil {
+			return nil, errors.Wrapf(err, "invalid value for %s", style.Symbol(envVar.Name))
+		}
+		layers = append(layers, Layer{
+			Origin:   Origin{Source: SourceEnv, Path: envVar.Name},
+			Config:   cfg,
+			Explicit: []string{envVar.Key},
+		})
+	}
+	return layers, nil
+}
+
+func splitEnvList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseEnvPairs parses a comma separated list of <key>=<value> pairs, keeping their order.
+func parseEnvPairs(value string) ([][2]string, error) {
+	var pairs [][2]string
+	for _, item := range splitEnvList(value) {
+		key, val, ok := strings.Cut(item, "=")
+		key, val = strings.TrimSpace(key), strings.TrimSpace(val)
+		if !ok || key == "" || val == "" {
+			return nil, errors.Errorf("expected %s, got %s", style.Symbol("<key>=<value>"), style.Symbol(item))
+		}
+		pairs = append(pairs, [2]string{key, val})
+	}
+	return pairs, nil
+}
@@ -0,0 +1,79 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/config"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestEnv(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "env", testEnv, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testEnv(t *testing.T, when spec.G, it spec.S) {
+	var lookupEnv = func(env map[string]string) func(string) (string, bool) {
+		return func(name string) (string, bool) {
+			value, ok := env[name]
+			return value, ok
+		}
+	}
+
+	when("#ReadEnv", func() {
+		it("maps environment variables onto config settings", func() {
+			layers, err := config.ReadEnv(lookupEnv(map[string]string{
+				"PACK_DEFAULT_BUILDER":       "env/builder",
+				"PACK_EXPERIMENTAL":          "false",
+				"PACK_PULL_POLICY":           "",
+				"PACK_REGISTRY_MIRRORS":      "index.docker.io=10.0.0.1, *=10.0.0.2",
+				"PACK_RUN_IMAGE_MIRRORS":     "some/run=mirror/run-1,some/run=mirror/run-2",
+				"PACK_TRUSTED_BUILDERS":      "some/builder,other/builder",
+				"PACK_REGISTRY_MIRROR_RULES": "docker.io/paketobuildpacks/*=artifactory.corp/pb-remote/*,docker.io/paketobuildpacks/*=docker.io/paketobuildpacks/*",
+			}))
+			h.AssertNil(t, err)
+
+			layered := config.Merge(append([]config.Layer{{
+				Origin: config.Origin{Source: config.SourceUser},
+				Config: config.Config{DefaultBuilder: "user/builder", PullPolicy: "always", Experimental: true},
+			}}, layers...)...)
+
+			h.AssertEq(t, layered.Config, config.Config{
+				DefaultBuilder:  "env/builder",
+				PullPolicy:      "always",
+				RunImages:       []config.RunImage{{Image: "some/run", Mirrors: []string{"mirror/run-1", "mirror/run-2"}}},
+				TrustedBuilders: []config.TrustedBuilder{{Name: "some/builder"}, {Name: "other/builder"}},
+				RegistryMirrors: map[string]string{"index.docker.io": "10.0.0.1", "*": "10.0.0.2"},
+				RegistryMirrorRules: []config.RegistryMirrorRule{{
+					Match:   "docker.io/paketobuildpacks/*",
+					Mirrors: []string{"artifactory.corp/pb-remote/*", "docker.io/paketobuildpacks/*"},
+				}},
+			})
+			h.AssertEq(t, layered.Values[0], config.Value{
+				Key:    "default-builder-image",
+				Value:  "env/builder",
+				Origin: config.Origin{Source: config.SourceEnv, Path: "PACK_DEFAULT_BUILDER"},
+			})
+			h.AssertEq(t, layered.Values[2], config.Value{
+				Key:    "experimental",
+				Value:  "false",
+				Origin: config.Origin{Source: config.SourceEnv, Path: "PACK_EXPERIMENTAL"},
+			})
+		})
+
+		it("errors on invalid booleans", func() {
+			_, err := config.ReadEnv(lookupEnv(map[string]string{"PACK_EXPERIMENTAL": "maybe"}))
+			h.AssertError(t, err, "invalid value for 'PACK_EXPERIMENTAL': expected 'true' or 'false'")
+		})
+
+		it("errors on malformed pairs", func() {
+			_, err := config.ReadEnv(lookupEnv(map[string]string{"PACK_REGISTRY_MIRRORS": "index.docker.io"}))
+			h.AssertError(t, err, "invalid value for 'PACK_REGISTRY_MIRRORS': expected '<key>=<value>', got 'index.docker.io'")
+		})
+	})
+}
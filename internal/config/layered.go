@@ -0,0 +1,294 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/stringset"
+)
+
+// ProjectConfigPath is where a project's pack configuration is stored, relative to the project.
+var ProjectConfigPath = filepath.Join(".pack", "config.toml")
+
+// Source identifies where a configuration value came from.
+type Source string
+
+const (
+	SourceUser    Source = "user config"
+	SourceProject Source = "project config"
+)
+
+// Origin is the source a configuration value came from, along with the path of files or the name of environment variables.
+type Origin struct {
+	Source Source
+	Path   string
+}
+
+func (o Origin) String() string {
+	if o.Path == "" {
+		return string(o.Source)
+	}
+	return fmt.Sprintf("%s %s", o.Source, o.Path)
+}
+
+// Layer is a configuration to merge, along with its origin.
+type Layer struct {
+	Origin Origin
+	Config Config
+
+	// Keys of settings to override even when they are empty or false in Config.
+	Explicit []string
+}
+
+// Value is a single effective configuration value, formatted for display.
+type Value struct {
+	Key    string
+	Value  string
+	Origin Origin
+}
+
+// Layered is the effective configuration obtained by merging layers, and the origin of each of its values.
+type Layered struct {
+	Config Config
+	Values []Value
+}
+
+// projectConfig holds the settings a project may set in its pack configuration. Builders can't be trusted by a
+// project, only by the user.
+type projectConfig struct {
+	DefaultBuilder      string               `toml:"default-builder-image"`
+	PullPolicy          string               `toml:"pull-policy"`
+	LifecycleImage      string               `toml:"lifecycle-image"`
+	RunImages           []RunImage           `toml:"run-images"`
+	RegistryMirrors     map[string]string    `toml:"registry-mirrors"`
+	RegistryMirrorRules []RegistryMirrorRule `toml:"registry-mirror-rules"`
+}
+
+// FindProjectConfig looks for a project configuration in dir and its parents, stopping at the root of a git repository.
+// It returns an empty path when there is none.
+func FindProjectConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		path := filepath.Join(dir, ProjectConfigPath)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// ReadProject reads the project configuration at path. Settings that a project may not set are returned
+// as undecoded keys instead of being read.
+func ReadProject(path string) (Config, []toml.Key, error) {
+	var project projectConfig
+	md, err := toml.DecodeFile(path, &project)
+	if err != nil {
+		return Config{}, nil, errors.Wrapf(err, "failed to read project config file at path %s", path)
+	}
+
+	return Config{
+		DefaultBuilder:      project.DefaultBuilder,
+		PullPolicy:          project.PullPolicy,
+		LifecycleImage:      project.LifecycleImage,
+		RunImages:           project.RunImages,
+		RegistryMirrors:     project.RegistryMirrors,
+		RegistryMirrorRules: project.RegistryMirrorRules,
+	}, md.Undecoded(), nil
+}
+
+// ImageSources returns the keys of the settings of cfg that change where images are pulled from.
+func ImageSources(cfg Config) []string {
+	var keys []string
+	if cfg.LifecycleImage != "" {
+		keys = append(keys, "lifecycle-image")
+	}
+	for _, runImage := range cfg.RunImages {
+		keys = append(keys, entryKey("run-images", runImage.Image))
+	}
+
+	var registries []string
+	for registry := range cfg.RegistryMirrors {
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+	for _, registry := range registries {
+		keys = append(keys, entryKey("registry-mirrors", registry))
+	}
+
+	for _, rule := range cfg.RegistryMirrorRules {
+		keys = append(keys, entryKey("registry-mirror-rules", rule.Match))
+	}
+	return keys
+}
+
+// Merge merges layers, in increasing order of precedence. Settings are overridden by later layers, while lists and
+// maps are merged entry by entry, with the entries of later layers taking precedence.
+func Merge(layers ...Layer) Layered {
+	var (
+		cfg     Config
+		origins = map[string]Origin{}
+	)
+
+	for _, layer := range layers {
+		c := layer.Config
+		explicit := stringset.FromSlice(layer.Explicit)
+		setString := func(key string, dst *string, value string) {
+			if _, ok := explicit[key]; ok || value != "" {
+				*dst = value
+				origins[key] = layer.Origin
+			}
+		}
+
+		setString("default-registry-url", &cfg.DefaultRegistry, c.DefaultRegistry)
+		setString("default-registry", &cfg.DefaultRegistryName, c.DefaultRegistryName)
+		setString("default-builder-image", &cfg.DefaultBuilder, c.DefaultBuilder)
+		setString("pull-policy", &cfg.PullPolicy, c.PullPolicy)
+		setString("lifecycle-image", &cfg.LifecycleImage, c.LifecycleImage)
+		setString("layout-repo-dir", &cfg.LayoutRepositoryDir, c.LayoutRepositoryDir)
+		if _, ok := explicit["experimental"]; ok || c.Experimental {
+			cfg.Experimental = c.Experimental
+			origins["experimental"] = layer.Origin
+		}
+
+		for _, runImage := range c.RunImages {
+			cfg = SetRunImageMirrors(cfg, runImage.Image, runImage.Mirrors)
+			origins[entryKey("run-images", runImage.Image)] = layer.Origin
+		}
+
+		for _, builder := range c.TrustedBuilders {
+			if !containsTrustedBuilder(cfg.TrustedBuilders, builder.Name) {
+				cfg.TrustedBuilders = append(cfg.TrustedBuilders, builder)
+			}
+			origins[entryKey("trusted-builders", builder.Name)] = layer.Origin
+		}
+
+		for _, registry := range c.Registries {
+			cfg.Registries = setRegistry(cfg.Registries, registry)
+			origins[entryKey("registries", registry.Name)] = layer.Origin
+		}
+
+		for registry, mirror := range c.RegistryMirrors {
+			if cfg.RegistryMirrors == nil {
+				cfg.RegistryMirrors = map[string]string{}
+			}
+			cfg.RegistryMirrors[registry] = mirror
+			origins[entryKey("registry-mirrors", registry)] = layer.Origin
+		}
+
+		if len(c.RegistryMirrorRules) > 0 {
+			rules := append([]RegistryMirrorRule{}, c.RegistryMirrorRules...)
+			for _, rule := range cfg.RegistryMirrorRules {
+				if !containsMirrorRule(c.RegistryMirrorRules, rule.Match) {
+					rules = append(rules, rule)
+				}
+			}
+			cfg.RegistryMirrorRules = rules
+			for _, rule := range c.RegistryMirrorRules {
+				origins[entryKey("registry-mirror-rules", rule.Match)] = layer.Origin
+			}
+		}
+	}
+
+	return Layered{Config: cfg, Values: values(cfg, origins)}
+}
+
+// values lists the effective values of cfg in the order of the Config fields.
+func values(cfg Config, origins map[string]Origin) []Value {
+	var vals []Value
+	add := func(key, originKey, value string) {
+		vals = append(vals, Value{Key: key, Value: value, Origin: origins[originKey]})
+	}
+	addString := func(key, value string) {
+		if value != "" {
+			add(key, key, value)
+		}
+	}
+
+	addString("default-registry-url", cfg.DefaultRegistry)
+	addString("default-registry", cfg.DefaultRegistryName)
+	addString("default-builder-image", cfg.DefaultBuilder)
+	addString("pull-policy", cfg.PullPolicy)
+	if _, ok := origins["experimental"]; ok {
+		add("experimental", "experimental", strconv.FormatBool(cfg.Experimental))
+	}
+	for _, runImage := range cfg.RunImages {
+		key := entryKey("run-images", runImage.Image)
+		add(key, key, strings.Join(runImage.Mirrors, ", "))
+	}
+	for _, builder := range cfg.TrustedBuilders {
+		add("trusted-builders", entryKey("trusted-builders", builder.Name), builder.Name)
+	}
+	for _, registry := range cfg.Registries {
+		key := entryKey("registries", registry.Name)
+		add(key, key, fmt.Sprintf("%s %s", registry.Type, registry.URL))
+	}
+	addString("lifecycle-image", cfg.LifecycleImage)
+
+	var registries []string
+	for registry := range cfg.RegistryMirrors {
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+	for _, registry := range registries {
+		key := entryKey("registry-mirrors", registry)
+		add(key, key, cfg.RegistryMirrors[registry])
+	}
+
+	for _, rule := range cfg.RegistryMirrorRules {
+		key := entryKey("registry-mirror-rules", rule.Match)
+		add(key, key, strings.Join(rule.Mirrors, ", "))
+	}
+	addString("layout-repo-dir", cfg.LayoutRepositoryDir)
+	return vals
+}
+
+func entryKey(key, entry string) string {
+	return fmt.Sprintf("%s.%q", key, entry)
+}
+
+func containsTrustedBuilder(builders []TrustedBuilder, name string) bool {
+	for _, builder := range builders {
+		if builder.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMirrorRule(rules []RegistryMirrorRule, match string) bool {
+	for _, rule := range rules {
+		if rule.Match == match {
+			return true
+		}
+	}
+	return false
+}
+
+func setRegistry(registries []Registry, registry Registry) []Registry {
+	for i := range registries {
+		if registries[i].Name == registry.Name {
+			registries[i] = registry
+			return registries
+		}
+	}
+	return append(registries, registry)
+}
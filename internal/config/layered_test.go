@@ -0,0 +1,175 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/config"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestLayered(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "layered", testLayered, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testLayered(t *testing.T, when spec.G, it spec.S) {
+	var (
+		tmpDir  string
+		user    = config.Origin{Source: config.SourceUser, Path: "/home/user/.pack/config.toml"}
+		project = config.Origin{Source: config.SourceProject, Path: "/repo/.pack/config.toml"}
+	)
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "pack.layered.test.")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#Merge", func() {
+		it("overrides settings and merges entries with later layers", func() {
+			layered := config.Merge(
+				config.Layer{Origin: user, Config: config.Config{
+					DefaultBuilder:  "user/builder",
+					PullPolicy:      "always",
+					Experimental:    true,
+					TrustedBuilders: []config.TrustedBuilder{{Name: "user/trusted"}},
+					RegistryMirrors: map[string]string{"index.docker.io": "10.0.0.1", "gcr.io": "10.0.0.2"},
+					RegistryMirrorRules: []config.RegistryMirrorRule{
+						{Match: "gcr.io/*", Mirrors: []string{"user.corp/gcr/*"}},
+						{Match: "quay.io/*", Mirrors: []string{"user.corp/quay/*"}},
+					},
+				}},
+				config.Layer{Origin: project, Config: config.Config{
+					DefaultBuilder:      "project/builder",
+					TrustedBuilders:     []config.TrustedBuilder{{Name: "project/trusted"}},
+					RegistryMirrors:     map[string]string{"index.docker.io": "10.0.0.3"},
+					RegistryMirrorRules: []config.RegistryMirrorRule{{Match: "quay.io/*", Mirrors: []string{"project.corp/quay/*"}}},
+				}},
+			)
+
+			h.AssertEq(t, layered.Config, config.Config{
+				DefaultBuilder:  "project/builder",
+				PullPolicy:      "always",
+				Experimental:    true,
+				TrustedBuilders: []config.TrustedBuilder{{Name: "user/trusted"}, {Name: "project/trusted"}},
+				RegistryMirrors: map[string]string{"index.docker.io": "10.0.0.3", "gcr.io": "10.0.0.2"},
+				RegistryMirrorRules: []config.RegistryMirrorRule{
+					{Match: "quay.io/*", Mirrors: []string{"project.corp/quay/*"}},
+					{Match: "gcr.io/*", Mirrors: []string{"user.corp/gcr/*"}},
+				},
+			})
+
+			h.AssertEq(t, layered.Values, []config.Value{
+				{Key: "default-builder-image", Value: "project/builder", Origin: project},
+				{Key: "pull-policy", Value: "always", Origin: user},
+				{Key: "experimental", Value: "true", Origin: user},
+				{Key: "trusted-builders", Value: "user/trusted", Origin: user},
+				{Key: "trusted-builders", Value: "project/trusted", Origin: project},
+				{Key: `registry-mirrors."gcr.io"`, Value: "10.0.0.2", Origin: user},
+				{Key: `registry-mirrors."index.docker.io"`, Value: "10.0.0.3", Origin: project},
+				{Key: `registry-mirror-rules."quay.io/*"`, Value: "project.corp/quay/*", Origin: project},
+				{Key: `registry-mirror-rules."gcr.io/*"`, Value: "user.corp/gcr/*", Origin: user},
+			})
+		})
+
+		it("doesn't modify the layers", func() {
+			userCfg := config.Config{RunImages: []config.RunImage{{Image: "some/run", Mirrors: []string{"user/mirror"}}}}
+			layered := config.Merge(
+				config.Layer{Origin: user, Config: userCfg},
+				config.Layer{Origin: project, Config: config.Config{RunImages: []config.RunImage{{Image: "some/run", Mirrors: []string{"project/mirror"}}}}},
+			)
+
+			h.AssertEq(t, layered.Config.RunImages, []config.RunImage{{Image: "some/run", Mirrors: []string{"project/mirror"}}})
+			h.AssertEq(t, userCfg.RunImages, []config.RunImage{{Image: "some/run", Mirrors: []string{"user/mirror"}}})
+		})
+	})
+
+	when("#FindProjectConfig", func() {
+		it("finds the project config in a parent directory", func() {
+			projectConfig := filepath.Join(tmpDir, ".pack", "config.toml")
+			h.AssertNil(t, os.MkdirAll(filepath.Dir(projectConfig), 0750))
+			h.AssertNil(t, os.WriteFile(projectConfig, []byte{}, 0600))
+			subDir := filepath.Join(tmpDir, "app", "src")
+			h.AssertNil(t, os.MkdirAll(subDir, 0750))
+
+			found, err := config.FindProjectConfig(subDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, found, projectConfig)
+		})
+
+		it("stops at the root of a git repository", func() {
+			h.AssertNil(t, os.MkdirAll(filepath.Join(tmpDir, ".pack"), 0750))
+			h.AssertNil(t, os.WriteFile(filepath.Join(tmpDir, ".pack", "config.toml"), []byte{}, 0600))
+			repoDir := filepath.Join(tmpDir, "repo")
+			h.AssertNil(t, os.MkdirAll(filepath.Join(repoDir, ".git"), 0750))
+
+			found, err := config.FindProjectConfig(repoDir)
+			h.AssertNil(t, err)
+			h.AssertEq(t, found, "")
+		})
+	})
+
+	when("#ReadProject", func() {
+		it("reads the settings a project may set and returns the others as undecoded", func() {
+			path := filepath.Join(tmpDir, "config.toml")
+			h.AssertNil(t, os.WriteFile(path, []byte(`
+default-builder-image = "project/builder"
+pull-policy = "if-not-present"
+experimental = true
+
+[[trusted-builders]]
+name = "project/builder"
+
+[[registry-mirror-rules]]
+match = "docker.io/paketobuildpacks/*"
+mirrors = ["artifactory.corp/pb-remote/*"]
+`), 0600))
+
+			cfg, undecoded, err := config.ReadProject(path)
+			h.AssertNil(t, err)
+			h.AssertEq(t, cfg, config.Config{
+				DefaultBuilder: "project/builder",
+				PullPolicy:     "if-not-present",
+				RegistryMirrorRules: []config.RegistryMirrorRule{
+					{Match: "docker.io/paketobuildpacks/*", Mirrors: []string{"artifactory.corp/pb-remote/*"}},
+				},
+			})
+			h.AssertContains(t, config.FormatUndecodedKeys(undecoded), "'experimental'")
+			h.AssertContains(t, config.FormatUndecodedKeys(undecoded), "'trusted-builders'")
+		})
+	})
+
+	when("#ImageSources", func() {
+		it("lists the settings that change where images are pulled from", func() {
+			h.AssertEq(t, config.ImageSources(config.Config{
+				DefaultBuilder:      "project/builder",
+				PullPolicy:          "always",
+				LifecycleImage:      "project/lifecycle",
+				RunImages:           []config.RunImage{{Image: "some/run", Mirrors: []string{"project/run"}}},
+				RegistryMirrors:     map[string]string{"index.docker.io": "10.0.0.3", "gcr.io": "10.0.0.2"},
+				RegistryMirrorRules: []config.RegistryMirrorRule{{Match: "quay.io/*", Mirrors: []string{"project.corp/quay/*"}}},
+			}), []string{
+				"lifecycle-image",
+				`run-images."some/run"`,
+				`registry-mirrors."gcr.io"`,
+				`registry-mirrors."index.docker.io"`,
+				`registry-mirror-rules."quay.io/*"`,
+			})
+		})
+
+		it("is empty when no image source is set", func() {
+			h.AssertEq(t, len(config.ImageSources(config.Config{DefaultBuilder: "project/builder"})), 0)
+		})
+	})
+}
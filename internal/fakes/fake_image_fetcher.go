@@ -2,6 +2,7 @@ package fakes
 
 import (
 	"context"
+	"sync"
 
 	"github.com/buildpacks/imgutil"
 	"github.com/pkg/errors"
@@ -21,6 +22,8 @@ type FakeImageFetcher struct {
 	LocalImages  map[string]imgutil.Image
 	RemoteImages map[string]imgutil.Image
 	FetchCalls   map[string]*FetchArgs
+
+	mu sync.Mutex
 }
 
 func NewFakeImageFetcher() *FakeImageFetcher {
@@ -32,6 +35,9 @@ func NewFakeImageFetcher() *FakeImageFetcher {
 }
 
 func (f *FakeImageFetcher) Fetch(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	f.FetchCalls[name] = &FetchArgs{Daemon: options.Daemon, PullPolicy: options.PullPolicy, Target: options.Target, LayoutOption: options.LayoutOption}
 
 	ri, remoteFound := f.RemoteImages[name]
@@ -0,0 +1,149 @@
+package name
+
+import (
+	"strings"
+
+	gname "github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+const ruleWildcard = "/*"
+
+// MirrorRule redirects images matching Match to Mirrors, which are tried in order.
+//
+// Match is a registry followed by a repository, such as `docker.io/paketobuildpacks/builder`,
+// or by a repository prefix ending in `/*`, such as `docker.io/paketobuildpacks/*` or `gcr.io/*`.
+// When Match ends in `/*`, every mirror must end in `/*` too, and the `*` is replaced by the
+// rest of the repository, so `artifactory.corp/pb-remote/*` rewrites
+// `docker.io/paketobuildpacks/builder:base` to `artifactory.corp/pb-remote/builder:base`.
+// Otherwise every mirror is a repository. Tags and digests are kept.
+type MirrorRule struct {
+	Match   string
+	Mirrors []string
+}
+
+// ValidateMirrorRule returns an error when rule can't be used to rewrite image names.
+func ValidateMirrorRule(rule MirrorRule) error {
+	registry, repo, wildcard, err := parseMirrorPattern(rule.Match)
+	if err != nil {
+		return err
+	}
+	if !wildcard {
+		if _, err := gname.NewRepository(registry+"/"+repo, gname.WeakValidation); err != nil {
+			return errors.Wrapf(err, "invalid mirror rule %s", style.Symbol(rule.Match))
+		}
+	}
+
+	if len(rule.Mirrors) == 0 {
+		return errors.Errorf("mirror rule %s has no mirrors", style.Symbol(rule.Match))
+	}
+	for _, mirror := range rule.Mirrors {
+		mirrorRepo, mirrorWildcard := strings.CutSuffix(mirror, ruleWildcard)
+		switch {
+		case strings.Contains(mirrorRepo, "*"):
+			return errors.Errorf("invalid mirror %s: only a trailing %s wildcard is supported", style.Symbol(mirror), style.Symbol(ruleWildcard))
+		case wildcard && !mirrorWildcard:
+			return errors.Errorf("invalid mirror %s: mirrors must end in %s when %s does", style.Symbol(mirror), style.Symbol(ruleWildcard), style.Symbol(rule.Match))
+		case !wildcard && mirrorWildcard:
+			return errors.Errorf("invalid mirror %s: mirrors can't end in %s when %s doesn't", style.Symbol(mirror), style.Symbol(ruleWildcard), style.Symbol(rule.Match))
+		}
+		if _, err := gname.NewRegistry(strings.SplitN(mirrorRepo, "/", 2)[0], gname.WeakValidation); err != nil {
+			return errors.Wrapf(err, "invalid mirror %s", style.Symbol(mirror))
+		}
+	}
+	return nil
+}
+
+// MirrorCandidates returns the images to try for imageName, in order, according to the first rule in rules that matches it.
+// It returns nil when no rule matches.
+func MirrorCandidates(imageName string, rules []MirrorRule) ([]string, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	srcRef, err := gname.ParseReference(imageName, gname.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	separator := ":"
+	if _, ok := srcRef.(gname.Digest); ok {
+		separator = "@"
+	}
+
+	for _, rule := range rules {
+		rest, ok, err := matchMirrorRule(srcRef.Context(), rule.Match)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		var candidates []string
+		for _, mirror := range rule.Mirrors {
+			repo := mirror
+			if mirrorRepo, wildcard := strings.CutSuffix(mirror, ruleWildcard); wildcard {
+				repo = mirrorRepo + "/" + rest
+			}
+
+			candidate := repo + separator + srcRef.Identifier()
+			if _, err := gname.ParseReference(candidate, gname.WeakValidation); err != nil {
+				return nil, errors.Wrapf(err, "rewriting %s with mirror %s", style.Symbol(imageName), style.Symbol(mirror))
+			}
+			candidates = append(candidates, candidate)
+		}
+		return candidates, nil
+	}
+	return nil, nil
+}
+
+// matchMirrorRule reports whether repo matches pattern and, for wildcard patterns, the part of the repository matched by the wildcard.
+func matchMirrorRule(repo gname.Repository, pattern string) (string, bool, error) {
+	registry, prefix, wildcard, err := parseMirrorPattern(pattern)
+	if err != nil {
+		return "", false, err
+	}
+	if repo.RegistryStr() != registry {
+		return "", false, nil
+	}
+
+	if !wildcard {
+		ruleRepo, err := gname.NewRepository(registry+"/"+prefix, gname.WeakValidation)
+		if err != nil {
+			return "", false, err
+		}
+		return "", ruleRepo.RepositoryStr() == repo.RepositoryStr(), nil
+	}
+
+	if prefix == "" {
+		return repo.RepositoryStr(), true, nil
+	}
+	rest, ok := strings.CutPrefix(repo.RepositoryStr(), prefix+"/")
+	return rest, ok, nil
+}
+
+// parseMirrorPattern splits pattern into its normalized registry and its repository, or repository prefix for wildcard patterns.
+func parseMirrorPattern(pattern string) (registry string, repo string, wildcard bool, err error) {
+	repo, wildcard = strings.CutSuffix(pattern, ruleWildcard)
+	if strings.Contains(repo, "*") {
+		return "", "", false, errors.Errorf("invalid mirror rule %s: only a trailing %s wildcard is supported", style.Symbol(pattern), style.Symbol(ruleWildcard))
+	}
+
+	parts := strings.SplitN(repo, "/", 2)
+	if len(parts) == 1 && !wildcard {
+		return "", "", false, errors.Errorf("invalid mirror rule %s: expected a registry followed by a repository", style.Symbol(pattern))
+	}
+	reg, err := gname.NewRegistry(parts[0], gname.WeakValidation)
+	if err != nil {
+		return "", "", false, errors.Wrapf(err, "invalid mirror rule %s", style.Symbol(pattern))
+	}
+	if len(parts) == 2 {
+		repo = parts[1]
+	} else {
+		repo = ""
+	}
+	return reg.RegistryStr(), repo, wildcard, nil
+}
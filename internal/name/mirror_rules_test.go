@@ -0,0 +1,85 @@
+package name_test
+
+import (
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/name"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestMirrorRules(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "MirrorRules", testMirrorRules, spec.Report(report.Terminal{}))
+}
+
+func testMirrorRules(t *testing.T, when spec.G, it spec.S) {
+	var assert = h.NewAssertionManager(t)
+
+	when("#MirrorCandidates", func() {
+		var rules = []name.MirrorRule{
+			{Match: "docker.io/paketobuildpacks/builder", Mirrors: []string{"builders.corp/paketo-builder"}},
+			{Match: "docker.io/paketobuildpacks/*", Mirrors: []string{"artifactory.corp/pb-remote/*", "docker.io/paketobuildpacks/*"}},
+			{Match: "gcr.io/*", Mirrors: []string{"artifactory.corp/gcr/*"}},
+		}
+
+		it("rewrites the repository prefix matched by the first matching rule to each mirror", func() {
+			candidates, err := name.MirrorCandidates("paketobuildpacks/run-jammy-base:latest", rules)
+			assert.Nil(err)
+			assert.Equal(candidates, []string{
+				"artifactory.corp/pb-remote/run-jammy-base:latest",
+				"docker.io/paketobuildpacks/run-jammy-base:latest",
+			})
+		})
+
+		it("prefers earlier rules", func() {
+			candidates, err := name.MirrorCandidates("index.docker.io/paketobuildpacks/builder:base", rules)
+			assert.Nil(err)
+			assert.Equal(candidates, []string{"builders.corp/paketo-builder:base"})
+		})
+
+		it("keeps digests", func() {
+			digest := "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+			candidates, err := name.MirrorCandidates("gcr.io/buildpacks/builder@"+digest, rules)
+			assert.Nil(err)
+			assert.Equal(candidates, []string{"artifactory.corp/gcr/buildpacks/builder@" + digest})
+		})
+
+		it("doesn't match repositories that only share a prefix", func() {
+			candidates, err := name.MirrorCandidates("docker.io/paketobuildpacks-community/builder", rules)
+			assert.Nil(err)
+			assert.Equal(len(candidates), 0)
+		})
+
+		it("doesn't rewrite images on other registries", func() {
+			candidates, err := name.MirrorCandidates("quay.io/some/image", rules)
+			assert.Nil(err)
+			assert.Equal(len(candidates), 0)
+		})
+	})
+
+	when("#ValidateMirrorRule", func() {
+		it("accepts wildcard rules with wildcard mirrors", func() {
+			assert.Nil(name.ValidateMirrorRule(name.MirrorRule{Match: "docker.io/*", Mirrors: []string{"mirror.corp/hub/*"}}))
+		})
+
+		it("rejects wildcards that aren't trailing", func() {
+			err := name.ValidateMirrorRule(name.MirrorRule{Match: "docker.io/*/builder", Mirrors: []string{"mirror.corp/builder"}})
+			assert.ErrorContains(err, "only a trailing '/*' wildcard is supported")
+		})
+
+		it("rejects mirrors without a wildcard for wildcard rules", func() {
+			err := name.ValidateMirrorRule(name.MirrorRule{Match: "docker.io/paketobuildpacks/*", Mirrors: []string{"mirror.corp/pb"}})
+			assert.ErrorContains(err, "mirrors must end in '/*'")
+		})
+
+		it("rejects rules without a repository", func() {
+			err := name.ValidateMirrorRule(name.MirrorRule{Match: "docker.io", Mirrors: []string{"mirror.corp/pb"}})
+			assert.ErrorContains(err, "expected a registry followed by a repository")
+		})
+	})
+}
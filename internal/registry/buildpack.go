@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
 )
 
 // Buildpack contains information about a buildpack stored in a Registry
@@ -17,6 +18,19 @@ type Buildpack struct {
 	Version   string `json:"version"`
 	Yanked    bool   `json:"yanked"`
 	Address   string `json:"addr,omitempty"`
+
+	// Deprecated versions can still be used, with a warning suggesting the Replacement.
+	Deprecated  bool   `json:"deprecated,omitempty"`
+	Message     string `json:"message,omitempty"`
+	Replacement string `json:"replacement,omitempty"`
+}
+
+// Deprecation returns the deprecation notice of a deprecated buildpack, or nil
+func (b Buildpack) Deprecation() *dist.Deprecation {
+	if !b.Deprecated {
+		return nil
+	}
+	return &dist.Deprecation{Message: b.Message, Replacement: b.Replacement}
 }
 
 // Validate that a buildpack reference contains required information
@@ -7,6 +7,7 @@ import (
 	"github.com/sclevine/spec/report"
 
 	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/pkg/dist"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -41,6 +42,17 @@ func testRegistryBuildpack(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#Deprecation", func() {
+		it("is nil when the buildpack is not deprecated", func() {
+			h.AssertNil(t, registry.Buildpack{Message: "ignored"}.Deprecation())
+		})
+
+		it("returns the message and replacement of a deprecated buildpack", func() {
+			b := registry.Buildpack{Deprecated: true, Message: "No longer maintained", Replacement: "example/java"}
+			h.AssertEq(t, b.Deprecation(), &dist.Deprecation{Message: "No longer maintained", Replacement: "example/java"})
+		})
+	})
+
 	when("#ParseNamespaceName", func() {
 		it("should parse buildpack id into namespace and name", func() {
 			const id = "heroku/rust@1.2.3"
@@ -0,0 +1,180 @@
+package registry
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+// DirIndex is a registry index kept as index files in a directory, laid out like the official registry index. The
+// directory is either on the filesystem, where buildpacks can be registered and yanked, or served read-only over HTTP.
+type DirIndex struct {
+	logger  logging.Logger
+	root    string
+	baseURL *url.URL
+	client  *http.Client
+}
+
+// NewDirIndex creates an index for the directory at location, which is a filesystem path, a file:// URI or an
+// http(s) URL.
+func NewDirIndex(logger logging.Logger, location string) (*DirIndex, error) {
+	if location == "" {
+		return nil, errors.New("registry location must be provided")
+	}
+
+	index := &DirIndex{logger: logger, client: http.DefaultClient}
+	switch {
+	case strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://"):
+		baseURL, err := url.Parse(strings.TrimSuffix(location, "/") + "/")
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing registry url %s", location)
+		}
+		index.baseURL = baseURL
+	case paths.IsURI(location):
+		root, err := paths.URIToFilePath(location)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing registry uri %s", location)
+		}
+		index.root = root
+	default:
+		root, err := filepath.Abs(location)
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolving registry path %s", location)
+		}
+		index.root = root
+	}
+	return index, nil
+}
+
+// LocateBuildpack stored in registry
+func (d *DirIndex) LocateBuildpack(bp string) (Buildpack, error) {
+	ns, name, version, err := buildpack.ParseRegistryID(bp)
+	if err != nil {
+		return Buildpack{}, errors.Wrap(err, "parsing buildpacks registry id")
+	}
+
+	entry, err := d.readEntry(ns, name)
+	if err != nil {
+		return Buildpack{}, errors.Wrap(err, "reading entry")
+	}
+
+	return locateInEntry(entry, bp, version)
+}
+
+// LookupEntry returns the entry of the buildpack with the id <namespace>/<name>
+func (d *DirIndex) LookupEntry(id string) (Entry, error) {
+	ns, name, err := ParseNamespaceName(id)
+	if err != nil {
+		return Entry{}, err
+	}
+	return d.readEntry(ns, name)
+}
+
+// Entries returns the entries of every buildpack in the index. Indexes served over HTTP cannot be listed.
+func (d *DirIndex) Entries() ([]Entry, error) {
+	if d.baseURL != nil {
+		return nil, errors.Errorf("registry %s is served over HTTP and cannot be listed", style.Symbol(d.baseURL.String()))
+	}
+	return entriesInDir(d.root)
+}
+
+// Register adds a new version of a buildpack to the index
+func (d *DirIndex) Register(b Buildpack) error {
+	return d.updateEntry(b.Namespace, b.Name, func(entry *Entry) error {
+		return entry.add(b)
+	})
+}
+
+// Yank marks a version of a buildpack in the index as yanked, or as not yanked when b.Yanked is false
+func (d *DirIndex) Yank(b Buildpack) error {
+	return d.updateEntry(b.Namespace, b.Name, func(entry *Entry) error {
+		return entry.yank(b)
+	})
+}
+
+func (d *DirIndex) updateEntry(ns, name string, update func(entry *Entry) error) error {
+	if d.baseURL != nil {
+		return errors.Errorf("registry %s is served over HTTP and is read-only", style.Symbol(d.baseURL.String()))
+	}
+
+	index, err := IndexPath(d.root, ns, name)
+	if err != nil {
+		return err
+	}
+
+	entry, err := d.readEntry(ns, name)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "reading existing buildpack entries")
+	}
+	if err := update(&entry); err != nil {
+		return err
+	}
+
+	contents, err := entry.encode()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(index), 0755); err != nil {
+		return errors.Wrapf(err, "creating directory structure for: %s/%s", ns, name)
+	}
+	d.logger.Debugf("Writing registry index %s", style.Symbol(index))
+	if err := os.WriteFile(index, contents, 0644); err != nil {
+		return errors.Wrapf(err, "writing buildpack file: %s/%s", ns, name)
+	}
+	return nil
+}
+
+func (d *DirIndex) readEntry(ns, name string) (Entry, error) {
+	if d.baseURL == nil {
+		index, err := IndexPath(d.root, ns, name)
+		if err != nil {
+			return Entry{}, err
+		}
+
+		file, err := os.Open(filepath.Clean(index))
+		if err != nil {
+			return Entry{}, errors.Wrapf(err, "finding buildpack: %s/%s", ns, name)
+		}
+		defer file.Close()
+
+		return parseEntry(file, ns, name)
+	}
+
+	index, err := IndexPath("", ns, name)
+	if err != nil {
+		return Entry{}, err
+	}
+	indexURL := d.baseURL.ResolveReference(&url.URL{Path: filepath.ToSlash(index)})
+
+	d.logger.Debugf("Fetching registry index %s", style.Symbol(indexURL.String()))
+	resp, err := d.client.Get(indexURL.String())
+	if err != nil {
+		return Entry{}, errors.Wrapf(err, "fetching index for buildpack: %s/%s", ns, name)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return Entry{}, errors.Wrapf(os.ErrNotExist, "finding buildpack: %s/%s", ns, name)
+	case resp.StatusCode != http.StatusOK:
+		return Entry{}, fmt.Errorf("fetching index for buildpack: %s/%s: unexpected status %s", ns, name, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Entry{}, errors.Wrapf(err, "reading index for buildpack: %s/%s", ns, name)
+	}
+	return parseEntry(bytes.NewReader(body), ns, name)
+}
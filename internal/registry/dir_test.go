@@ -0,0 +1,180 @@
+package registry_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDirIndex(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "DirIndex", testDirIndex, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDirIndex(t *testing.T, when spec.G, it spec.S) {
+	var (
+		tmpDir string
+		outBuf bytes.Buffer
+		logger logging.Logger
+		fooV1  = registry.Buildpack{
+			Namespace: "example",
+			Name:      "foo",
+			Version:   "1.0.0",
+			Address:   "example.com/some/package@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566",
+		}
+		fooV2 = registry.Buildpack{
+			Namespace: "example",
+			Name:      "foo",
+			Version:   "2.0.0",
+			Address:   "example.com/some/package@sha256:74eb48882e835d8767f62940d453eb96ed2737de3a16573881dcea7dea769df7",
+		}
+	)
+
+	it.Before(func() {
+		logger = logging.NewLogWithWriters(&outBuf, &outBuf)
+
+		var err error
+		tmpDir, err = os.MkdirTemp("", "dir-registry")
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("on the filesystem", func() {
+		var index *registry.DirIndex
+
+		it.Before(func() {
+			var err error
+			index, err = registry.NewDirIndex(logger, tmpDir)
+			h.AssertNil(t, err)
+		})
+
+		it("registers and locates buildpacks", func() {
+			h.AssertNil(t, index.Register(fooV1))
+			h.AssertNil(t, index.Register(fooV2))
+
+			h.AssertPathExists(t, filepath.Join(tmpDir, "3", "fo", "example_foo"))
+
+			bp, err := index.LocateBuildpack("example/foo")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp, fooV2)
+
+			bp, err = index.LocateBuildpack("example/foo@1.0.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp, fooV1)
+		})
+
+		it("errors when registering an existing version", func() {
+			h.AssertNil(t, index.Register(fooV1))
+			h.AssertError(t, index.Register(fooV1), "version '1.0.0' of 'example/foo' already exists")
+		})
+
+		it("yanks and restores buildpacks", func() {
+			h.AssertNil(t, index.Register(fooV1))
+			h.AssertNil(t, index.Register(fooV2))
+
+			yanked := fooV2
+			yanked.Yanked = true
+			h.AssertNil(t, index.Yank(yanked))
+
+			bp, err := index.LocateBuildpack("example/foo@^1.0 || ^2.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.0.0")
+
+			yanked.Yanked = false
+			h.AssertNil(t, index.Yank(yanked))
+
+			bp, err = index.LocateBuildpack("example/foo@^1.0 || ^2.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "2.0.0")
+		})
+
+		it("errors when yanking a missing version", func() {
+			h.AssertNil(t, index.Register(fooV1))
+
+			missing := fooV2
+			missing.Yanked = true
+			h.AssertError(t, index.Yank(missing), "version '2.0.0' of 'example/foo' was not found")
+		})
+
+		it("errors when locating a missing buildpack", func() {
+			_, err := index.LocateBuildpack("example/bar")
+			h.AssertError(t, err, "finding buildpack: example/bar")
+		})
+
+		it("lists and looks up entries", func() {
+			barV1 := fooV1
+			barV1.Name = "bar"
+			h.AssertNil(t, index.Register(fooV1))
+			h.AssertNil(t, index.Register(fooV2))
+			h.AssertNil(t, index.Register(barV1))
+			h.AssertNil(t, os.WriteFile(filepath.Join(tmpDir, "README.md"), []byte("not an index file"), 0644))
+
+			entries, err := index.Entries()
+			h.AssertNil(t, err)
+			h.AssertEq(t, entries, []registry.Entry{
+				{Buildpacks: []registry.Buildpack{barV1}},
+				{Buildpacks: []registry.Buildpack{fooV1, fooV2}},
+			})
+
+			entry, err := index.LookupEntry("example/foo")
+			h.AssertNil(t, err)
+			h.AssertEq(t, entry, registry.Entry{Buildpacks: []registry.Buildpack{fooV1, fooV2}})
+		})
+	})
+
+	when("served over HTTP", func() {
+		var (
+			index  *registry.DirIndex
+			server *httptest.Server
+		)
+
+		it.Before(func() {
+			fsIndex, err := registry.NewDirIndex(logger, tmpDir)
+			h.AssertNil(t, err)
+			h.AssertNil(t, fsIndex.Register(fooV1))
+
+			server = httptest.NewServer(http.FileServer(http.Dir(tmpDir)))
+			index, err = registry.NewDirIndex(logger, server.URL+"/")
+			h.AssertNil(t, err)
+		})
+
+		it.After(func() {
+			server.Close()
+		})
+
+		it("locates buildpacks", func() {
+			bp, err := index.LocateBuildpack("example/foo@1.0.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp, fooV1)
+		})
+
+		it("errors when locating a missing buildpack", func() {
+			_, err := index.LocateBuildpack("example/bar")
+			h.AssertError(t, err, "finding buildpack: example/bar")
+		})
+
+		it("cannot be listed", func() {
+			_, err := index.Entries()
+			h.AssertError(t, err, "is served over HTTP and cannot be listed")
+		})
+
+		it("is read-only", func() {
+			h.AssertError(t, index.Register(fooV2), "is served over HTTP and is read-only")
+		})
+	})
+}
@@ -0,0 +1,212 @@
+package registry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/logging"
+	registrytypes "github.com/buildpacks/pack/registry"
+)
+
+// Index is a buildpack registry index that buildpacks can be located in
+type Index interface {
+	LocateBuildpack(bp string) (Buildpack, error)
+
+	// LookupEntry returns the entry of the buildpack with the id <namespace>/<name>
+	LookupEntry(id string) (Entry, error)
+
+	// Entries returns the entries of every buildpack in the index
+	Entries() ([]Entry, error)
+}
+
+// WritableIndex is a buildpack registry index that buildpacks can be registered in and yanked from directly
+type WritableIndex interface {
+	Index
+	Register(b Buildpack) error
+	Yank(b Buildpack) error
+}
+
+// indexFileID returns the namespace and name of the buildpack whose index file is at relPath, relative to the index
+// root, and false when relPath is not an index file.
+func indexFileID(relPath string) (string, string, bool) {
+	parts := strings.SplitN(filepath.Base(relPath), "_", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	expected, err := IndexPath("", parts[0], parts[1])
+	if err != nil || filepath.ToSlash(expected) != filepath.ToSlash(relPath) {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// entriesInDir reads the entries of every index file under root
+func entriesInDir(root string) ([]Entry, error) {
+	var entries []Entry
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		relPath, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		ns, name, ok := indexFileID(relPath)
+		if !ok {
+			return nil
+		}
+
+		file, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return errors.Wrapf(err, "opening index for buildpack: %s/%s", ns, name)
+		}
+		defer file.Close()
+
+		entry, err := parseEntry(file, ns, name)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading registry index %s", style.Symbol(root))
+	}
+	return entries, nil
+}
+
+func parseEntry(r io.Reader, ns, name string) (Entry, error) {
+	entry := Entry{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var bp Buildpack
+		if err := json.Unmarshal(scanner.Bytes(), &bp); err != nil {
+			return Entry{}, errors.Wrapf(err, "parsing index for buildpack: %s/%s", ns, name)
+		}
+
+		entry.Buildpacks = append(entry.Buildpacks, bp)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return entry, errors.Wrapf(err, "reading index for buildpack: %s/%s", ns, name)
+	}
+
+	return entry, nil
+}
+
+// locateInEntry returns the buildpack of the entry with the requested version. An empty version locates the highest
+// version, and a version constraint the highest version satisfying it that is not yanked.
+func locateInEntry(entry Entry, bp, version string) (Buildpack, error) {
+	if len(entry.Buildpacks) == 0 {
+		return Buildpack{}, fmt.Errorf("no entries for buildpack: %s", bp)
+	}
+
+	if version == "" {
+		highestVersion := entry.Buildpacks[0]
+		if len(entry.Buildpacks) > 1 {
+			for _, bp := range entry.Buildpacks[1:] {
+				if buildpack.CompareVersions(bp.Version, highestVersion.Version) > 0 {
+					highestVersion = bp
+				}
+			}
+		}
+		return highestVersion, Validate(highestVersion)
+	}
+
+	if buildpack.IsVersionConstraint(version) {
+		var versions []string
+		for _, bpIndex := range entry.Buildpacks {
+			if !bpIndex.Yanked {
+				versions = append(versions, bpIndex.Version)
+			}
+		}
+		resolved, err := buildpack.ResolveVersion(version, versions)
+		if err != nil {
+			return Buildpack{}, errors.Wrapf(err, "resolving version for buildpack: %s", bp)
+		}
+		version = resolved
+	}
+
+	for _, bpIndex := range entry.Buildpacks {
+		if bpIndex.Version == version {
+			return bpIndex, Validate(bpIndex)
+		}
+	}
+	return Buildpack{}, fmt.Errorf("could not find version for buildpack: %s", bp)
+}
+
+// add appends a new version of a buildpack to the entry
+func (e *Entry) add(b Buildpack) error {
+	for _, existing := range e.Buildpacks {
+		if existing.Version == b.Version {
+			return errors.Errorf("version %s of %s already exists, upgrade the version to add", style.Symbol(b.Version), style.Symbol(b.Namespace+"/"+b.Name))
+		}
+	}
+	e.Buildpacks = append(e.Buildpacks, b)
+	return nil
+}
+
+// yank marks a version of a buildpack in the entry as yanked, or as not yanked when b.Yanked is false
+func (e *Entry) yank(b Buildpack) error {
+	for i, existing := range e.Buildpacks {
+		if existing.Version == b.Version {
+			e.Buildpacks[i].Yanked = b.Yanked
+			return nil
+		}
+	}
+	return errors.Errorf("version %s of %s was not found", style.Symbol(b.Version), style.Symbol(b.Namespace+"/"+b.Name))
+}
+
+// encode writes the entry in the index file format, one buildpack per line
+func (e *Entry) encode() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	for _, b := range e.Buildpacks {
+		line, err := json.Marshal(b)
+		if err != nil {
+			return nil, errors.Wrapf(err, "converting buildpack to json: %s/%s", b.Namespace, b.Name)
+		}
+		buf.Write(line)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+// NewIndex creates the index of a registry of the given type. Git and GitHub registries are cloned into a cache under
+// home; dir and oci registries are read directly.
+func NewIndex(logger logging.Logger, home, registryType, registryURL string) (Index, error) {
+	switch registryType {
+	case registrytypes.TypeDir:
+		return NewDirIndex(logger, registryURL)
+	case registrytypes.TypeOCI:
+		return NewOCIIndex(logger, registryURL, authn.DefaultKeychain)
+	default:
+		cache, err := NewRegistryCache(logger, home, registryURL)
+		if err != nil {
+			return nil, err
+		}
+		return &cache, nil
+	}
+}
@@ -0,0 +1,335 @@
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/logging"
+)
+
+const (
+	// OCIIndexConfigMediaType is the config media type of a registry index stored as an OCI artifact
+	OCIIndexConfigMediaType types.MediaType = "application/vnd.buildpacks.registry.index.config.v1+json"
+
+	// OCIIndexLayerMediaType is the media type of the layer holding the index files of a registry index
+	OCIIndexLayerMediaType types.MediaType = "application/vnd.buildpacks.registry.index.v1.tar+gzip"
+)
+
+// maxIndexUpdateAttempts is how many times an update of an OCIIndex is retried when the artifact changed while it was
+// being updated
+const maxIndexUpdateAttempts = 3
+
+// OCIIndex is a registry index stored as an OCI artifact, whose single layer holds the index files laid out like the
+// official registry index. Registering and yanking buildpacks pushes a new version of the artifact.
+//
+// Updates check that the artifact is still at the digest they read before pushing and start over when it is not.
+// Registries have no conditional push though, so a write landing between that check and the push is still lost:
+// writers that can run concurrently must be serialized by the caller.
+type OCIIndex struct {
+	logger   logging.Logger
+	ref      name.Reference
+	keychain authn.Keychain
+}
+
+// NewOCIIndex creates an index for the OCI artifact at the image reference
+func NewOCIIndex(logger logging.Logger, reference string, keychain authn.Keychain) (*OCIIndex, error) {
+	ref, err := name.ParseReference(reference, name.WeakValidation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing registry reference %s", reference)
+	}
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+	return &OCIIndex{logger: logger, ref: ref, keychain: keychain}, nil
+}
+
+// LocateBuildpack stored in registry
+func (o *OCIIndex) LocateBuildpack(bp string) (Buildpack, error) {
+	ns, name, version, err := buildpack.ParseRegistryID(bp)
+	if err != nil {
+		return Buildpack{}, errors.Wrap(err, "parsing buildpacks registry id")
+	}
+
+	files, _, err := o.readFiles()
+	if err != nil {
+		return Buildpack{}, err
+	}
+
+	entry, err := readFileEntry(files, ns, name)
+	if err != nil {
+		return Buildpack{}, errors.Wrap(err, "reading entry")
+	}
+
+	return locateInEntry(entry, bp, version)
+}
+
+// LookupEntry returns the entry of the buildpack with the id <namespace>/<name>
+func (o *OCIIndex) LookupEntry(id string) (Entry, error) {
+	ns, name, err := ParseNamespaceName(id)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	files, _, err := o.readFiles()
+	if err != nil {
+		return Entry{}, err
+	}
+	return readFileEntry(files, ns, name)
+}
+
+// Entries returns the entries of every buildpack in the index
+func (o *OCIIndex) Entries() ([]Entry, error) {
+	files, _, err := o.readFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var filePaths []string
+	for filePath := range files {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	var entries []Entry
+	for _, filePath := range filePaths {
+		ns, name, ok := indexFileID(filePath)
+		if !ok {
+			continue
+		}
+		entry, err := parseEntry(bytes.NewReader(files[filePath]), ns, name)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Register adds a new version of a buildpack to the index
+func (o *OCIIndex) Register(b Buildpack) error {
+	return o.updateEntry(b.Namespace, b.Name, func(entry *Entry) error {
+		return entry.add(b)
+	})
+}
+
+// Yank marks a version of a buildpack in the index as yanked, or as not yanked when b.Yanked is false
+func (o *OCIIndex) Yank(b Buildpack) error {
+	return o.updateEntry(b.Namespace, b.Name, func(entry *Entry) error {
+		return entry.yank(b)
+	})
+}
+
+func (o *OCIIndex) updateEntry(ns, name string, update func(entry *Entry) error) error {
+	for attempt := 1; ; attempt++ {
+		err := o.tryUpdateEntry(ns, name, update)
+		if !errors.Is(err, errIndexChanged) {
+			return err
+		}
+		if attempt == maxIndexUpdateAttempts {
+			return errors.Wrapf(err, "updating registry index %s after %d attempts", style.Symbol(o.ref.Name()), attempt)
+		}
+		o.logger.Debugf("Registry index %s changed while updating it, retrying", style.Symbol(o.ref.Name()))
+	}
+}
+
+var errIndexChanged = errors.New("registry index was changed by another writer")
+
+// tryUpdateEntry applies update to the index and pushes it, unless the artifact is no longer at the digest it read
+func (o *OCIIndex) tryUpdateEntry(ns, name string, update func(entry *Entry) error) error {
+	files, digest, err := o.readFiles()
+	if err != nil {
+		return err
+	}
+
+	entry, err := readFileEntry(files, ns, name)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "reading existing buildpack entries")
+	}
+	if err := update(&entry); err != nil {
+		return err
+	}
+
+	index, err := indexFilePath(ns, name)
+	if err != nil {
+		return err
+	}
+	if files[index], err = entry.encode(); err != nil {
+		return err
+	}
+
+	return o.writeFiles(files, digest)
+}
+
+// currentDigest returns the digest of the artifact, or the zero hash when it doesn't exist
+func (o *OCIIndex) currentDigest() (v1.Hash, error) {
+	desc, err := remote.Head(o.ref, remote.WithAuthFromKeychain(o.keychain))
+	if err != nil {
+		if isNotFound(err) {
+			return v1.Hash{}, nil
+		}
+		return v1.Hash{}, errors.Wrapf(err, "fetching registry index %s", style.Symbol(o.ref.Name()))
+	}
+	return desc.Digest, nil
+}
+
+// readFiles returns the index files of the artifact by path, and the digest they were read at. A missing artifact is an
+// empty index with the zero digest.
+func (o *OCIIndex) readFiles() (map[string][]byte, v1.Hash, error) {
+	o.logger.Debugf("Fetching registry index %s", style.Symbol(o.ref.Name()))
+	img, err := remote.Image(o.ref, remote.WithAuthFromKeychain(o.keychain))
+	if err != nil {
+		if isNotFound(err) {
+			return map[string][]byte{}, v1.Hash{}, nil
+		}
+		return nil, v1.Hash{}, errors.Wrapf(err, "fetching registry index %s", style.Symbol(o.ref.Name()))
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, v1.Hash{}, errors.Wrapf(err, "reading registry index %s", style.Symbol(o.ref.Name()))
+	}
+	files, err := readIndexLayer(img)
+	if err != nil {
+		return nil, v1.Hash{}, errors.Wrapf(err, "reading registry index %s", style.Symbol(o.ref.Name()))
+	}
+	return files, digest, nil
+}
+
+func readIndexLayer(img v1.Image) (map[string][]byte, error) {
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, err
+	}
+	if len(layers) != 1 {
+		return nil, errors.Errorf("must have exactly one layer, found %d", len(layers))
+	}
+
+	rc, err := layers[0].Uncompressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get next tar entry")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", style.Symbol(header.Name))
+		}
+		files[path.Clean(header.Name)] = contents
+	}
+}
+
+// writeFiles pushes the index files as a new version of the artifact, unless it is no longer at the digest they were
+// read at
+func (o *OCIIndex) writeFiles(files map[string][]byte, readDigest v1.Hash) error {
+	var filePaths []string
+	for filePath := range files {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for _, filePath := range filePaths {
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     filePath,
+			Mode:     0644,
+			Size:     int64(len(files[filePath])),
+			ModTime:  archive.NormalizedDateTime,
+		}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[filePath]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	}, tarball.WithMediaType(OCIIndexLayerMediaType))
+	if err != nil {
+		return errors.Wrap(err, "creating registry index layer")
+	}
+
+	var img v1.Image = mutate.MediaType(empty.Image, types.OCIManifestSchema1)
+	img = mutate.ConfigMediaType(img, OCIIndexConfigMediaType)
+	if img, err = mutate.AppendLayers(img, layer); err != nil {
+		return errors.Wrap(err, "creating registry index")
+	}
+
+	current, err := o.currentDigest()
+	if err != nil {
+		return err
+	}
+	if current != readDigest {
+		return errIndexChanged
+	}
+
+	o.logger.Debugf("Pushing registry index %s", style.Symbol(o.ref.Name()))
+	if err := remote.Write(o.ref, img, remote.WithAuthFromKeychain(o.keychain)); err != nil {
+		return errors.Wrapf(err, "pushing registry index %s", style.Symbol(o.ref.Name()))
+	}
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var terr *transport.Error
+	return errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound
+}
+
+func indexFilePath(ns, name string) (string, error) {
+	index, err := IndexPath("", ns, name)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(index), nil
+}
+
+func readFileEntry(files map[string][]byte, ns, name string) (Entry, error) {
+	index, err := indexFilePath(ns, name)
+	if err != nil {
+		return Entry{}, err
+	}
+	contents, ok := files[index]
+	if !ok {
+		return Entry{}, errors.Wrapf(os.ErrNotExist, "finding buildpack: %s/%s", ns, name)
+	}
+	return parseEntry(bytes.NewReader(contents), ns, name)
+}
@@ -0,0 +1,163 @@
+package registry_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	ggcrregistry "github.com/google/go-containerregistry/pkg/registry"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestOCIIndex(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "OCIIndex", testOCIIndex, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testOCIIndex(t *testing.T, when spec.G, it spec.S) {
+	var (
+		server *httptest.Server
+		ref    string
+		index  *registry.OCIIndex
+		outBuf bytes.Buffer
+		// beforeHead, when set, runs before the registry answers a HEAD request for the index manifest
+		beforeHead func()
+		headMu     sync.Mutex
+		fooV1  = registry.Buildpack{
+			Namespace: "example",
+			Name:      "foo",
+			Version:   "1.0.0",
+			Address:   "example.com/some/package@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566",
+		}
+		javaV1 = registry.Buildpack{
+			Namespace: "example",
+			Name:      "java",
+			Version:   "1.0.0",
+			Address:   "example.com/some/package@sha256:74eb48882e835d8767f62940d453eb96ed2737de3a16573881dcea7dea769df7",
+		}
+	)
+
+	it.Before(func() {
+		handler := ggcrregistry.New(ggcrregistry.Logger(log.New(io.Discard, "", 0)))
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead && strings.Contains(r.URL.Path, "/manifests/") {
+				headMu.Lock()
+				hook := beforeHead
+				headMu.Unlock()
+				if hook != nil {
+					hook()
+				}
+			}
+			handler.ServeHTTP(w, r)
+		}))
+
+		var err error
+		ref = strings.TrimPrefix(server.URL, "http://") + "/buildpacks/registry-index:latest"
+		index, err = registry.NewOCIIndex(logging.NewLogWithWriters(&outBuf, &outBuf), ref, authn.DefaultKeychain)
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		server.Close()
+	})
+
+	it("treats a missing artifact as an empty index", func() {
+		_, err := index.LocateBuildpack("example/foo")
+		h.AssertError(t, err, "finding buildpack: example/foo")
+	})
+
+	it("registers, yanks and locates buildpacks", func() {
+		h.AssertNil(t, index.Register(fooV1))
+		h.AssertNil(t, index.Register(javaV1))
+
+		bp, err := index.LocateBuildpack("example/foo")
+		h.AssertNil(t, err)
+		h.AssertEq(t, bp, fooV1)
+
+		bp, err = index.LocateBuildpack("example/java@1.0.0")
+		h.AssertNil(t, err)
+		h.AssertEq(t, bp, javaV1)
+
+		yanked := fooV1
+		yanked.Yanked = true
+		h.AssertNil(t, index.Yank(yanked))
+
+		bp, err = index.LocateBuildpack("example/foo@1.0.0")
+		h.AssertNil(t, err)
+		h.AssertTrue(t, bp.Yanked)
+	})
+
+	it("errors when registering an existing version", func() {
+		h.AssertNil(t, index.Register(fooV1))
+		h.AssertError(t, index.Register(fooV1), "version '1.0.0' of 'example/foo' already exists")
+	})
+
+	when("the index changes while it is being updated", func() {
+		var (
+			other     *registry.OCIIndex
+			competing int
+		)
+
+		it.Before(func() {
+			var err error
+			other, err = registry.NewOCIIndex(logging.NewLogWithWriters(&outBuf, &outBuf), ref, authn.DefaultKeychain)
+			h.AssertNil(t, err)
+
+			// registers a new java version with the other index before the update checks the digest, the requests
+			// made by that registration don't trigger another one
+			var injecting atomic.Bool
+			headMu.Lock()
+			beforeHead = func() {
+				if competing == 0 || !injecting.CompareAndSwap(false, true) {
+					return
+				}
+				defer injecting.Store(false)
+				competing--
+				bp := javaV1
+				bp.Version = fmt.Sprintf("%d.0.0", competing+1)
+				h.AssertNil(t, other.Register(bp))
+			}
+			headMu.Unlock()
+		})
+
+		it("retries the update on top of the other change", func() {
+			competing = 1
+
+			h.AssertNil(t, index.Register(fooV1))
+
+			bp, err := index.LocateBuildpack("example/foo@1.0.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp, fooV1)
+
+			bp, err = index.LocateBuildpack("example/java@1.0.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp, javaV1)
+		})
+
+		it("errors when the index keeps changing", func() {
+			competing = 3
+
+			err := index.Register(fooV1)
+			h.AssertError(t, err, "after 3 attempts")
+			h.AssertError(t, err, "registry index was changed by another writer")
+
+			_, err = index.LocateBuildpack("example/foo")
+			h.AssertError(t, err, "finding buildpack: example/foo")
+		})
+	})
+}
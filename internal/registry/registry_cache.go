@@ -1,7 +1,6 @@
 package registry
 
 import (
-	"bufio"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
@@ -16,7 +15,6 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/pkg/errors"
-	"golang.org/x/mod/semver"
 
 	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/buildpack"
@@ -92,28 +90,28 @@ func (r *Cache) LocateBuildpack(bp string) (Buildpack, error) {
 		return Buildpack{}, errors.Wrap(err, "reading entry")
 	}
 
-	if len(entry.Buildpacks) > 0 {
-		if version == "" {
-			highestVersion := entry.Buildpacks[0]
-			if len(entry.Buildpacks) > 1 {
-				for _, bp := range entry.Buildpacks[1:] {
-					if semver.Compare(fmt.Sprintf("v%s", bp.Version), fmt.Sprintf("v%s", highestVersion.Version)) > 0 {
-						highestVersion = bp
-					}
-				}
-			}
-			return highestVersion, Validate(highestVersion)
-		}
+	return locateInEntry(entry, bp, version)
+}
 
-		for _, bpIndex := range entry.Buildpacks {
-			if bpIndex.Version == version {
-				return bpIndex, Validate(bpIndex)
-			}
-		}
-		return Buildpack{}, fmt.Errorf("could not find version for buildpack: %s", bp)
+// LookupEntry returns the entry of the buildpack with the id <namespace>/<name>
+func (r *Cache) LookupEntry(id string) (Entry, error) {
+	if err := r.Refresh(); err != nil {
+		return Entry{}, errors.Wrap(err, "refreshing cache")
 	}
 
-	return Buildpack{}, fmt.Errorf("no entries for buildpack: %s", bp)
+	ns, name, err := ParseNamespaceName(id)
+	if err != nil {
+		return Entry{}, err
+	}
+	return r.readEntry(ns, name)
+}
+
+// Entries returns the entries of every buildpack in the registry
+func (r *Cache) Entries() ([]Entry, error) {
+	if err := r.Refresh(); err != nil {
+		return nil, errors.Wrap(err, "refreshing cache")
+	}
+	return entriesInDir(r.Root)
 }
 
 // Refresh local Registry Cache
@@ -350,21 +348,5 @@ func (r *Cache) readEntry(ns, name string) (Entry, error) {
 	}
 	defer file.Close()
 
-	entry := Entry{}
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		var bp Buildpack
-		err = json.Unmarshal([]byte(scanner.Text()), &bp)
-		if err != nil {
-			return Entry{}, errors.Wrapf(err, "parsing index for buildpack: %s/%s", ns, name)
-		}
-
-		entry.Buildpacks = append(entry.Buildpacks, bp)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return entry, errors.Wrapf(err, "reading index for buildpack: %s/%s", ns, name)
-	}
-
-	return entry, nil
+	return parseEntry(file, ns, name)
 }
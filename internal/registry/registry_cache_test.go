@@ -129,6 +129,21 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 			h.AssertEq(t, bp.Version, "1.1.0")
 		})
 
+		it("locates the highest buildpack version satisfying a version constraint", func() {
+			bp, err := registryCache.LocateBuildpack("example/foo@^1.0")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.2.0")
+
+			bp, err = registryCache.LocateBuildpack("example/foo@~1.1")
+			h.AssertNil(t, err)
+			h.AssertEq(t, bp.Version, "1.1.0")
+		})
+
+		it("returns error if no buildpack version satisfies the version constraint", func() {
+			_, err := registryCache.LocateBuildpack("example/foo@^2.0")
+			h.AssertError(t, err, "no version satisfies '^2.0'")
+		})
+
 		it("returns error if can't parse buildpack id", func() {
 			_, err := registryCache.LocateBuildpack("quack")
 			h.AssertError(t, err, "parsing buildpacks registry id")
@@ -150,6 +165,20 @@ func testRegistryCache(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#Entries", func() {
+		it("lists the entries of every buildpack", func() {
+			registryCache, err := NewRegistryCache(logger, tmpDir, registryFixture)
+			h.AssertNil(t, err)
+
+			entries, err := registryCache.Entries()
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(entries), 2)
+			h.AssertEq(t, len(entries[0].Buildpacks), 3)
+			h.AssertEq(t, entries[0].Buildpacks[0].Name, "foo")
+			h.AssertEq(t, entries[1].Buildpacks[0].Name, "java")
+		})
+	})
+
 	when("#Refresh", func() {
 		var (
 			registryCache Cache
@@ -61,6 +61,29 @@ func (b blob) Open() (r io.ReadCloser, err error) {
 	return rc, nil
 }
 
+// OpenRaw returns an io.ReadCloser with the contents of a blob backed by a single file as they are stored, without
+// decompressing them. Blobs that are not backed by a file on the host are opened with Open.
+func OpenRaw(b Blob) (io.ReadCloser, error) {
+	var path string
+	switch fb := b.(type) {
+	case *blob:
+		path = fb.path
+	case blob:
+		path = fb.path
+	default:
+		return b.Open()
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "read blob at path '%s'", path)
+	}
+	if fi.IsDir() {
+		return nil, errors.Errorf("blob at path '%s' is a directory", path)
+	}
+	return os.Open(path)
+}
+
 func isGZip(file io.ReadSeeker) (bool, error) {
 	b := make([]byte, 3)
 	if _, err := file.Seek(0, 0); err != nil {
@@ -1,6 +1,7 @@
 package blob_test
 
 import (
+	"io"
 	"os"
 	"path/filepath"
 	"testing"
@@ -59,5 +60,29 @@ func testBlob(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("#OpenRaw", func() {
+			var blobDir = filepath.Join("testdata", "blob")
+
+			it("returns the file contents without decompressing them", func() {
+				blobPath := h.CreateTGZ(t, blobDir, ".", -1)
+				defer os.Remove(blobPath)
+
+				rc, err := blob.OpenRaw(blob.NewBlob(blobPath))
+				h.AssertNil(t, err)
+				defer rc.Close()
+
+				contents, err := io.ReadAll(rc)
+				h.AssertNil(t, err)
+				expected, err := os.ReadFile(blobPath)
+				h.AssertNil(t, err)
+				h.AssertEq(t, contents, expected)
+			})
+
+			it("errors for a directory", func() {
+				_, err := blob.OpenRaw(blob.NewBlob(blobDir))
+				h.AssertError(t, err, "is a directory")
+			})
+		})
 	})
 }
@@ -42,6 +42,12 @@ type RegistryResolver interface {
 	Resolve(registryName, bpURI string) (string, error)
 }
 
+// TagLister is implemented by image fetchers that can list the tags of an image repository, which is needed to
+// resolve image references whose tag is a version constraint.
+type TagLister interface {
+	ListTags(repository string) ([]string, error)
+}
+
 type buildpackDownloader struct {
 	logger           Logger
 	imageFetcher     ImageFetcher
@@ -80,6 +86,9 @@ type DownloadOptions struct {
 
 	// The OS/Architecture/Variant to download.
 	Target *dist.Target
+
+	// Pins the versions that version constraints resolve to. Optional.
+	VersionLock *VersionLock
 }
 
 func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, opts DownloadOptions) (BuildModule, []BuildModule, error) {
@@ -105,6 +114,11 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 	switch locatorType {
 	case PackageLocator:
 		imageName := ParsePackageLocator(moduleURI)
+		if repository, constraint := ParseImageConstraint(imageName); constraint != "" {
+			if imageName, err = c.resolveImageTag(repository, constraint, opts.VersionLock); err != nil {
+				return nil, nil, err
+			}
+		}
 		c.logger.Debugf("Downloading %s from image: %s", kind, style.Symbol(imageName))
 		mainBP, depBPs, err = extractPackaged(ctx, kind, imageName, c.imageFetcher, image.FetchOptions{
 			Daemon:     opts.Daemon,
@@ -116,7 +130,11 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 		}
 	case RegistryLocator:
 		c.logger.Debugf("Downloading %s from registry: %s", kind, style.Symbol(moduleURI))
-		address, err := c.registryResolver.Resolve(opts.RegistryName, moduleURI)
+		id, constraint := ParseIDLocator(moduleURI)
+		if !IsVersionConstraint(constraint) {
+			constraint = ""
+		}
+		address, err := c.resolveRegistryAddress(opts.RegistryName, moduleURI, id, constraint, opts.VersionLock)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "locating in registry: %s", style.Symbol(moduleURI))
 		}
@@ -129,6 +147,11 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "extracting from registry %s", style.Symbol(moduleURI))
 		}
+		if constraint != "" {
+			version := mainBP.Descriptor().Info().Version
+			opts.VersionLock.Pin(id, constraint, version)
+			c.logger.Infof("Resolved %s to version %s", style.Symbol(id+"@"+constraint), style.Symbol(version))
+		}
 	case URILocator:
 		moduleURI, err = paths.FilePathToURI(moduleURI, opts.RelativeBaseDir)
 		if err != nil {
@@ -156,6 +179,37 @@ func (c *buildpackDownloader) Download(ctx context.Context, moduleURI string, op
 	return mainBP, depBPs, nil
 }
 
+// resolveRegistryAddress locates a registry buildpack, preferring the version pinned for its version constraint while
+// the registry still has it.
+func (c *buildpackDownloader) resolveRegistryAddress(registryName, moduleURI, id, constraint string, lock *VersionLock) (string, error) {
+	if pinned, ok := lock.Pinned(id, constraint); ok {
+		address, err := c.registryResolver.Resolve(registryName, id+"@"+pinned)
+		if err == nil {
+			return address, nil
+		}
+		c.logger.Debugf("Pinned version %s of %s is unavailable: %s", style.Symbol(pinned), style.Symbol(id), err)
+	}
+	return c.registryResolver.Resolve(registryName, moduleURI)
+}
+
+// resolveImageTag returns the image name of the highest tag of the repository satisfying the version constraint.
+func (c *buildpackDownloader) resolveImageTag(repository, constraint string, lock *VersionLock) (string, error) {
+	lister, ok := c.imageFetcher.(TagLister)
+	if !ok {
+		return "", errors.Errorf("resolving version constraint %s of %s: listing tags is not supported", style.Symbol(constraint), style.Symbol(repository))
+	}
+	tags, err := lister.ListTags(repository)
+	if err != nil {
+		return "", errors.Wrapf(err, "listing tags of %s", style.Symbol(repository))
+	}
+	tag, err := lock.Resolve(repository, constraint, tags)
+	if err != nil {
+		return "", err
+	}
+	c.logger.Infof("Resolved %s to version %s", style.Symbol(repository+":"+constraint), style.Symbol(tag))
+	return repository + ":" + tag, nil
+}
+
 // decomposeBlob decomposes a buildpack or extension blob into the main module (order buildpack or extension) and
 // (for buildpack blobs) its dependent buildpacks.
 func decomposeBlob(blob blob.Blob, kind string, imageOS string, logger Logger) (mainModule BuildModule, depModules []BuildModule, err error) {
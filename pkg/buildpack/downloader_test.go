@@ -173,6 +173,52 @@ func testBuildpackDownloader(t *testing.T, when spec.G, it spec.S) {
 					h.AssertEq(t, mainBP.Descriptor().Info().ID, "example/foo")
 				})
 			})
+			when("version constraint provided", func() {
+				it.Before(func() {
+					mockRegistryResolver.EXPECT().
+						Resolve("some-registry", "example/foo@^1.0").
+						Return("example.com/some/package@sha256:74eb48882e835d8767f62940d453eb96ed2737de3a16573881dcea7dea769df7", nil).
+						AnyTimes()
+					downloadOptions = buildpack.DownloadOptions{
+						RegistryName: "some-registry",
+						Target:       &dist.Target{OS: "linux"},
+						Daemon:       true,
+						PullPolicy:   image.PullAlways,
+					}
+				})
+
+				it("should report and pin the resolved version", func() {
+					downloadOptions.VersionLock = &buildpack.VersionLock{}
+
+					shouldFetchPackageImageWith(true, image.PullAlways, &dist.Target{OS: "linux"})
+					mainBP, _, err := buildpackDownloader.Download(context.TODO(), "example/foo@^1.0", downloadOptions)
+					h.AssertNil(t, err)
+					h.AssertEq(t, mainBP.Descriptor().Info().Version, "1.1.0")
+					h.AssertContains(t, out.String(), "Resolved 'example/foo@^1.0' to version '1.1.0'")
+					h.AssertEq(t, downloadOptions.VersionLock.Modules, []buildpack.LockedModule{
+						{ID: "example/foo", Constraint: "^1.0", Version: "1.1.0"},
+					})
+				})
+
+				it("should locate the pinned version", func() {
+					downloadOptions.VersionLock = &buildpack.VersionLock{
+						Modules: []buildpack.LockedModule{{ID: "example/foo", Constraint: "^1.0", Version: "1.1.0"}},
+					}
+
+					// the pinned version is looked up instead of the constraint
+					shouldFetchPackageImageWith(true, image.PullAlways, &dist.Target{OS: "linux"})
+					mainBP, _, err := buildpackDownloader.Download(context.TODO(), "example/foo@^1.0", downloadOptions)
+					h.AssertNil(t, err)
+					h.AssertEq(t, mainBP.Descriptor().Info().Version, "1.1.0")
+				})
+			})
+		})
+
+		when("package image tag is a version constraint", func() {
+			it("errors when the image fetcher cannot list tags", func() {
+				_, _, err := buildpackDownloader.Download(context.TODO(), "docker://some/package:^1.0", downloadOptions)
+				h.AssertError(t, err, "resolving version constraint '^1.0' of 'some/package': listing tags is not supported")
+			})
 		})
 
 		when("package image lives in docker registry", func() {
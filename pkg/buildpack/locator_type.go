@@ -35,8 +35,9 @@ const (
 
 var (
 	// https://semver.org/#is-there-a-suggested-regular-expression-regex-to-check-a-semver-string
-	semverPattern   = `(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?`
-	registryPattern = regexp.MustCompile(`^[a-z0-9\-\.]+\/[a-z0-9\-\.]+(?:@` + semverPattern + `)?$`)
+	semverPattern     = `(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?`
+	registryPattern   = regexp.MustCompile(`^[a-z0-9\-\.]+\/[a-z0-9\-\.]+(?:@` + semverPattern + `)?$`)
+	registryIDPattern = regexp.MustCompile(`^[a-z0-9\-\.]+\/[a-z0-9\-\.]+$`)
 )
 
 func (l LocatorType) String() string {
@@ -74,6 +75,9 @@ func GetLocatorType(locator string, relativeBaseDir string, buildpacksFromBuilde
 			if _, err := name.ParseReference(locator); err == nil {
 				return PackageLocator, nil
 			}
+			if _, constraint := ParseImageConstraint(ParsePackageLocator(locator)); constraint != "" {
+				return PackageLocator, nil
+			}
 		}
 		return URILocator, nil
 	}
@@ -120,13 +124,17 @@ func canBePackageRef(locator string) bool {
 }
 
 func canBeRegistryRef(locator string) bool {
-	return registryPattern.MatchString(locator)
+	if registryPattern.MatchString(locator) {
+		return true
+	}
+	id, version := ParseIDLocator(locator)
+	return IsVersionConstraint(version) && registryIDPattern.MatchString(id)
 }
 
 func isFoundInBuilder(locator string, candidates []dist.ModuleInfo) bool {
 	id, version := ParseIDLocator(locator)
 	for _, c := range candidates {
-		if id == c.ID && (version == "" || version == c.Version) {
+		if id == c.ID && (version == "" || version == c.Version || (IsVersionConstraint(version) && satisfies(version, c.Version))) {
 			return true
 		}
 	}
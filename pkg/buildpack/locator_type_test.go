@@ -56,6 +56,24 @@ func testGetLocatorType(t *testing.T, when spec.G, it spec.S) {
 			builderBPs:   []dist.ModuleInfo{{ID: "some-bp", Version: "some-version"}},
 			expectedType: buildpack.IDLocator,
 		},
+		{
+			locator:      "urn:cnb:builder:some-bp@^1.4",
+			builderBPs:   []dist.ModuleInfo{{ID: "some-bp", Version: "1.5.0"}},
+			expectedType: buildpack.IDLocator,
+		},
+		{
+			locator:     "urn:cnb:builder:some-bp@^1.4",
+			builderBPs:  []dist.ModuleInfo{{ID: "some-bp", Version: "2.0.0"}},
+			expectedErr: "'urn:cnb:builder:some-bp@^1.4' is not a valid identifier",
+		},
+		{
+			locator:      "some/bp@~2.1",
+			expectedType: buildpack.RegistryLocator,
+		},
+		{
+			locator:      "docker://cnbs/some-bp:^1.4",
+			expectedType: buildpack.PackageLocator,
+		},
 		{
 			locator:     "urn:cnb:builder:some-bp",
 			expectedErr: "'urn:cnb:builder:some-bp' is not a valid identifier",
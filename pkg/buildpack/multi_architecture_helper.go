@@ -1,11 +1,22 @@
 package buildpack
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/logging"
 )
@@ -54,6 +65,92 @@ func (m *MultiArchConfig) CopyConfigFiles(baseDir string) ([]string, error) {
 	return filesToClean, nil
 }
 
+// TargetBuildData is the data available to the build command of a target when it is rendered.
+type TargetBuildData struct {
+	OS           string
+	Arch         string
+	ArchVariant  string
+	TargetDir    string
+	BuildpackDir string
+}
+
+// BuildTargets will, given a base directory (which is expected to be the root folder of a single buildpack), run the
+// build command once per target to prepare the contents of its platform root folder, which is created when missing.
+// The command is rendered as a Go template with TargetBuildData (for example `go build -o {{.TargetDir}}/bin/build`)
+// and runs from the base directory with GOOS, GOARCH, GOARM and TARGET_* variables set for the target, on top of
+// the provided env.
+func (m *MultiArchConfig) BuildTargets(ctx context.Context, baseDir, command string, env map[string]string) error {
+	tmpl, err := template.New("build").Option("missingkey=error").Parse(command)
+	if err != nil {
+		return errors.Wrap(err, "parsing build command")
+	}
+
+	buildpackDir, err := filepath.Abs(baseDir)
+	if err != nil {
+		return err
+	}
+
+	for _, target := range dist.ExpandTargetsDistributions(m.Targets()...) {
+		targetDir := filepath.Join(append([]string{buildpackDir}, target.ValuesAsSlice()...)...)
+		if err := os.MkdirAll(targetDir, os.ModePerm); err != nil {
+			return errors.Wrapf(err, "creating target directory %s", style.Symbol(targetDir))
+		}
+
+		rendered := &bytes.Buffer{}
+		if err := tmpl.Execute(rendered, TargetBuildData{
+			OS:           target.OS,
+			Arch:         target.Arch,
+			ArchVariant:  target.ArchVariant,
+			TargetDir:    targetDir,
+			BuildpackDir: buildpackDir,
+		}); err != nil {
+			return errors.Wrapf(err, "rendering build command for target %s", style.Symbol(target.ValuesAsPlatform()))
+		}
+
+		m.logger.Infof("Building target %s: %s", style.Symbol(target.ValuesAsPlatform()), rendered.String())
+		cmd := hostCommand(ctx, rendered.String())
+		cmd.Dir = buildpackDir
+		cmd.Env = append(os.Environ(), targetBuildEnv(target, targetDir, env)...)
+		cmd.Stdout = logging.GetWriterForLevel(m.logger, logging.InfoLevel)
+		cmd.Stderr = logging.GetWriterForLevel(m.logger, logging.ErrorLevel)
+		if err := cmd.Run(); err != nil {
+			return errors.Wrapf(err, "building target %s", style.Symbol(target.ValuesAsPlatform()))
+		}
+	}
+	return nil
+}
+
+func hostCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+func targetBuildEnv(target dist.Target, targetDir string, env map[string]string) []string {
+	vars := []string{
+		"GOOS=" + target.OS,
+		"GOARCH=" + target.Arch,
+		"TARGET_OS=" + target.OS,
+		"TARGET_ARCH=" + target.Arch,
+		"TARGET_ARCH_VARIANT=" + target.ArchVariant,
+		"TARGET_DIR=" + targetDir,
+	}
+	if target.Arch == "arm" && target.ArchVariant != "" {
+		vars = append(vars, "GOARM="+strings.TrimPrefix(target.ArchVariant, "v"))
+	}
+
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		vars = append(vars, fmt.Sprintf("%s=%s", key, env[key]))
+	}
+	return vars
+}
+
 // CopyConfigFile will copy the buildpack.toml file from the base directory into the corresponding platform folder
 // for the specified target and desired distribution version.
 func CopyConfigFile(baseDir string, target dist.Target) (string, error) {
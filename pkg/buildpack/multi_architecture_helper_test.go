@@ -2,8 +2,10 @@ package buildpack_test
 
 import (
 	"bytes"
+	"context"
 	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/heroku/color"
@@ -118,6 +120,48 @@ func testMultiArchConfig(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("#BuildTargets", func() {
+		var rootFolder string
+
+		it.Before(func() {
+			h.SkipIf(t, runtime.GOOS == "windows", "build commands are run with sh")
+
+			rootFolder = filepath.Join(tmpDir, "some-buildpack")
+			h.AssertNil(t, os.MkdirAll(rootFolder, 0755))
+
+			targetsFromBuildpack = []dist.Target{{OS: "linux", Arch: "amd64"}, {OS: "linux", Arch: "arm", ArchVariant: "v7"}}
+			multiArchConfig, err = buildpack.NewMultiArchConfig(targetsFromBuildpack, []dist.Target{}, logger)
+			h.AssertNil(t, err)
+		})
+
+		it("runs the build command in each target platform folder", func() {
+			err := multiArchConfig.BuildTargets(context.TODO(), rootFolder,
+				`echo "$GOOS $GOARCH $GOARM $SOME_VAR" > {{.TargetDir}}/built`,
+				map[string]string{"SOME_VAR": "some-value"})
+			h.AssertNil(t, err)
+
+			contents, err := os.ReadFile(filepath.Join(rootFolder, "linux", "amd64", "built"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(contents), "linux amd64  some-value\n")
+
+			contents, err = os.ReadFile(filepath.Join(rootFolder, "linux", "arm", "v7", "built"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, string(contents), "linux arm 7 some-value\n")
+
+			h.AssertContains(t, outBuf.String(), "Building target 'linux/arm/v7'")
+		})
+
+		it("returns an error when the build command fails", func() {
+			err := multiArchConfig.BuildTargets(context.TODO(), rootFolder, "exit 1", nil)
+			h.AssertError(t, err, "building target 'linux/amd64'")
+		})
+
+		it("returns an error when the build command references unknown data", func() {
+			err := multiArchConfig.BuildTargets(context.TODO(), rootFolder, "echo {{.Unknown}}", nil)
+			h.AssertError(t, err, "rendering build command for target 'linux/amd64'")
+		})
+	})
+
 	when("#PlatformRootFolder", func() {
 		var target dist.Target
 
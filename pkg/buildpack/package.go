@@ -33,6 +33,11 @@ func (s *syncPkg) GetLayer(diffID string) (io.ReadCloser, error) {
 	return s.pkg.GetLayer(diffID)
 }
 
+// BuildpacksFromPackage constructs the buildpacks stored in a buildpack package, such as a buildpack package image.
+func BuildpacksFromPackage(pkg Package) (mainBP BuildModule, dependencies []BuildModule, err error) {
+	return extractBuildpacks(pkg)
+}
+
 // extractBuildpacks when provided a flattened buildpack package containing N buildpacks,
 // will return N modules: 1 module with a single tar containing ALL N buildpacks, and N-1 modules with empty tar files.
 func extractBuildpacks(pkg Package) (mainBP BuildModule, depBPs []BuildModule, err error) {
@@ -57,3 +57,16 @@ func parseBuilderLocator(locator string) (path string) {
 		strings.TrimPrefix(locator, deprecatedFromBuilderPrefix+":"),
 		fromBuilderPrefix+":")
 }
+
+// ParseImageConstraint parses an image name whose tag is a version constraint (ie. `<repo>:^1.4`) into the repository
+// and the constraint. The constraint is empty when the tag is missing or is not a version constraint.
+func ParseImageConstraint(imageName string) (repository string, constraint string) {
+	idx := strings.LastIndex(imageName, ":")
+	if idx < 0 || strings.Contains(imageName[idx+1:], "/") {
+		return imageName, ""
+	}
+	if tag := imageName[idx+1:]; IsVersionConstraint(tag) {
+		return imageName[:idx], tag
+	}
+	return imageName, ""
+}
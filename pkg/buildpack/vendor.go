@@ -0,0 +1,276 @@
+package buildpack
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/paths"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/archive"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+const (
+	// VendorDir is the directory, relative to the buildpack root, where vendored dependencies are stored as
+	// <VendorDir>/<sha256>/<file name>.
+	VendorDir = "dependencies"
+
+	// VendorManifestFile is the manifest, relative to the buildpack root, listing the vendored dependencies.
+	VendorManifestFile = VendorDir + "/manifest.toml"
+)
+
+// Dependency is a dependency declared under [[metadata.dependencies]] in buildpack.toml. Dependencies with an os or
+// arch only apply to matching targets.
+type Dependency struct {
+	ID      string `toml:"id"`
+	Name    string `toml:"name,omitempty"`
+	Version string `toml:"version"`
+	URI     string `toml:"uri"`
+	SHA256  string `toml:"sha256"`
+	OS      string `toml:"os,omitempty"`
+	Arch    string `toml:"arch,omitempty"`
+}
+
+// VendoredDependency is an entry of the vendor manifest; Path is relative to the buildpack root.
+type VendoredDependency struct {
+	ID      string `toml:"id"`
+	Version string `toml:"version"`
+	URI     string `toml:"uri"`
+	SHA256  string `toml:"sha256"`
+	Path    string `toml:"path"`
+}
+
+// VendorManifest lists the dependencies vendored into a buildpack, so the buildpack can find them at build time
+// instead of downloading them.
+type VendorManifest struct {
+	Dependencies []VendoredDependency `toml:"dependencies"`
+}
+
+// ReadDependencies returns the dependencies declared under [[metadata.dependencies]] in the buildpack.toml at the root
+// of the blob.
+func ReadDependencies(bpBlob Blob) ([]Dependency, error) {
+	rc, err := bpBlob.Open()
+	if err != nil {
+		return nil, errors.Wrap(err, "open buildpack")
+	}
+	defer rc.Close()
+
+	_, buf, err := archive.ReadTarEntry(rc, "buildpack.toml")
+	if err != nil {
+		return nil, errors.Wrap(err, "reading buildpack.toml")
+	}
+
+	descriptor := struct {
+		Metadata struct {
+			Dependencies []Dependency `toml:"dependencies"`
+		} `toml:"metadata"`
+	}{}
+	if _, err := toml.Decode(string(buf), &descriptor); err != nil {
+		return nil, errors.Wrap(err, "decoding buildpack.toml")
+	}
+	return descriptor.Metadata.Dependencies, nil
+}
+
+// VendorDependencies returns a blob with the contents of the buildpack root blob plus every dependency declared in its
+// buildpack.toml that applies to the target. Each dependency is downloaded, verified against its sha256 and stored
+// under VendorDir, along with a VendorManifestFile describing them.
+func VendorDependencies(ctx context.Context, bpBlob Blob, downloader blob.Downloader, target dist.Target, logger Logger) (Blob, error) {
+	deps, err := ReadDependencies(bpBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	vendored := &vendoredBlob{root: bpBlob}
+	manifest := VendorManifest{}
+	for _, dep := range deps {
+		if !dependencyAppliesTo(dep, target) {
+			logger.Debugf("Skipping dependency %s for target %s", style.Symbol(dep.ID+"@"+dep.Version), style.Symbol(target.ValuesAsPlatform()))
+			continue
+		}
+		if dep.URI == "" || dep.SHA256 == "" {
+			return nil, errors.Errorf("dependency %s must declare a %s and %s to be vendored", style.Symbol(dep.ID+"@"+dep.Version), style.Symbol("uri"), style.Symbol("sha256"))
+		}
+
+		logger.Infof("Vendoring dependency %s", style.Symbol(dep.ID+"@"+dep.Version))
+		depBlob, err := downloader.Download(ctx, dep.URI)
+		if err != nil {
+			return nil, errors.Wrapf(err, "downloading dependency %s", style.Symbol(dep.ID))
+		}
+
+		size, err := verifyDependency(depBlob, dep.SHA256)
+		if err != nil {
+			return nil, errors.Wrapf(err, "verifying dependency %s", style.Symbol(dep.ID))
+		}
+
+		depPath := path.Join(VendorDir, strings.ToLower(dep.SHA256), dependencyFileName(dep))
+		vendored.files = append(vendored.files, vendoredFile{path: depPath, size: size, blob: depBlob})
+		manifest.Dependencies = append(manifest.Dependencies, VendoredDependency{
+			ID:      dep.ID,
+			Version: dep.Version,
+			URI:     dep.URI,
+			SHA256:  strings.ToLower(dep.SHA256),
+			Path:    depPath,
+		})
+	}
+
+	if len(manifest.Dependencies) == 0 {
+		logger.Warnf("No dependencies to vendor for target %s", style.Symbol(target.ValuesAsPlatform()))
+		return bpBlob, nil
+	}
+
+	buf := &bytes.Buffer{}
+	if err := toml.NewEncoder(buf).Encode(manifest); err != nil {
+		return nil, errors.Wrap(err, "encoding vendor manifest")
+	}
+	vendored.manifest = buf.Bytes()
+
+	return vendored, nil
+}
+
+func dependencyAppliesTo(dep Dependency, target dist.Target) bool {
+	return (dep.OS == "" || target.OS == "" || dep.OS == target.OS) &&
+		(dep.Arch == "" || target.Arch == "" || dep.Arch == target.Arch)
+}
+
+func dependencyFileName(dep Dependency) string {
+	name := dep.URI
+	if paths.IsURI(dep.URI) {
+		if u, err := url.Parse(dep.URI); err == nil {
+			name = u.Path
+		}
+	}
+	name = path.Base(filepath.ToSlash(name))
+	if name == "." || name == "/" || name == "" {
+		return dep.ID
+	}
+	return name
+}
+
+func verifyDependency(depBlob Blob, expected string) (int64, error) {
+	rc, err := blob.OpenRaw(depBlob)
+	if err != nil {
+		return 0, err
+	}
+	defer rc.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, rc)
+	if err != nil {
+		return 0, err
+	}
+
+	if actual := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(actual, expected) {
+		return 0, errors.Errorf("expected sha256 %s, found %s", style.Symbol(expected), style.Symbol(actual))
+	}
+	return size, nil
+}
+
+type vendoredFile struct {
+	path string
+	size int64
+	blob Blob
+}
+
+// vendoredBlob adds the vendored dependencies and their manifest to the tar stream of a buildpack root blob
+type vendoredBlob struct {
+	root     Blob
+	files    []vendoredFile
+	manifest []byte
+}
+
+func (b *vendoredBlob) Open() (io.ReadCloser, error) {
+	rc, err := b.root.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return archive.GenerateTar(func(tw archive.TarWriter) error {
+		defer rc.Close()
+
+		tr := tar.NewReader(rc)
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return errors.Wrap(err, "failed to get next tar entry")
+			}
+			if name := path.Clean(header.Name); name == VendorDir || strings.HasPrefix(name, VendorDir+"/") {
+				// replaced by the vendored dependencies
+				continue
+			}
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+		}
+
+		dirs := map[string]bool{}
+		writeDir := func(dir string) error {
+			if dirs[dir] {
+				return nil
+			}
+			dirs[dir] = true
+			return tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: dir, Mode: 0755, ModTime: archive.NormalizedDateTime})
+		}
+
+		if err := writeDir(VendorDir); err != nil {
+			return err
+		}
+		for _, file := range b.files {
+			if err := writeDir(path.Dir(file.path)); err != nil {
+				return err
+			}
+			if err := writeVendoredFile(tw, file); err != nil {
+				return err
+			}
+		}
+
+		if err := tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     VendorManifestFile,
+			Mode:     0644,
+			Size:     int64(len(b.manifest)),
+			ModTime:  archive.NormalizedDateTime,
+		}); err != nil {
+			return err
+		}
+		_, err := tw.Write(b.manifest)
+		return err
+	}), nil
+}
+
+func writeVendoredFile(tw archive.TarWriter, file vendoredFile) error {
+	rc, err := blob.OpenRaw(file.blob)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	if err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     file.path,
+		Mode:     0644,
+		Size:     file.size,
+		ModTime:  archive.NormalizedDateTime,
+	}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, rc)
+	return err
+}
@@ -0,0 +1,190 @@
+package buildpack
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/Masterminds/semver"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// IsVersionConstraint returns true when the version of a module reference is a semver range (ie. `^1.4`, `~2.1`,
+// `>=1.0.0, <2.0.0` or `1.x`) rather than an exact version.
+func IsVersionConstraint(version string) bool {
+	if version == "" {
+		return false
+	}
+	if strings.ContainsAny(version, "^~<>=*|, ") {
+		return true
+	}
+	for _, segment := range strings.Split(version, ".") {
+		if segment == "x" || segment == "X" {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveVersion returns the highest of the available versions satisfying the constraint. Available versions that
+// are not valid semver are ignored.
+func ResolveVersion(constraint string, available []string) (string, error) {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing version constraint %s", style.Symbol(constraint))
+	}
+
+	var (
+		highest        *semver.Version
+		highestVersion string
+	)
+	for _, v := range available {
+		parsed, err := semver.NewVersion(v)
+		if err != nil {
+			continue
+		}
+		if !c.Check(parsed) {
+			continue
+		}
+		if highest == nil || parsed.GreaterThan(highest) {
+			highest, highestVersion = parsed, v
+		}
+	}
+
+	if highest == nil {
+		return "", errors.Errorf("no version satisfies %s (available: %s)", style.Symbol(constraint), strings.Join(sortedVersions(available), ", "))
+	}
+	return highestVersion, nil
+}
+
+// CompareVersions returns -1, 0 or 1 when version a is lower than, equal to or higher than version b. Versions that
+// are not valid semver are lower than every valid one, and compare by their text between themselves.
+func CompareVersions(a, b string) int {
+	va, erra := semver.NewVersion(a)
+	vb, errb := semver.NewVersion(b)
+	switch {
+	case erra == nil && errb == nil:
+		return va.Compare(vb)
+	case erra == nil:
+		return 1
+	case errb == nil:
+		return -1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func satisfies(constraint, version string) bool {
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false
+	}
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+	return c.Check(v)
+}
+
+func sortedVersions(versions []string) []string {
+	sorted := append([]string{}, versions...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// LockedModule pins the version a constraint resolved to.
+type LockedModule struct {
+	ID         string `toml:"id"`
+	Constraint string `toml:"constraint"`
+	Version    string `toml:"version"`
+}
+
+// VersionLock records the versions that version constraints were resolved to, so later resolutions keep choosing
+// the same versions while they remain available and satisfy the constraint.
+type VersionLock struct {
+	Modules []LockedModule `toml:"modules"`
+}
+
+// ReadVersionLock reads a version lock file. A missing file results in an empty lock.
+func ReadVersionLock(path string) (*VersionLock, error) {
+	lock := &VersionLock{}
+	if _, err := toml.DecodeFile(path, lock); err != nil {
+		if os.IsNotExist(err) {
+			return lock, nil
+		}
+		return nil, errors.Wrapf(err, "reading version lock %s", style.Symbol(path))
+	}
+	return lock, nil
+}
+
+// Write writes the version lock to path, sorted by id.
+func (l *VersionLock) Write(path string) error {
+	sort.Slice(l.Modules, func(i, j int) bool {
+		if l.Modules[i].ID != l.Modules[j].ID {
+			return l.Modules[i].ID < l.Modules[j].ID
+		}
+		return l.Modules[i].Constraint < l.Modules[j].Constraint
+	})
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrapf(err, "creating directory for version lock %s", style.Symbol(path))
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "writing version lock %s", style.Symbol(path))
+	}
+	defer f.Close()
+
+	return toml.NewEncoder(f).Encode(l)
+}
+
+// Pinned returns the version pinned for the module id and constraint, if it still satisfies the constraint.
+func (l *VersionLock) Pinned(id, constraint string) (string, bool) {
+	if l == nil {
+		return "", false
+	}
+	for _, m := range l.Modules {
+		if m.ID == id && m.Constraint == constraint && satisfies(constraint, m.Version) {
+			return m.Version, true
+		}
+	}
+	return "", false
+}
+
+// Pin records the version the constraint of the module id resolved to.
+func (l *VersionLock) Pin(id, constraint, version string) {
+	if l == nil {
+		return
+	}
+	for i, m := range l.Modules {
+		if m.ID == id && m.Constraint == constraint {
+			l.Modules[i].Version = version
+			return
+		}
+	}
+	l.Modules = append(l.Modules, LockedModule{ID: id, Constraint: constraint, Version: version})
+}
+
+// Resolve returns the version the constraint of the module id resolves to among the available versions. A pinned
+// version is preferred while it is available; otherwise the highest satisfying version is chosen and pinned.
+// Resolve may be called on a nil lock, in which case nothing is pinned.
+func (l *VersionLock) Resolve(id, constraint string, available []string) (string, error) {
+	if pinned, ok := l.Pinned(id, constraint); ok {
+		for _, v := range available {
+			if v == pinned {
+				return pinned, nil
+			}
+		}
+	}
+
+	version, err := ResolveVersion(constraint, available)
+	if err != nil {
+		return "", errors.Wrapf(err, "resolving version of %s", style.Symbol(id))
+	}
+	l.Pin(id, constraint, version)
+	return version, nil
+}
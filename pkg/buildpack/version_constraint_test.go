@@ -0,0 +1,137 @@
+package buildpack_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/buildpack"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestVersionConstraint(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "testVersionConstraint", testVersionConstraint, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testVersionConstraint(t *testing.T, when spec.G, it spec.S) {
+	when("#IsVersionConstraint", func() {
+		it("detects version constraints", func() {
+			for _, v := range []string{"^1.4", "~2.1", ">=1.0.0, <2.0.0", "1.x", "1.2.X", "*", "1.0.0 || 2.0.0"} {
+				h.AssertTrue(t, buildpack.IsVersionConstraint(v))
+			}
+		})
+
+		it("does not treat exact versions as constraints", func() {
+			for _, v := range []string{"", "1.4.0", "1.4.0-rc.1", "some-version"} {
+				h.AssertFalse(t, buildpack.IsVersionConstraint(v))
+			}
+		})
+	})
+
+	when("#ResolveVersion", func() {
+		available := []string{"1.3.0", "1.4.2", "1.10.0", "2.1.0", "2.1.5", "2.2.0", "not-semver"}
+
+		it("resolves to the highest satisfying version", func() {
+			version, err := buildpack.ResolveVersion("^1.4", available)
+			h.AssertNil(t, err)
+			h.AssertEq(t, version, "1.10.0")
+
+			version, err = buildpack.ResolveVersion("~2.1", available)
+			h.AssertNil(t, err)
+			h.AssertEq(t, version, "2.1.5")
+		})
+
+		it("errors when no version satisfies the constraint", func() {
+			_, err := buildpack.ResolveVersion("^3.0", available)
+			h.AssertError(t, err, "no version satisfies '^3.0'")
+		})
+
+		it("errors when the constraint is invalid", func() {
+			_, err := buildpack.ResolveVersion("^not-a-version", available)
+			h.AssertError(t, err, "parsing version constraint '^not-a-version'")
+		})
+	})
+
+	when("#CompareVersions", func() {
+		it("compares versions by semver precedence", func() {
+			h.AssertEq(t, buildpack.CompareVersions("1.10.0", "1.9.0"), 1)
+			h.AssertEq(t, buildpack.CompareVersions("1.0.0-rc.1", "1.0.0"), -1)
+			h.AssertEq(t, buildpack.CompareVersions("2.0.0", "2.0.0"), 0)
+		})
+
+		it("orders versions that are not semver below the ones that are", func() {
+			h.AssertEq(t, buildpack.CompareVersions("not-semver", "0.0.1"), -1)
+			h.AssertEq(t, buildpack.CompareVersions("0.0.1", "not-semver"), 1)
+			h.AssertEq(t, buildpack.CompareVersions("b-version", "a-version"), 1)
+		})
+	})
+
+	when("VersionLock", func() {
+		var tmpDir string
+
+		it.Before(func() {
+			var err error
+			tmpDir, err = os.MkdirTemp("", "version-lock")
+			h.AssertNil(t, err)
+		})
+
+		it.After(func() {
+			h.AssertNil(t, os.RemoveAll(tmpDir))
+		})
+
+		it("reads a missing lock file as an empty lock", func() {
+			lock, err := buildpack.ReadVersionLock(filepath.Join(tmpDir, "missing.toml"))
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(lock.Modules), 0)
+		})
+
+		it("pins the resolved version and prefers it while available", func() {
+			lock := &buildpack.VersionLock{}
+			version, err := lock.Resolve("some/bp", "^1.4", []string{"1.4.0", "1.5.0"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, version, "1.5.0")
+
+			version, err = lock.Resolve("some/bp", "^1.4", []string{"1.4.0", "1.5.0", "1.6.0"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, version, "1.5.0")
+
+			version, err = lock.Resolve("some/bp", "^1.4", []string{"1.4.0", "1.6.0"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, version, "1.6.0")
+		})
+
+		it("ignores pins that do not satisfy the constraint", func() {
+			lock := &buildpack.VersionLock{Modules: []buildpack.LockedModule{{ID: "some/bp", Constraint: "^1.4", Version: "2.0.0"}}}
+			_, ok := lock.Pinned("some/bp", "^1.4")
+			h.AssertFalse(t, ok)
+		})
+
+		it("resolves without pinning on a nil lock", func() {
+			var lock *buildpack.VersionLock
+			version, err := lock.Resolve("some/bp", "~1.4", []string{"1.4.0", "1.4.3", "1.5.0"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, version, "1.4.3")
+		})
+
+		it("writes and reads back the lock", func() {
+			path := filepath.Join(tmpDir, "nested", "pack.lock")
+			lock := &buildpack.VersionLock{}
+			lock.Pin("some/other-bp", "~2.1", "2.1.5")
+			lock.Pin("some/bp", "^1.4", "1.5.0")
+			h.AssertNil(t, lock.Write(path))
+
+			read, err := buildpack.ReadVersionLock(path)
+			h.AssertNil(t, err)
+			h.AssertEq(t, read.Modules, []buildpack.LockedModule{
+				{ID: "some/bp", Constraint: "^1.4", Version: "1.5.0"},
+				{ID: "some/other-bp", Constraint: "~2.1", Version: "2.1.5"},
+			})
+		})
+	})
+}
@@ -112,10 +112,22 @@ type BuildOptions struct {
 	//    the builder metadata
 	AdditionalMirrors map[string][]string
 
+	// Only select a run image mirror with the same digest as the run image,
+	// skipping mirrors that don't respond within RunImageMirrorTimeout.
+	VerifyRunImageMirrors bool
+
+	// How long the run image and each mirror may take to respond when VerifyRunImageMirrors is set.
+	// Defaults to DefaultRunImageMirrorTimeout when not positive.
+	RunImageMirrorTimeout time.Duration
+
 	// User provided environment variables to the buildpacks.
 	// Buildpacks may both read and overwrite these values.
 	Env map[string]string
 
+	// Names of the secrets of the ProjectDescriptor allowed to be read from environment variables.
+	// Secrets read from files don't need to be allowed.
+	Secrets []string
+
 	// Used to configure various cache available options
 	Cache cache.CacheOpts
 
@@ -157,6 +169,7 @@ type BuildOptions struct {
 	ContainerConfig ContainerConfig
 
 	// Process type that will be used when setting container start command.
+	// When empty, the default process declared in the ProjectDescriptor is used.
 	DefaultProcessType string
 
 	// Platform is the desired platform to build on (e.g., linux/amd64)
@@ -212,6 +225,12 @@ type BuildOptions struct {
 
 	// Configuration to export to OCI layout format
 	LayoutConfig *LayoutConfig
+
+	// Pins the versions that version constraints of the buildpacks resolve to. Optional.
+	VersionLock *buildpack.VersionLock
+
+	// Fail instead of warning when the build uses deprecated buildpacks or extensions.
+	Strict bool
 }
 
 func (b *BuildOptions) Layout() bool {
@@ -291,6 +310,12 @@ var IsTrustedBuilderFunc = func(b string) bool {
 // If any configuration is deemed invalid, or if any lifecycle phases fail,
 // an error will be returned and no image produced.
 func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
+	return c.build(ctx, opts, "")
+}
+
+// build runs the lifecycle against the app. If detectOutputDir is set, only detection is run
+// and the resulting group.toml and plan.toml are written to detectOutputDir.
+func (c *Client) build(ctx context.Context, opts BuildOptions, detectOutputDir string) error {
 	var pathsConfig layoutPathConfig
 
 	imageRef, err := c.parseReference(opts)
@@ -318,11 +343,6 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 
 	proxyConfig := c.processProxyConfig(opts.ProxyConfig)
 
-	builderRef, err := c.processBuilderName(opts.Builder)
-	if err != nil {
-		return errors.Wrapf(err, "invalid builder '%s'", opts.Builder)
-	}
-
 	requestedTarget := func() *dist.Target {
 		if opts.Platform == "" {
 			return nil
@@ -340,13 +360,28 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		}
 	}()
 
+	builderName, builderPullPolicy := opts.Builder, opts.PullPolicy
+	if image.IsArchiveReference(opts.Builder) {
+		// builders stored as files are loaded into the daemon, and then referenced by the name they were loaded as
+		archiveBuilder, err := c.imageFetcher.Fetch(ctx, opts.Builder, image.FetchOptions{Daemon: true, Target: requestedTarget, PullPolicy: image.PullNever})
+		if err != nil {
+			return errors.Wrapf(err, "failed to load builder '%s'", opts.Builder)
+		}
+		builderName, builderPullPolicy = archiveBuilder.Name(), image.PullNever
+	}
+
+	builderRef, err := c.processBuilderName(builderName)
+	if err != nil {
+		return errors.Wrapf(err, "invalid builder '%s'", opts.Builder)
+	}
+
 	rawBuilderImage, err := c.imageFetcher.Fetch(
 		ctx,
 		builderRef.Name(),
 		image.FetchOptions{
 			Daemon:     true,
 			Target:     requestedTarget,
-			PullPolicy: opts.PullPolicy},
+			PullPolicy: builderPullPolicy},
 	)
 	if err != nil {
 		return errors.Wrapf(err, "failed to fetch builder image '%s'", builderRef.Name())
@@ -372,7 +407,12 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		PullPolicy: opts.PullPolicy,
 		Target:     targetToUse,
 	}
-	runImageName := c.resolveRunImage(opts.RunImage, imgRegistry, builderRef.Context().RegistryStr(), bldr.DefaultRunImage(), opts.AdditionalMirrors, opts.Publish, fetchOptions)
+	var runImageName string
+	if opts.VerifyRunImageMirrors && opts.RunImage == "" {
+		runImageName = c.resolveVerifiedRunImage(ctx, imgRegistry, builderRef.Context().RegistryStr(), bldr.DefaultRunImage(), opts.AdditionalMirrors, opts.Publish, targetToUse, opts.RunImageMirrorTimeout)
+	} else {
+		runImageName = c.resolveRunImage(opts.RunImage, imgRegistry, builderRef.Context().RegistryStr(), bldr.DefaultRunImage(), opts.AdditionalMirrors, opts.Publish, fetchOptions)
+	}
 
 	if opts.Layout() {
 		targetRunImagePath, err := layout.ParseRefToPath(runImageName)
@@ -409,6 +449,13 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return err
 	}
 
+	if err := c.checkDeprecations(buildpack.KindBuildpack, usedModules(fetchedBPs, bldr.Buildpacks(), order, bldr.Order()), opts.Strict); err != nil {
+		return err
+	}
+	if err := c.checkDeprecations(buildpack.KindExtension, usedModules(fetchedExs, bldr.Extensions(), orderExtensions, bldr.OrderExtensions()), opts.Strict); err != nil {
+		return err
+	}
+
 	// Default mode: if the TrustBuilder option is not set, trust the suggested builders.
 	if opts.TrustBuilder == nil {
 		opts.TrustBuilder = IsTrustedBuilderFunc
@@ -426,7 +473,8 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 
 	// Get the platform API version to use
 	lifecycleVersion := bldr.LifecycleDescriptor().Info.Version
-	useCreator := supportsCreator(lifecycleVersion) && opts.TrustBuilder(opts.Builder)
+	detectOnly := detectOutputDir != ""
+	useCreator := supportsCreator(lifecycleVersion) && opts.TrustBuilder(opts.Builder) && !detectOnly
 	var (
 		lifecycleOptsLifecycleImage string
 		lifecycleAPIs               []string
@@ -501,9 +549,25 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		}
 	}
 
-	buildEnvs := map[string]string{}
-	for _, envVar := range opts.ProjectDescriptor.Build.Env {
-		buildEnvs[envVar.Name] = envVar.Value
+	defaultProcessType, err := projectDefaultProcess(opts.ProjectDescriptor)
+	if err != nil {
+		return err
+	}
+	if opts.DefaultProcessType != "" {
+		defaultProcessType = opts.DefaultProcessType
+	}
+
+	buildEnvs, err := projectBuildEnv(opts.ProjectDescriptor)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := projectSecrets(opts.ProjectDescriptor, opts.ProjectDescriptorBaseDir, opts.Secrets)
+	if err != nil {
+		return err
+	}
+	if len(secrets) > 0 && targetToUse.OS == "windows" {
+		return errors.New("secrets are not supported for Windows builds")
 	}
 
 	for k, v := range opts.Env {
@@ -543,7 +607,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		return err
 	}
 
-	runImageName, err = pname.TranslateRegistry(runImageName, c.registryMirrors, c.logger)
+	runImageName, err = c.translateRunImage(runImageName, runImage)
 	if err != nil {
 		return err
 	}
@@ -585,7 +649,7 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		Network:                  opts.ContainerConfig.Network,
 		AdditionalTags:           opts.AdditionalTags,
 		Volumes:                  processedVolumes,
-		DefaultProcessType:       opts.DefaultProcessType,
+		DefaultProcessType:       defaultProcessType,
 		FileFilter:               fileFilter,
 		Workspace:                opts.Workspace,
 		GID:                      opts.GroupID,
@@ -598,6 +662,9 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 		CreationTime:             opts.CreationTime,
 		Layout:                   opts.Layout(),
 		Keychain:                 c.keychain,
+		Secrets:                  secrets,
+		DetectOnly:               detectOnly,
+		DetectOutputDir:          detectOutputDir,
 	}
 
 	switch {
@@ -760,6 +827,9 @@ func (c *Client) Build(ctx context.Context, opts BuildOptions) error {
 	if err = c.lifecycleExecutor.Execute(ctx, lifecycleOpts); err != nil {
 		return fmt.Errorf("executing lifecycle: %w", err)
 	}
+	if detectOnly {
+		return nil
+	}
 	return c.logImageNameAndSha(ctx, opts.Publish, imageRef)
 }
 
@@ -879,6 +949,22 @@ func (c *Client) getBuilder(img imgutil.Image) (*builder.Builder, error) {
 	return bldr, nil
 }
 
+// translateRunImage returns the name the lifecycle should use for the run image, applying the registry mirror rules or
+// registry mirrors. When a rule matches, the mirror runImage was fetched from is preferred.
+func (c *Client) translateRunImage(runImageName string, runImage imgutil.Image) (string, error) {
+	candidates, err := pname.MirrorCandidates(runImageName, c.registryMirrorRules)
+	if err != nil {
+		return "", err
+	}
+	if len(candidates) == 0 {
+		return pname.TranslateRegistry(runImageName, c.registryMirrors, c.logger)
+	}
+	if contains(candidates, runImage.Name()) {
+		return runImage.Name(), nil
+	}
+	return candidates[0], nil
+}
+
 func (c *Client) validateRunImage(context context.Context, name string, opts image.FetchOptions, expectedStack string) (imgutil.Image, error) {
 	if name == "" {
 		return nil, errors.New("run image must be specified")
@@ -1249,6 +1335,13 @@ func (c *Client) fetchBuildpack(ctx context.Context, bp string, relativeBaseDir
 	switch locatorType {
 	case buildpack.IDLocator:
 		id, version := buildpack.ParseIDLocator(bp)
+		if buildpack.IsVersionConstraint(version) {
+			constraint := version
+			if version, err = opts.VersionLock.Resolve(id, constraint, builderModuleVersions(builderBPs, id)); err != nil {
+				return nil, nil, err
+			}
+			c.logger.Infof("Resolved %s to version %s", style.Symbol(id+"@"+constraint), style.Symbol(version))
+		}
 		moduleInfo = &dist.ModuleInfo{
 			ID:      id,
 			Version: version,
@@ -1260,6 +1353,7 @@ func (c *Client) fetchBuildpack(ctx context.Context, bp string, relativeBaseDir
 			RelativeBaseDir: relativeBaseDir,
 			Daemon:          !publish,
 			PullPolicy:      pullPolicy,
+			VersionLock:     opts.VersionLock,
 		}
 		if kind == buildpack.KindExtension {
 			downloadOptions.ModuleKind = kind
@@ -1285,6 +1379,44 @@ func (c *Client) fetchBuildpack(ctx context.Context, bp string, relativeBaseDir
 	return fetchedBPs, moduleInfo, nil
 }
 
+// usedModules returns the modules a build may use: the fetched modules, and the builder modules referred to by the
+// order, which falls back to the builder order when it is empty.
+func usedModules(fetched []buildpack.BuildModule, builderModules []dist.ModuleInfo, order, builderOrder dist.Order) []dist.ModuleInfo {
+	if len(order) == 0 || len(order[0].Group) == 0 {
+		order = builderOrder
+	}
+
+	used := moduleInfos(fetched)
+	for _, module := range builderModules {
+		for _, entry := range order {
+			if containsModuleRef(entry.Group, module) {
+				used = append(used, module)
+				break
+			}
+		}
+	}
+	return used
+}
+
+func containsModuleRef(group []dist.ModuleRef, module dist.ModuleInfo) bool {
+	for _, ref := range group {
+		if ref.ID == module.ID && (ref.Version == "" || ref.Version == module.Version) {
+			return true
+		}
+	}
+	return false
+}
+
+func builderModuleVersions(builderModules []dist.ModuleInfo, id string) []string {
+	var versions []string
+	for _, module := range builderModules {
+		if module.ID == id {
+			versions = append(versions, module.Version)
+		}
+	}
+	return versions
+}
+
 func (c *Client) fetchBuildpackDependencies(ctx context.Context, bp string, packageCfgPath string, downloadOptions buildpack.DownloadOptions) ([]buildpack.BuildModule, error) {
 	packageReader := buildpackage.NewConfigReader()
 	packageCfg, err := packageReader.Read(packageCfgPath)
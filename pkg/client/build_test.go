@@ -485,6 +485,26 @@ func testBuild(t *testing.T, when spec.G, it spec.S) {
 					h.AssertEq(t, fakeLifecycle.Opts.Builder.Name(), customBuilderImage.Name())
 				})
 			})
+
+			when("the builder is stored as an OCI layout", func() {
+				it.Before(func() {
+					fakeImageFetcher.LocalImages["oci:/some/layout"] = defaultBuilderImage
+				})
+
+				it("loads the builder and uses it without pulling", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: "oci:/some/layout",
+					}))
+
+					args := fakeImageFetcher.FetchCalls["oci:/some/layout"]
+					h.AssertEq(t, args.Daemon, true)
+
+					args = fakeImageFetcher.FetchCalls[defaultBuilderName]
+					h.AssertEq(t, args.Daemon, true)
+					h.AssertEq(t, args.PullPolicy, image.PullNever)
+				})
+			})
 		})
 
 		when("RunImage option", func() {
@@ -1233,6 +1253,44 @@ api = "0.2"
 				})
 			})
 
+			when("a buildpack is deprecated", func() {
+				it("warns naming the replacement", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						ClearCache: true,
+						Buildpacks: []string{filepath.Join("testdata", "buildpack-deprecated")},
+					}))
+					h.AssertContains(t, outBuf.String(), "Warning: Buildpack 'bp.deprecated@1.0.0' is deprecated: No longer maintained, use 'bp.one@1.2.3' instead")
+				})
+
+				it("fails when strict", func() {
+					h.AssertError(t, subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						ClearCache: true,
+						Buildpacks: []string{filepath.Join("testdata", "buildpack-deprecated")},
+						Strict:     true,
+					}), "buildpack 'bp.deprecated@1.0.0' is deprecated: No longer maintained, use 'bp.one@1.2.3' instead")
+				})
+
+				it("warns about builder buildpacks deprecated by a registry", func() {
+					subject.registryResolver = &registryResolver{
+						deprecations: map[string]dist.Deprecation{
+							"buildpack.1.id@buildpack.1.version": {Replacement: "buildpack.2.id"},
+						},
+					}
+
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:      "some/app",
+						Builder:    defaultBuilderName,
+						ClearCache: true,
+						Buildpacks: []string{"buildpack.1.id"},
+					}))
+					h.AssertContains(t, outBuf.String(), "Warning: Buildpack 'buildpack.1.id@buildpack.1.version' is deprecated, use 'buildpack.2.id' instead")
+				})
+			})
+
 			when("buildpacks include URIs", func() {
 				var buildpackTgz string
 
@@ -1956,6 +2014,206 @@ api = "0.2"
 					})
 				})
 			})
+
+			when("env and secrets", func() {
+				it.Before(func() {
+					h.AssertNil(t, os.WriteFile(filepath.Join(tmpDir, "token.txt"), []byte("file-secret\n"), 0600))
+					t.Setenv("PACK_TEST_SECRET", "env-secret")
+				})
+
+				it("sets env vars on the ephemeral builder and passes secrets to the lifecycle only", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						ProjectDescriptor: projectTypes.Descriptor{
+							Build: projectTypes.Build{
+								Env: []projectTypes.EnvVar{
+									{Name: "key1", Value: "value1"},
+									{Name: "key2", Value: "value2", ExecEnv: []string{"build"}},
+								},
+								Secrets: []projectTypes.Secret{
+									{Name: "FILE_SECRET", File: "token.txt"},
+									{Name: "ENV_SECRET", Env: "PACK_TEST_SECRET"},
+								},
+							},
+						},
+						ProjectDescriptorBaseDir: tmpDir,
+						Secrets:                  []string{"ENV_SECRET"},
+					}))
+
+					layerTar, err := defaultBuilderImage.FindLayerWithPath("/platform/env/key1")
+					h.AssertNil(t, err)
+					h.AssertTarFileContents(t, layerTar, "/platform/env/key1", `value1`)
+					h.AssertTarFileContents(t, layerTar, "/platform/env/key2", `value2`)
+					_, err = defaultBuilderImage.FindLayerWithPath("/platform/env/FILE_SECRET")
+					h.AssertNotNil(t, err)
+					_, err = defaultBuilderImage.FindLayerWithPath("/platform/env/ENV_SECRET")
+					h.AssertNotNil(t, err)
+
+					h.AssertEq(t, fakeLifecycle.Opts.Secrets, map[string]string{
+						"FILE_SECRET": "file-secret",
+						"ENV_SECRET":  "env-secret",
+					})
+				})
+
+				it("fails when a secret read from an env var isn't allowed", func() {
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						ProjectDescriptor: projectTypes.Descriptor{
+							Build: projectTypes.Build{
+								Secrets: []projectTypes.Secret{{Name: "ENV_SECRET", Env: "PACK_TEST_SECRET"}},
+							},
+						},
+					})
+					h.AssertError(t, err, "secret 'ENV_SECRET' reads env var 'PACK_TEST_SECRET', allow it with '--secret ENV_SECRET'")
+				})
+
+				it("fails when a secret file is absolute", func() {
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						ProjectDescriptor: projectTypes.Descriptor{
+							Build: projectTypes.Build{
+								Secrets: []projectTypes.Secret{{Name: "TOKEN", File: filepath.Join(tmpDir, "token.txt")}},
+							},
+						},
+						ProjectDescriptorBaseDir: tmpDir,
+					})
+					h.AssertError(t, err, "must be relative to the project")
+				})
+
+				it("fails when a secret file is outside of the project", func() {
+					projectDir := filepath.Join(tmpDir, "project")
+					h.AssertNil(t, os.MkdirAll(projectDir, 0750))
+					h.AssertNil(t, os.Symlink(filepath.Join(tmpDir, "token.txt"), filepath.Join(projectDir, "link.txt")))
+
+					for _, file := range []string{"../token.txt", "link.txt"} {
+						err := subject.Build(context.TODO(), BuildOptions{
+							Image:   "some/app",
+							Builder: defaultBuilderName,
+							ProjectDescriptor: projectTypes.Descriptor{
+								Build: projectTypes.Build{
+									Secrets: []projectTypes.Secret{{Name: "TOKEN", File: file}},
+								},
+							},
+							ProjectDescriptorBaseDir: projectDir,
+						})
+						h.AssertError(t, err, "is outside of the project")
+					}
+				})
+
+				it("fails when an env var is scoped to buildpacks", func() {
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						ProjectDescriptor: projectTypes.Descriptor{
+							Build: projectTypes.Build{
+								Env: []projectTypes.EnvVar{{Name: "key1", Value: "value1", Buildpacks: []string{"some/bp"}}},
+							},
+						},
+					})
+					h.AssertError(t, err, "env var 'key1' is scoped to buildpacks some/bp, which is not supported yet")
+				})
+
+				it("fails when a secret is scoped to buildpacks", func() {
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						ProjectDescriptor: projectTypes.Descriptor{
+							Build: projectTypes.Build{
+								Secrets: []projectTypes.Secret{{Name: "ENV_SECRET", Env: "PACK_TEST_SECRET", Buildpacks: []string{"some/bp"}}},
+							},
+						},
+					})
+					h.AssertError(t, err, "secret 'ENV_SECRET' is scoped to buildpacks some/bp, which is not supported yet")
+				})
+
+				it("fails when an env var targets an exec-env other than build", func() {
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						ProjectDescriptor: projectTypes.Descriptor{
+							Build: projectTypes.Build{
+								Env: []projectTypes.EnvVar{{Name: "key1", Value: "value1", ExecEnv: []string{"build", "launch"}}},
+							},
+						},
+					})
+					h.AssertError(t, err, "env var 'key1' targets exec-env 'launch', which is not supported yet, only 'build' is")
+				})
+
+				it("fails when a secret env var is not set", func() {
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						ProjectDescriptor: projectTypes.Descriptor{
+							Build: projectTypes.Build{
+								Secrets: []projectTypes.Secret{{Name: "TOKEN", Env: "PACK_TEST_MISSING_SECRET"}},
+							},
+						},
+						Secrets: []string{"TOKEN"},
+					})
+					h.AssertError(t, err, "secret 'TOKEN' references env var 'PACK_TEST_MISSING_SECRET', which is not set")
+				})
+
+				it("fails when a secret file can't be read", func() {
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:   "some/app",
+						Builder: defaultBuilderName,
+						ProjectDescriptor: projectTypes.Descriptor{
+							Build: projectTypes.Build{
+								Secrets: []projectTypes.Secret{{Name: "TOKEN", File: "missing.txt"}},
+							},
+						},
+						ProjectDescriptorBaseDir: tmpDir,
+					})
+					h.AssertError(t, err, "reading secret 'TOKEN'")
+				})
+			})
+
+			when("run processes", func() {
+				var descriptor projectTypes.Descriptor
+
+				it.Before(func() {
+					descriptor = projectTypes.Descriptor{
+						Run: projectTypes.Run{
+							Processes: []projectTypes.Process{
+								{Type: "web"},
+								{Type: "worker", Default: true},
+							},
+						},
+					}
+				})
+
+				it("uses the default process of the descriptor", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:             "some/app",
+						Builder:           defaultBuilderName,
+						ProjectDescriptor: descriptor,
+					}))
+					h.AssertEq(t, fakeLifecycle.Opts.DefaultProcessType, "worker")
+				})
+
+				it("prefers the default process option", func() {
+					h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+						Image:              "some/app",
+						Builder:            defaultBuilderName,
+						DefaultProcessType: "web",
+						ProjectDescriptor:  descriptor,
+					}))
+					h.AssertEq(t, fakeLifecycle.Opts.DefaultProcessType, "web")
+				})
+
+				it("fails when a process overrides its command or args", func() {
+					descriptor.Run.Processes[1].Command = []string{"bin/worker"}
+					err := subject.Build(context.TODO(), BuildOptions{
+						Image:             "some/app",
+						Builder:           defaultBuilderName,
+						ProjectDescriptor: descriptor,
+					})
+					h.AssertError(t, err, "process 'worker' overrides command or args, which is not supported yet, only 'default' is")
+				})
+			})
 		})
 
 		when("Env option", func() {
@@ -2971,6 +3229,23 @@ api = "0.2"
 			})
 		})
 
+		when("RegistryMirrorRules option", func() {
+			it("rewrites run image with the first mirror before passing to lifecycle", func() {
+				subject.registryMirrors = map[string]string{
+					"index.docker.io": "10.0.0.1",
+				}
+				subject.registryMirrorRules = []image.RegistryMirrorRule{
+					{Match: "docker.io/default/*", Mirrors: []string{"artifactory.corp/default-remote/*", "docker.io/default/*"}},
+				}
+
+				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
+					Builder: defaultBuilderName,
+					Image:   "example.com/some/repo:tag",
+				}))
+				h.AssertEq(t, fakeLifecycle.Opts.RunImage, "artifactory.corp/default-remote/run:latest")
+			})
+		})
+
 		when("previous-image option", func() {
 			it("previous-image is passed to lifecycle", func() {
 				h.AssertNil(t, subject.Build(context.TODO(), BuildOptions{
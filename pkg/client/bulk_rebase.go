@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+const defaultRebaseConcurrency = 4
+
+// RebaseStatus is the outcome of rebasing a single image as part of a bulk rebase.
+type RebaseStatus string
+
+const (
+	RebaseStatusRebased RebaseStatus = "rebased"
+	RebaseStatusSkipped RebaseStatus = "skipped"
+	RebaseStatusFailed  RebaseStatus = "failed"
+)
+
+// BulkRebaseOptions is a configuration struct that controls rebasing many images at once.
+type BulkRebaseOptions struct {
+	// Images to rebase. Each entry is rebased as if passed to Rebase,
+	// except that ReportDestinationDir is ignored.
+	Images []RebaseOptions
+
+	// Maximum number of images rebased at the same time.
+	// Defaults to 4 when not positive.
+	Concurrency int
+}
+
+// RebaseResult reports what happened to a single image during a bulk rebase.
+type RebaseResult struct {
+	Image    string       `json:"image"`
+	Status   RebaseStatus `json:"status"`
+	RunImage string       `json:"runImage,omitempty"`
+	Error    string       `json:"error,omitempty"`
+}
+
+// BulkRebase rebases every image in opts using a bounded pool of workers. Run images are fetched
+// once and shared between all images resolving to them, and images already based on the top layer
+// of their run image are skipped. Failing images don't stop the others; their errors are reported
+// in the returned results, which are in the same order as opts.Images.
+func (c *Client) BulkRebase(ctx context.Context, opts BulkRebaseOptions) ([]RebaseResult, error) {
+	if len(opts.Images) == 0 {
+		return nil, errors.New("no images to rebase")
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultRebaseConcurrency
+	}
+	if concurrency > len(opts.Images) {
+		concurrency = len(opts.Images)
+	}
+
+	runImages := &runImageCache{fetcher: c.imageFetcher, images: map[runImageKey]*cachedRunImage{}}
+	results := make([]RebaseResult, len(opts.Images))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range indexes {
+				results[idx] = c.rebaseForBulk(ctx, opts.Images[idx], runImages)
+			}
+		}()
+	}
+
+	for idx := range opts.Images {
+		indexes <- idx
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results, nil
+}
+
+func (c *Client) rebaseForBulk(ctx context.Context, opts RebaseOptions, runImages *runImageCache) RebaseResult {
+	opts.ReportDestinationDir = ""
+	result := RebaseResult{Image: opts.RepoName}
+
+	if err := ctx.Err(); err != nil {
+		result.Status = RebaseStatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	rebased, err := c.rebase(ctx, opts, runImages.Fetch, true)
+	result.RunImage = rebased.runImage
+	switch {
+	case err != nil:
+		c.logger.Errorf("Failed to rebase %s: %s", opts.RepoName, err)
+		result.Status = RebaseStatusFailed
+		result.Error = err.Error()
+	case rebased.skipped:
+		result.Status = RebaseStatusSkipped
+	default:
+		result.Status = RebaseStatusRebased
+	}
+	return result
+}
+
+type runImageKey struct {
+	name   string
+	daemon bool
+	target string
+}
+
+type cachedRunImage struct {
+	once  sync.Once
+	image imgutil.Image
+	err   error
+}
+
+// runImageCache fetches each run image once, no matter how many app images are rebased on it.
+type runImageCache struct {
+	fetcher ImageFetcher
+
+	mu     sync.Mutex
+	images map[runImageKey]*cachedRunImage
+}
+
+func (r *runImageCache) Fetch(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error) {
+	key := runImageKey{name: name, daemon: options.Daemon}
+	if options.Target != nil {
+		key.target = options.Target.ValuesAsPlatform()
+	}
+
+	r.mu.Lock()
+	cached, ok := r.images[key]
+	if !ok {
+		cached = &cachedRunImage{}
+		r.images[key] = cached
+	}
+	r.mu.Unlock()
+
+	cached.once.Do(func() {
+		cached.image, cached.err = r.fetcher.Fetch(ctx, name, options)
+	})
+	return cached.image, cached.err
+}
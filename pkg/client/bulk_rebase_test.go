@@ -0,0 +1,126 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestBulkRebase(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "bulk_rebase", testBulkRebase, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+type countingImageFetcher struct {
+	*ifakes.FakeImageFetcher
+
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (f *countingImageFetcher) Fetch(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error) {
+	f.mu.Lock()
+	f.counts[name]++
+	f.mu.Unlock()
+	return f.FakeImageFetcher.Fetch(ctx, name, options)
+}
+
+func testBulkRebase(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fetcher  *countingImageFetcher
+		subject  *Client
+		runImage *fakes.Image
+		out      bytes.Buffer
+	)
+
+	var addAppImage = func(name, topLayer string) *fakes.Image {
+		appImage := fakes.NewImage(name, "", &fakeIdentifier{name: name + "-digest"})
+		h.AssertNil(t, appImage.SetLabel("io.buildpacks.lifecycle.metadata",
+			`{"runImage":{"image":"some/run","topLayer":"`+topLayer+`"}}`))
+		h.AssertNil(t, appImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+		fetcher.LocalImages[name] = appImage
+		return appImage
+	}
+
+	it.Before(func() {
+		fetcher = &countingImageFetcher{FakeImageFetcher: ifakes.NewFakeImageFetcher(), counts: map[string]int{}}
+
+		runImage = fakes.NewImage("some/run", "new-top-layer-sha", &fakeIdentifier{name: "run-image-digest"})
+		h.AssertNil(t, runImage.SetLabel("io.buildpacks.stack.id", "io.buildpacks.stacks.jammy"))
+		fetcher.LocalImages["some/run"] = runImage
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fetcher,
+		}
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, runImage.Cleanup())
+	})
+
+	when("#BulkRebase", func() {
+		it("rebases outdated images, skips up to date ones and reports failures", func() {
+			outdated := addAppImage("some/app-a", "old-top-layer-sha")
+			upToDate := addAppImage("some/app-b", "new-top-layer-sha")
+			otherOutdated := addAppImage("some/app-c", "old-top-layer-sha")
+
+			results, err := subject.BulkRebase(context.TODO(), BulkRebaseOptions{
+				Images: []RebaseOptions{
+					{RepoName: "some/app-a", PullPolicy: image.PullNever},
+					{RepoName: "some/app-b", PullPolicy: image.PullNever},
+					{RepoName: "some/missing", PullPolicy: image.PullNever},
+					{RepoName: "some/app-c", PullPolicy: image.PullNever},
+				},
+				Concurrency: 2,
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(results), 4)
+			h.AssertEq(t, results[0], RebaseResult{Image: "some/app-a", Status: RebaseStatusRebased, RunImage: "some/run"})
+			h.AssertEq(t, results[1], RebaseResult{Image: "some/app-b", Status: RebaseStatusSkipped, RunImage: "some/run"})
+			h.AssertEq(t, results[2].Status, RebaseStatusFailed)
+			h.AssertContains(t, results[2].Error, "image 'some/missing' does not exist on the daemon")
+			h.AssertEq(t, results[3], RebaseResult{Image: "some/app-c", Status: RebaseStatusRebased, RunImage: "some/run"})
+
+			h.AssertEq(t, outdated.Base(), "some/run")
+			h.AssertEq(t, upToDate.Base(), "")
+			h.AssertEq(t, otherOutdated.Base(), "some/run")
+			h.AssertContains(t, out.String(), "Skipping 'some/app-b', already based on run image 'some/run'")
+		})
+
+		it("fetches each run image once", func() {
+			for _, name := range []string{"some/app-a", "some/app-b", "some/app-c"} {
+				addAppImage(name, "old-top-layer-sha")
+			}
+
+			_, err := subject.BulkRebase(context.TODO(), BulkRebaseOptions{
+				Images: []RebaseOptions{
+					{RepoName: "some/app-a", PullPolicy: image.PullNever},
+					{RepoName: "some/app-b", PullPolicy: image.PullNever},
+					{RepoName: "some/app-c", PullPolicy: image.PullNever},
+				},
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, fetcher.counts["some/run"], 1)
+		})
+
+		it("errors when there are no images", func() {
+			_, err := subject.BulkRebase(context.TODO(), BulkRebaseOptions{})
+			h.AssertError(t, err, "no images to rebase")
+		})
+	})
+}
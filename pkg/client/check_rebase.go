@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// RebaseCheck reports whether an app image is based on the latest version of its run image.
+type RebaseCheck struct {
+	// Name of the checked app image.
+	Image string `json:"image"`
+
+	// Run image, or run image mirror, the app image would be rebased on.
+	RunImage string `json:"runImage"`
+
+	// Run image reference and top layer recorded on the app image when it was built or last rebased.
+	CurrentReference string `json:"currentReference"`
+	CurrentTopLayer  string `json:"currentTopLayer"`
+
+	// Reference and top layer of the latest version of the run image.
+	LatestReference string `json:"latestReference"`
+	LatestTopLayer  string `json:"latestTopLayer"`
+
+	// Whether rebasing would change the run image layers of the app image.
+	RebaseAvailable bool `json:"rebaseAvailable"`
+
+	// Whether the app image allows being rebased.
+	Rebasable bool `json:"rebasable"`
+}
+
+// CheckRebase resolves the run image that Rebase would use for the image in opts and compares it
+// with the run image recorded on the app image, without modifying anything. The run image layers
+// are compared by top layer, so a mirror holding the same run image is considered up to date.
+// Only RepoName, Publish, PullPolicy, RunImage, AdditionalMirrors and PreviousImage are used.
+func (c *Client) CheckRebase(ctx context.Context, opts RebaseOptions) (RebaseCheck, error) {
+	images, err := c.fetchRebaseImages(ctx, opts, c.imageFetcher.Fetch)
+	if err != nil {
+		return RebaseCheck{}, err
+	}
+
+	rebasable, err := getRebasableLabel(images.app)
+	if err != nil {
+		return RebaseCheck{}, err
+	}
+
+	latestTopLayer, err := images.run.TopLayer()
+	if err != nil {
+		return RebaseCheck{}, errors.Wrapf(err, "getting top layer of run image %s", style.Symbol(images.run.Name()))
+	}
+
+	latestIdentifier, err := images.run.Identifier()
+	if err != nil {
+		return RebaseCheck{}, errors.Wrapf(err, "getting identifier of run image %s", style.Symbol(images.run.Name()))
+	}
+
+	current := images.metadata.RunImage
+	return RebaseCheck{
+		Image:            opts.RepoName,
+		RunImage:         images.run.Name(),
+		CurrentReference: current.Reference,
+		CurrentTopLayer:  current.TopLayer,
+		LatestReference:  latestIdentifier.String(),
+		LatestTopLayer:   latestTopLayer,
+		RebaseAvailable:  current.TopLayer != latestTopLayer,
+		Rebasable:        rebasable,
+	}, nil
+}
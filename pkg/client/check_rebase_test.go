@@ -0,0 +1,118 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestCheckRebase(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "check_rebase", testCheckRebase, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testCheckRebase(t *testing.T, when spec.G, it spec.S) {
+	var (
+		fetcher  *ifakes.FakeImageFetcher
+		subject  *Client
+		appImage *fakes.Image
+		runImage *fakes.Image
+		mirror   *fakes.Image
+		out      bytes.Buffer
+	)
+
+	it.Before(func() {
+		fetcher = ifakes.NewFakeImageFetcher()
+
+		appImage = fakes.NewImage("example.com/some/app", "", &fakeIdentifier{name: "app-digest"})
+		h.AssertNil(t, appImage.SetLabel("io.buildpacks.lifecycle.metadata",
+			`{"runImage":{"image":"some/run","mirrors":["example.com/some/run"],"topLayer":"old-top-layer-sha","reference":"some/run@sha256:old"}}`))
+		fetcher.RemoteImages["example.com/some/app"] = appImage
+
+		runImage = fakes.NewImage("some/run", "new-top-layer-sha", &fakeIdentifier{name: "some/run@sha256:new"})
+		fetcher.RemoteImages["some/run"] = runImage
+
+		mirror = fakes.NewImage("example.com/some/run", "new-top-layer-sha", &fakeIdentifier{name: "example.com/some/run@sha256:new"})
+		fetcher.RemoteImages["example.com/some/run"] = mirror
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fetcher,
+		}
+	})
+
+	it.After(func() {
+		h.AssertNilE(t, appImage.Cleanup())
+		h.AssertNilE(t, runImage.Cleanup())
+		h.AssertNilE(t, mirror.Cleanup())
+	})
+
+	when("#CheckRebase", func() {
+		it("reports a rebase when the best run image mirror has a different top layer", func() {
+			result, err := subject.CheckRebase(context.TODO(), RebaseOptions{
+				RepoName:   "example.com/some/app",
+				Publish:    true,
+				PullPolicy: image.PullAlways,
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, result, RebaseCheck{
+				Image:            "example.com/some/app",
+				RunImage:         "example.com/some/run",
+				CurrentReference: "some/run@sha256:old",
+				CurrentTopLayer:  "old-top-layer-sha",
+				LatestReference:  "example.com/some/run@sha256:new",
+				LatestTopLayer:   "new-top-layer-sha",
+				RebaseAvailable:  true,
+				Rebasable:        true,
+			})
+		})
+
+		it("reports no rebase when the image is based on the latest run image", func() {
+			h.AssertNil(t, appImage.SetLabel("io.buildpacks.lifecycle.metadata",
+				`{"runImage":{"image":"some/run","topLayer":"new-top-layer-sha","reference":"some/run@sha256:new"}}`))
+			h.AssertNil(t, appImage.SetLabel("io.buildpacks.rebasable", "false"))
+
+			result, err := subject.CheckRebase(context.TODO(), RebaseOptions{
+				RepoName:   "example.com/some/app",
+				Publish:    true,
+				PullPolicy: image.PullAlways,
+				RunImage:   "some/run",
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, result.RunImage, "some/run")
+			h.AssertFalse(t, result.RebaseAvailable)
+			h.AssertFalse(t, result.Rebasable)
+		})
+
+		it("does not modify the app image", func() {
+			_, err := subject.CheckRebase(context.TODO(), RebaseOptions{
+				RepoName:   "example.com/some/app",
+				Publish:    true,
+				PullPolicy: image.PullAlways,
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, appImage.Base(), "")
+			h.AssertFalse(t, appImage.IsSaved())
+		})
+
+		it("errors when the app image is missing", func() {
+			_, err := subject.CheckRebase(context.TODO(), RebaseOptions{
+				RepoName:   "example.com/some/missing",
+				Publish:    true,
+				PullPolicy: image.PullAlways,
+			})
+			h.AssertError(t, err, "image 'example.com/some/missing' does not exist in registry")
+		})
+	})
+}
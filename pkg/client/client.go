@@ -125,10 +125,12 @@ type Client struct {
 	downloader          BlobDownloader
 	lifecycleExecutor   LifecycleExecutor
 	buildpackDownloader BuildpackDownloader
+	registryResolver    *registryResolver
 
-	experimental    bool
-	registryMirrors map[string]string
-	version         string
+	experimental        bool
+	registryMirrors     map[string]string
+	registryMirrorRules []image.RegistryMirrorRule
+	version             string
 }
 
 // Option is a type of function that mutate settings on the client.
@@ -210,6 +212,14 @@ func WithRegistryMirrors(registryMirrors map[string]string) Option {
 	}
 }
 
+// WithRegistryMirrorRules sets ordered rules rewriting images to mirrors to pull them from.
+// The first rule matching an image is used, and takes precedence over WithRegistryMirrors.
+func WithRegistryMirrorRules(rules []image.RegistryMirrorRule) Option {
+	return func(c *Client) {
+		c.registryMirrorRules = rules
+	}
+}
+
 // WithKeychain sets keychain of credentials to image registries
 func WithKeychain(keychain authn.Keychain) Option {
 	return func(c *Client) {
@@ -254,7 +264,7 @@ func NewClient(opts ...Option) (*Client, error) {
 	}
 
 	if client.imageFetcher == nil {
-		client.imageFetcher = image.NewFetcher(client.logger, client.docker, image.WithRegistryMirrors(client.registryMirrors), image.WithKeychain(client.keychain))
+		client.imageFetcher = image.NewFetcher(client.logger, client.docker, image.WithRegistryMirrors(client.registryMirrors), image.WithRegistryMirrorRules(client.registryMirrorRules), image.WithKeychain(client.keychain))
 	}
 
 	if client.imageFactory == nil {
@@ -277,13 +287,14 @@ func NewClient(opts ...Option) (*Client, error) {
 	}
 
 	if client.buildpackDownloader == nil {
+		client.registryResolver = &registryResolver{
+			logger: client.logger,
+		}
 		client.buildpackDownloader = buildpack.NewDownloader(
 			client.logger,
 			client.imageFetcher,
 			client.downloader,
-			&registryResolver{
-				logger: client.logger,
-			},
+			client.registryResolver,
 		)
 	}
 
@@ -294,6 +305,9 @@ func NewClient(opts ...Option) (*Client, error) {
 
 type registryResolver struct {
 	logger logging.Logger
+
+	// deprecations of the resolved buildpacks that the registry marks as deprecated, by <id>@<version>
+	deprecations map[string]dist.Deprecation
 }
 
 func (r *registryResolver) Resolve(registryName, bpName string) (string, error) {
@@ -307,9 +321,27 @@ func (r *registryResolver) Resolve(registryName, bpName string) (string, error)
 		return "", errors.Wrapf(err, "lookup buildpack %s", style.Symbol(bpName))
 	}
 
+	if deprecation := regBuildpack.Deprecation(); deprecation != nil {
+		if r.deprecations == nil {
+			r.deprecations = map[string]dist.Deprecation{}
+		}
+		r.deprecations[regBuildpack.Namespace+"/"+regBuildpack.Name+"@"+regBuildpack.Version] = *deprecation
+	}
+
 	return regBuildpack.Address, nil
 }
 
+// Deprecation returns the deprecation notice the registry gave for a buildpack resolved earlier, or nil
+func (r *registryResolver) Deprecation(module dist.ModuleInfo) *dist.Deprecation {
+	if r == nil {
+		return nil
+	}
+	if deprecation, ok := r.deprecations[module.FullName()]; ok {
+		return &deprecation
+	}
+	return nil
+}
+
 type imageFactory struct {
 	dockerClient local.DockerClient
 	keychain     authn.Keychain
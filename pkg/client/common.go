@@ -77,32 +77,36 @@ func (c *Client) resolveRunImage(runImage, imgRegistry, bldrRegistry string, run
 	return runImageName
 }
 
-func getRegistry(logger logging.Logger, registryName string) (registry.Cache, error) {
+func getRegistry(logger logging.Logger, registryName string) (registry.Index, error) {
 	home, err := config.PackHome()
 	if err != nil {
-		return registry.Cache{}, err
+		return nil, err
 	}
 
 	if err := config.MkdirAll(home); err != nil {
-		return registry.Cache{}, err
+		return nil, err
 	}
 
 	cfg, err := getConfig()
 	if err != nil {
-		return registry.Cache{}, err
+		return nil, err
 	}
 
 	if registryName == "" {
-		return registry.NewDefaultRegistryCache(logger, home)
+		cache, err := registry.NewDefaultRegistryCache(logger, home)
+		if err != nil {
+			return nil, err
+		}
+		return &cache, nil
 	}
 
 	for _, reg := range config.GetRegistries(cfg) {
 		if reg.Name == registryName {
-			return registry.NewRegistryCache(logger, home, reg.URL)
+			return registry.NewIndex(logger, home, reg.Type, reg.URL)
 		}
 	}
 
-	return registry.Cache{}, fmt.Errorf("registry %s is not defined in your config file", style.Symbol(registryName))
+	return nil, fmt.Errorf("registry %s is not defined in your config file", style.Symbol(registryName))
 }
 
 func getConfig() (config.Config, error) {
@@ -54,6 +54,12 @@ type CreateBuilderOptions struct {
 
 	// Target platforms to build builder images for
 	Targets []dist.Target
+
+	// Pins the versions that version constraints in the builder config resolve to. Optional.
+	VersionLock *buildpack.VersionLock
+
+	// Fail instead of warning when the builder config uses deprecated buildpacks or extensions.
+	Strict bool
 }
 
 // CreateBuilder creates and saves a builder image to a registry with the provided options.
@@ -111,6 +117,7 @@ func (c *Client) createBuilderTarget(ctx context.Context, opts CreateBuilderOpti
 		return "", errors.Wrap(err, "failed to add extensions to builder")
 	}
 
+	bldr.SetVersionLock(opts.VersionLock)
 	bldr.SetOrder(opts.Config.Order)
 	bldr.SetOrderExtensions(opts.Config.OrderExtensions)
 
@@ -334,6 +341,7 @@ func (c *Client) addConfig(ctx context.Context, kind string, config pubbldr.Modu
 		RegistryName:    opts.Registry,
 		RelativeBaseDir: opts.RelativeBaseDir,
 		Target:          target,
+		VersionLock:     opts.VersionLock,
 	})
 	if err != nil {
 		return errors.Wrapf(err, "downloading %s", kind)
@@ -343,6 +351,10 @@ func (c *Client) addConfig(ctx context.Context, kind string, config pubbldr.Modu
 		return errors.Wrapf(err, "invalid %s", kind)
 	}
 
+	if err := c.checkDeprecations(kind, moduleInfos(append([]buildpack.BuildModule{mainBP}, depBPs...)), opts.Strict); err != nil {
+		return err
+	}
+
 	bpDesc := mainBP.Descriptor()
 	for _, deprecatedAPI := range bldr.LifecycleDescriptor().APIs.Buildpack.Deprecated {
 		if deprecatedAPI.Equal(bpDesc.API()) {
@@ -407,7 +419,17 @@ func validateModule(kind string, module buildpack.BuildModule, source, expectedI
 		)
 	}
 
-	if expectedVersion != "" && info.Version != expectedVersion {
+	if buildpack.IsVersionConstraint(expectedVersion) {
+		if _, err := buildpack.ResolveVersion(expectedVersion, []string{info.Version}); err != nil {
+			return fmt.Errorf(
+				"%s from URI %s has version %s which does not satisfy version %s from builder config",
+				kind,
+				style.Symbol(source),
+				style.Symbol(info.Version),
+				style.Symbol(expectedVersion),
+			)
+		}
+	} else if expectedVersion != "" && info.Version != expectedVersion {
 		return fmt.Errorf(
 			"%s from URI %s has version %s which does not match version %s from builder config",
 			kind,
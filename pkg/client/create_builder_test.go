@@ -791,6 +791,40 @@ func testCreateBuilder(t *testing.T, when spec.G, it spec.S) {
 				h.AssertNotContains(t, out.String(), "is using deprecated Buildpacks API version")
 			})
 
+			when("a buildpack is deprecated", func() {
+				it.Before(func() {
+					opts.Config.Buildpacks[0].URI = "https://example.fake/deprecated-bp-one.tgz"
+					deprecated := createBuildpack(dist.BuildpackDescriptor{
+						WithAPI: api.MustParse("0.4"),
+						WithInfo: dist.ModuleInfo{
+							ID:          "bp.one",
+							Version:     "1.2.3",
+							Deprecation: &dist.Deprecation{Message: "No longer maintained.", Replacement: "bp.two"},
+						},
+						WithStacks: []dist.Stack{{ID: "some.stack.id"}},
+					})
+					mockBuildpackDownloader.EXPECT().Download(gomock.Any(), "https://example.fake/deprecated-bp-one.tgz", gomock.Any()).Return(deprecated, nil, nil)
+				})
+
+				it("warns naming the replacement and records the deprecation", func() {
+					prepareFetcherWithBuildImage()
+					prepareFetcherWithRunImages()
+					bldr := successfullyCreateBuilder()
+
+					h.AssertContains(t, out.String(), "Buildpack 'bp.one@1.2.3' is deprecated: No longer maintained, use 'bp.two' instead")
+					h.AssertEq(t, bldr.Buildpacks()[0].Deprecation, &dist.Deprecation{Message: "No longer maintained.", Replacement: "bp.two"})
+				})
+
+				it("fails when strict", func() {
+					prepareFetcherWithBuildImage()
+					prepareFetcherWithRunImages()
+					opts.Strict = true
+
+					err := subject.CreateBuilder(context.TODO(), opts)
+					h.AssertError(t, err, "buildpack 'bp.one@1.2.3' is deprecated: No longer maintained, use 'bp.two' instead")
+				})
+			})
+
 			it("should set labels", func() {
 				opts.Labels = map[string]string{"test.label.one": "1", "test.label.two": "2"}
 				prepareFetcherWithBuildImage()
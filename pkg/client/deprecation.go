@@ -0,0 +1,40 @@
+package client
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// checkDeprecations warns about each deprecated module, or fails on the first one when strict is set. Modules are
+// deprecated by their descriptor or, for buildpacks resolved from a buildpack registry, by the registry.
+func (c *Client) checkDeprecations(kind string, modules []dist.ModuleInfo, strict bool) error {
+	for _, module := range modules {
+		deprecation := module.Deprecation
+		if deprecation == nil {
+			deprecation = c.registryResolver.Deprecation(module)
+		}
+		if deprecation == nil {
+			continue
+		}
+
+		if strict {
+			return errors.Errorf("%s %s %s", kind, style.Symbol(module.FullName()), deprecation.Notice())
+		}
+		c.logger.Warnf("%s %s %s", cases.Title(language.AmericanEnglish).String(kind), style.Symbol(module.FullName()), deprecation.Notice())
+	}
+	return nil
+}
+
+// moduleInfos returns the info of each module
+func moduleInfos(modules []buildpack.BuildModule) []dist.ModuleInfo {
+	var infos []dist.ModuleInfo
+	for _, module := range modules {
+		infos = append(infos, module.Descriptor().Info())
+	}
+	return infos
+}
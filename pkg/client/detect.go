@@ -0,0 +1,271 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/pkg/errors"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// DetectStatus is the outcome of detection for a group or module.
+type DetectStatus string
+
+const (
+	DetectStatusPass DetectStatus = "pass"
+	DetectStatusFail DetectStatus = "fail"
+
+	// DetectStatusNotParticipating is a module of the passing group, or an extension, that isn't in group.toml.
+	// It either failed detection or was left out when resolving the build plan, the detector doesn't record which.
+	DetectStatusNotParticipating DetectStatus = "not participating"
+	// DetectStatusUnknown is a module of a group that failed. The detector doesn't record the result of each module of such a group.
+	DetectStatusUnknown DetectStatus = "unknown"
+	// DetectStatusNotEvaluated is a group after the passing group, or a module in it, which the detector never runs.
+	DetectStatusNotEvaluated DetectStatus = "not evaluated"
+)
+
+// DetectOptions defines configuration settings for Detect.
+type DetectOptions struct {
+	BuildOptions
+
+	// Directory to write the group.toml and plan.toml produced by detection to.
+	// If unset, the files are written to a temporary directory that is removed afterwards.
+	DestinationDir string
+}
+
+// DetectModuleResult is the detection status of a single buildpack or extension.
+type DetectModuleResult struct {
+	dist.ModuleInfo
+
+	// Whether the module is optional in its group.
+	Optional bool
+
+	Status DetectStatus
+}
+
+// DetectGroupResult is the detection status of a group of the builder order
+// and of each buildpack in it, with composite buildpacks expanded.
+// The statuses are inferred from group.toml, only the buildpacks of the passing group that are in it are
+// known to have passed.
+type DetectGroupResult struct {
+	Status     DetectStatus
+	Buildpacks []DetectModuleResult
+}
+
+// DetectResult describes the outcome of running detection against an app.
+type DetectResult struct {
+	// Status of each group of the builder order, in the order they were evaluated, inferred from group.toml.
+	// Buildpacks of groups that failed are reported as DetectStatusUnknown and groups after the passing
+	// group as DetectStatusNotEvaluated, as the detector does not record anything else.
+	// Empty if the order of the builder was overridden by buildpacks or extensions.
+	Groups []DetectGroupResult
+
+	// Status of each extension of the builder.
+	Extensions []DetectModuleResult
+
+	// Buildpacks and extensions that passed detection, as written to group.toml.
+	Group buildpack.Group
+
+	// Resulting build plan, as written to plan.toml.
+	Plan files.Plan
+}
+
+// Detect runs only the detect phase of the lifecycle against an app, and reports which group
+// of the builder order passed along with the resulting build plan.
+func (c *Client) Detect(ctx context.Context, opts DetectOptions) (*DetectResult, error) {
+	if opts.Image == "" {
+		// detection doesn't produce an image, but the build configuration requires a name
+		opts.Image = fmt.Sprintf("pack.local/detect/%s:latest", randString(10))
+	}
+
+	outputDir := opts.DestinationDir
+	if outputDir == "" {
+		tmpDir, err := os.MkdirTemp("", "pack.detect")
+		if err != nil {
+			return nil, errors.Wrap(err, "creating temp dir")
+		}
+		defer os.RemoveAll(tmpDir)
+		outputDir = tmpDir
+	} else if err := os.MkdirAll(outputDir, 0750); err != nil {
+		return nil, errors.Wrapf(err, "creating destination dir %s", outputDir)
+	}
+
+	if err := c.build(ctx, opts.BuildOptions, outputDir); err != nil {
+		return nil, err
+	}
+
+	result := &DetectResult{}
+	if _, err := toml.DecodeFile(filepath.Join(outputDir, "group.toml"), &result.Group); err != nil {
+		return nil, errors.Wrap(err, "reading group.toml")
+	}
+
+	// plan.toml is only written if there are buildpacks with requirements
+	if _, err := toml.DecodeFile(filepath.Join(outputDir, "plan.toml"), &result.Plan); err != nil && !os.IsNotExist(err) {
+		return nil, errors.Wrap(err, "reading plan.toml")
+	}
+
+	if len(opts.Buildpacks) > 0 || len(opts.Extensions) > 0 {
+		return result, nil
+	}
+
+	info, err := c.InspectBuilder(opts.Builder, true, WithDetectionOrderDepth(pubbldr.OrderDetectionMaxDepth))
+	if err != nil {
+		return nil, errors.Wrapf(err, "inspecting builder %s", opts.Builder)
+	}
+	if info == nil {
+		return result, nil
+	}
+
+	result.Groups = detectGroupStatuses(info.Order, result.Group.Group)
+	result.Extensions = detectExtensionStatuses(info.OrderExtensions, result.Group.GroupExtensions)
+
+	return result, nil
+}
+
+// detectGroupStatuses infers the status of each group of the order, and of the buildpacks within them,
+// from the buildpacks that passed detection. The first group that is satisfied by the passing buildpacks
+// is considered to have passed, all groups before it to have failed, and all groups after it to not have been
+// evaluated. Only the passing buildpacks of the passing group are known, the others are reported as such.
+func detectGroupStatuses(order pubbldr.DetectionOrder, passing []buildpack.GroupElement) []DetectGroupResult {
+	passed := map[string]bool{}
+	for _, el := range passing {
+		passed[moduleKey(el.ID, el.Version)] = true
+	}
+
+	var (
+		results    []DetectGroupResult
+		foundGroup bool
+	)
+	for _, entry := range order {
+		group := DetectGroupResult{Status: DetectStatusNotEvaluated}
+		collectDetectModules(entry.GroupDetectionOrder, false, map[string]bool{}, &group.Buildpacks)
+
+		if !foundGroup {
+			group.Status = DetectStatusFail
+			if len(passing) > 0 && containsAll(group.Buildpacks, passed) && groupSatisfied(entry.GroupDetectionOrder, passed) {
+				group.Status = DetectStatusPass
+				foundGroup = true
+			}
+		}
+
+		for i := range group.Buildpacks {
+			switch {
+			case group.Status == DetectStatusNotEvaluated:
+				group.Buildpacks[i].Status = DetectStatusNotEvaluated
+			case group.Status == DetectStatusFail:
+				group.Buildpacks[i].Status = DetectStatusUnknown
+			case passed[moduleKey(group.Buildpacks[i].ID, group.Buildpacks[i].Version)]:
+				group.Buildpacks[i].Status = DetectStatusPass
+			default:
+				group.Buildpacks[i].Status = DetectStatusNotParticipating
+			}
+		}
+
+		results = append(results, group)
+	}
+
+	return results
+}
+
+// detectExtensionStatuses returns the status of each extension of the order given the extensions that passed detection.
+// Extensions that aren't in group.toml are reported as not participating, the detector doesn't record whether they ran.
+func detectExtensionStatuses(order pubbldr.DetectionOrder, passing []buildpack.GroupElement) []DetectModuleResult {
+	passed := map[string]bool{}
+	for _, el := range passing {
+		passed[moduleKey(el.ID, el.Version)] = true
+	}
+
+	var extensions []DetectModuleResult
+	seen := map[string]bool{}
+	for _, entry := range order {
+		if entry.ID != "" {
+			collectDetectModules(pubbldr.DetectionOrder{entry}, true, seen, &extensions)
+		}
+		collectDetectModules(entry.GroupDetectionOrder, true, seen, &extensions)
+	}
+
+	for i := range extensions {
+		extensions[i].Status = DetectStatusNotParticipating
+		if passed[moduleKey(extensions[i].ID, extensions[i].Version)] {
+			extensions[i].Status = DetectStatusPass
+		}
+	}
+
+	return extensions
+}
+
+// collectDetectModules appends the buildpacks of the group to modules, expanding composite buildpacks
+func collectDetectModules(group pubbldr.DetectionOrder, optional bool, seen map[string]bool, modules *[]DetectModuleResult) {
+	for _, entry := range group {
+		if len(entry.GroupDetectionOrder) > 0 {
+			collectDetectModules(entry.GroupDetectionOrder, optional || entry.Optional, seen, modules)
+			continue
+		}
+
+		key := moduleKey(entry.ID, entry.Version)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		*modules = append(*modules, DetectModuleResult{
+			ModuleInfo: entry.ModuleInfo,
+			Optional:   optional || entry.Optional,
+		})
+	}
+}
+
+// groupSatisfied returns true if every required buildpack of the group passed. A required composite
+// buildpack is satisfied if any of the groups of its order is satisfied.
+func groupSatisfied(group pubbldr.DetectionOrder, passed map[string]bool) bool {
+	composites := map[string]bool{}
+	var required []string
+	for _, entry := range group {
+		key := moduleKey(entry.ID, entry.Version)
+		if len(entry.GroupDetectionOrder) == 0 {
+			if !entry.Optional && !passed[key] {
+				return false
+			}
+			continue
+		}
+
+		if _, ok := composites[key]; !ok && !entry.Optional {
+			required = append(required, key)
+		}
+		composites[key] = composites[key] || groupSatisfied(entry.GroupDetectionOrder, passed)
+	}
+
+	for _, key := range required {
+		if !composites[key] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsAll(modules []DetectModuleResult, keys map[string]bool) bool {
+	found := map[string]bool{}
+	for _, m := range modules {
+		found[moduleKey(m.ID, m.Version)] = true
+	}
+
+	for key := range keys {
+		if !found[key] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func moduleKey(id, version string) string {
+	return id + "@" + version
+}
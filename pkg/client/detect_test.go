@@ -0,0 +1,116 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/buildpacks/lifecycle/buildpack"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	pubbldr "github.com/buildpacks/pack/builder"
+	"github.com/buildpacks/pack/pkg/dist"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestDetect(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "Detect", testDetect, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testDetect(t *testing.T, when spec.G, it spec.S) {
+	var (
+		bpA = dist.ModuleInfo{ID: "some/bp-a", Version: "1.0.0"}
+		bpB = dist.ModuleInfo{ID: "some/bp-b", Version: "2.0.0"}
+		bpC = dist.ModuleInfo{ID: "some/bp-c", Version: "3.0.0"}
+
+		composite = dist.ModuleInfo{ID: "some/composite", Version: "0.1.0"}
+
+		entry = func(info dist.ModuleInfo, optional bool) pubbldr.DetectionOrderEntry {
+			return pubbldr.DetectionOrderEntry{ModuleRef: dist.ModuleRef{ModuleInfo: info, Optional: optional}}
+		}
+		group = func(entries ...pubbldr.DetectionOrderEntry) pubbldr.DetectionOrderEntry {
+			return pubbldr.DetectionOrderEntry{GroupDetectionOrder: entries}
+		}
+		passing = func(infos ...dist.ModuleInfo) []buildpack.GroupElement {
+			var elements []buildpack.GroupElement
+			for _, info := range infos {
+				elements = append(elements, buildpack.GroupElement{ID: info.ID, Version: info.Version})
+			}
+			return elements
+		}
+		statuses = func(group DetectGroupResult) []DetectStatus {
+			var result []DetectStatus
+			for _, bp := range group.Buildpacks {
+				result = append(result, bp.Status)
+			}
+			return result
+		}
+	)
+
+	when("#detectGroupStatuses", func() {
+		it("marks the first satisfied group as passed, earlier groups as failed and later groups as not evaluated", func() {
+			order := pubbldr.DetectionOrder{
+				group(entry(bpA, false), entry(bpB, false)),
+				group(entry(bpA, false), entry(bpC, true)),
+				group(entry(bpA, false)),
+			}
+
+			groups := detectGroupStatuses(order, passing(bpA))
+
+			h.AssertEq(t, len(groups), 3)
+			h.AssertEq(t, groups[0].Status, DetectStatusFail)
+			h.AssertEq(t, statuses(groups[0]), []DetectStatus{DetectStatusUnknown, DetectStatusUnknown})
+			h.AssertEq(t, groups[1].Status, DetectStatusPass)
+			h.AssertEq(t, statuses(groups[1]), []DetectStatus{DetectStatusPass, DetectStatusNotParticipating})
+			h.AssertEq(t, groups[1].Buildpacks[1].Optional, true)
+			h.AssertEq(t, groups[2].Status, DetectStatusNotEvaluated)
+			h.AssertEq(t, statuses(groups[2]), []DetectStatus{DetectStatusNotEvaluated})
+		})
+
+		it("expands composite buildpacks", func() {
+			order := pubbldr.DetectionOrder{
+				group(
+					pubbldr.DetectionOrderEntry{
+						ModuleRef:           dist.ModuleRef{ModuleInfo: composite},
+						GroupDetectionOrder: pubbldr.DetectionOrder{entry(bpA, false), entry(bpB, false)},
+					},
+					pubbldr.DetectionOrderEntry{
+						ModuleRef:           dist.ModuleRef{ModuleInfo: composite},
+						GroupDetectionOrder: pubbldr.DetectionOrder{entry(bpC, false)},
+					},
+				),
+			}
+
+			groups := detectGroupStatuses(order, passing(bpC))
+
+			h.AssertEq(t, len(groups), 1)
+			h.AssertEq(t, groups[0].Status, DetectStatusPass)
+			h.AssertEq(t, len(groups[0].Buildpacks), 3)
+			h.AssertEq(t, statuses(groups[0]), []DetectStatus{DetectStatusNotParticipating, DetectStatusNotParticipating, DetectStatusPass})
+		})
+
+		it("fails every group when nothing passed", func() {
+			order := pubbldr.DetectionOrder{group(entry(bpA, true))}
+
+			groups := detectGroupStatuses(order, nil)
+
+			h.AssertEq(t, groups[0].Status, DetectStatusFail)
+			h.AssertEq(t, statuses(groups[0]), []DetectStatus{DetectStatusUnknown})
+		})
+	})
+
+	when("#detectExtensionStatuses", func() {
+		it("marks passing extensions", func() {
+			order := pubbldr.DetectionOrder{entry(bpA, false), entry(bpB, false)}
+
+			extensions := detectExtensionStatuses(order, passing(bpB))
+
+			h.AssertEq(t, len(extensions), 2)
+			h.AssertEq(t, extensions[0].Status, DetectStatusNotParticipating)
+			h.AssertEq(t, extensions[0].Optional, true)
+			h.AssertEq(t, extensions[1].Status, DetectStatusPass)
+		})
+	})
+}
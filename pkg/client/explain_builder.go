@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// ExplainBuilderOptions are the options for simulating detection against the order of a builder.
+type ExplainBuilderOptions struct {
+	// Name of the builder image.
+	BuilderName string
+
+	// Strategy for pulling the builder image.
+	PullPolicy image.PullPolicy
+
+	// Assumed outcome of running detect for each buildpack.
+	Outcomes builder.DetectOutcomes
+}
+
+// ExplainBuilder statically evaluates the order of a builder against assumed detect outcomes, without running
+// any containers. It reports which group would be chosen and why the groups before it failed.
+func (c *Client) ExplainBuilder(ctx context.Context, opts ExplainBuilderOptions) (*builder.DetectionSimulation, error) {
+	if err := opts.Outcomes.Validate(); err != nil {
+		return nil, err
+	}
+
+	img, err := c.imageFetcher.Fetch(ctx, opts.BuilderName, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return nil, fmt.Errorf("fetching builder image: %w", err)
+	}
+
+	bldr, err := builder.FromImage(img)
+	if err != nil {
+		return nil, fmt.Errorf("reading builder metadata: %w", err)
+	}
+
+	layers := dist.ModuleLayers{}
+	if _, err := dist.GetLabel(img, dist.BuildpackLayersLabel, &layers); err != nil {
+		return nil, fmt.Errorf("reading image buildpack layers: %w", err)
+	}
+
+	simulation := builder.SimulateDetection(bldr.Order(), layers, opts.Outcomes)
+	return &simulation, nil
+}
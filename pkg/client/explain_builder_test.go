@@ -0,0 +1,89 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExplainBuilder(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ExplainBuilder", testExplainBuilder, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testExplainBuilder(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *Client
+		mockImageFetcher *testmocks.MockImageFetcher
+		mockController   *gomock.Controller
+		builderImage     *fakes.Image
+		out              bytes.Buffer
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: mockImageFetcher,
+		}
+
+		builderImage = fakes.NewImage("some/builder", "", nil)
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.stack.id", "test.stack.id"))
+		h.AssertNil(t, builderImage.SetEnv("CNB_USER_ID", "1234"))
+		h.AssertNil(t, builderImage.SetEnv("CNB_GROUP_ID", "4321"))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.builder.metadata", `{"lifecycle": {"version": "1.2.3"}}`))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.buildpack.order", `[
+  {"group": [{"id": "some/composite", "version": "1.0.0"}]},
+  {"group": [{"id": "some/bp-c", "version": "1.0.0"}]}
+]`))
+		h.AssertNil(t, builderImage.SetLabel("io.buildpacks.buildpack.layers", `{
+  "some/composite": {"1.0.0": {"api": "0.10", "order": [{"group": [{"id": "some/bp-a", "version": "1.0.0"}, {"id": "some/bp-b", "version": "1.0.0", "optional": true}]}], "layerDiffID": "sha256:composite"}},
+  "some/bp-a": {"1.0.0": {"api": "0.10", "layerDiffID": "sha256:a"}},
+  "some/bp-b": {"1.0.0": {"api": "0.10", "layerDiffID": "sha256:b"}},
+  "some/bp-c": {"1.0.0": {"api": "0.10", "layerDiffID": "sha256:c"}}
+}`))
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	it("simulates detection against the order of the builder", func() {
+		mockImageFetcher.EXPECT().Fetch(gomock.Any(), "some/builder", image.FetchOptions{Daemon: true, PullPolicy: image.PullIfNotPresent}).Return(builderImage, nil)
+
+		simulation, err := subject.ExplainBuilder(context.TODO(), ExplainBuilderOptions{
+			BuilderName: "some/builder",
+			PullPolicy:  image.PullIfNotPresent,
+			Outcomes:    builder.DetectOutcomes{Pass: []string{"some/bp-a"}},
+		})
+		h.AssertNil(t, err)
+
+		h.AssertEq(t, simulation.Chosen, 0)
+		h.AssertEq(t, len(simulation.Result), 1)
+		h.AssertEq(t, simulation.Result[0].FullName(), "some/bp-a@1.0.0")
+		h.AssertEq(t, simulation.Groups[1].Skipped, true)
+	})
+
+	it("errors on an invalid default outcome", func() {
+		_, err := subject.ExplainBuilder(context.TODO(), ExplainBuilderOptions{
+			BuilderName: "some/builder",
+			Outcomes:    builder.DetectOutcomes{Default: "maybe"},
+		})
+		h.AssertError(t, err, "invalid default outcome")
+	})
+}
@@ -0,0 +1,278 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// ModuleFile describes a file in the layer of a buildpack module.
+type ModuleFile struct {
+	Path     string
+	Mode     fs.FileMode
+	Size     int64
+	Linkname string
+}
+
+// ModuleFiles lists the files in the layer of a buildpack module.
+type ModuleFiles struct {
+	Module dist.ModuleInfo
+	Files  []ModuleFile
+}
+
+// ExtractBuildpackOptions is a configuration object used to define the behavior of ExtractBuildpack.
+type ExtractBuildpackOptions struct {
+	// A buildpack package file, OCI layout, image or registry buildpack.
+	BuildpackName string
+
+	// Directory to write the buildpacks to, as <Destination>/<escaped id>/<version>.
+	Destination string
+
+	// Whether to look for the image in the docker daemon.
+	Daemon bool
+
+	// Name of the buildpack registry to look up registry buildpacks in.
+	Registry string
+}
+
+// ExtractBuildpack writes the contents of each buildpack in a buildpack package into its own directory under the
+// destination, and returns the buildpacks that were extracted.
+func (c *Client) ExtractBuildpack(ctx context.Context, opts ExtractBuildpackOptions) ([]dist.ModuleInfo, error) {
+	if opts.Destination == "" {
+		return nil, errors.New("destination must be provided")
+	}
+
+	modules, err := c.buildpackModules(ctx, opts.BuildpackName, opts.Registry, opts.Daemon)
+	if err != nil {
+		return nil, err
+	}
+
+	var extracted []dist.ModuleInfo
+	for _, module := range modules {
+		info := module.Descriptor().Info()
+		moduleDir := filepath.Join(opts.Destination, module.Descriptor().EscapedID(), info.Version)
+		if err := os.MkdirAll(moduleDir, 0755); err != nil {
+			return nil, errors.Wrapf(err, "creating directory for %s", style.Symbol(info.FullName()))
+		}
+
+		if err := extractModule(module, opts.Destination); err != nil {
+			return nil, errors.Wrapf(err, "extracting %s", style.Symbol(info.FullName()))
+		}
+		extracted = append(extracted, info)
+	}
+	return extracted, nil
+}
+
+// buildpackModules returns the buildpacks stored in a buildpack package file, OCI layout, image or registry buildpack,
+// sorted by id and version.
+func (c *Client) buildpackModules(ctx context.Context, name, registry string, daemon bool) ([]buildpack.BuildModule, error) {
+	locatorType, err := buildpack.GetLocatorType(name, "", []dist.ModuleInfo{})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mainBP buildpack.BuildModule
+		deps   []buildpack.BuildModule
+	)
+	switch locatorType {
+	case buildpack.RegistryLocator:
+		registryCache, err := getRegistry(c.logger, registry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid registry %s: %q", registry, err)
+		}
+		registryBp, err := registryCache.LocateBuildpack(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find %s in registry: %q", style.Symbol(name), err)
+		}
+		mainBP, deps, err = c.buildpackModulesFromImage(ctx, registryBp.Address, false)
+		if err != nil {
+			return nil, err
+		}
+	case buildpack.PackageLocator:
+		mainBP, deps, err = c.buildpackModulesFromImage(ctx, buildpack.ParsePackageLocator(name), daemon)
+		if err != nil {
+			return nil, err
+		}
+	case buildpack.URILocator:
+		imgBlob, err := c.downloader.Download(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to download archive: %q", err)
+		}
+		mainBP, deps, err = buildpack.BuildpacksFromOCILayoutBlob(imgBlob)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading buildpacks from %s", style.Symbol(name))
+		}
+	default:
+		return nil, fmt.Errorf("unable to handle locator %q: for buildpack %q", locatorType, name)
+	}
+
+	modules := deps
+	if mainBP != nil {
+		modules = append([]buildpack.BuildModule{mainBP}, deps...)
+	}
+	sort.Slice(modules, func(i, j int) bool {
+		a, b := modules[i].Descriptor().Info(), modules[j].Descriptor().Info()
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		return a.Version < b.Version
+	})
+	return modules, nil
+}
+
+func (c *Client) buildpackModulesFromImage(ctx context.Context, name string, daemon bool) (buildpack.BuildModule, []buildpack.BuildModule, error) {
+	img, err := c.imageFetcher.Fetch(ctx, name, image.FetchOptions{Daemon: daemon, PullPolicy: image.PullNever})
+	if err != nil {
+		return nil, nil, err
+	}
+	return buildpack.BuildpacksFromPackage(img)
+}
+
+// moduleFiles lists the files in the layer of each module. Files outside of the directory of the module are
+// included, which happens for flattened buildpacks where a single layer holds several modules.
+func moduleFiles(modules []buildpack.BuildModule) ([]ModuleFiles, error) {
+	var result []ModuleFiles
+	for _, module := range modules {
+		info := module.Descriptor().Info()
+		entry := ModuleFiles{Module: info}
+		err := walkModule(module, func(header *tar.Header, _ io.Reader) error {
+			entry.Files = append(entry.Files, ModuleFile{
+				Path:     path.Clean("/" + header.Name),
+				Mode:     header.FileInfo().Mode(),
+				Size:     header.Size,
+				Linkname: header.Linkname,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing files of %s", style.Symbol(info.FullName()))
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+func walkModule(module buildpack.BuildModule, fn func(header *tar.Header, r io.Reader) error) error {
+	rc, err := module.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to get next tar entry")
+		}
+		if err := fn(header, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// extractModule writes the files of a module layer into dest, relative to the buildpacks directory of the layer, so
+// each module ends up in <dest>/<escaped id>/<version>. The layer of a flattened buildpack holds several modules,
+// which are all written. Entries outside of the buildpacks directory are skipped. Symlinks must point within dest
+// and nothing is written through a symlink, so a package can't write files outside of dest.
+func extractModule(module buildpack.BuildModule, dest string) error {
+	relPath := func(name string) (string, bool) {
+		name = path.Clean("/" + name)
+		if !strings.HasPrefix(name, dist.BuildpacksDir+"/") {
+			return "", false
+		}
+		return filepath.FromSlash(strings.TrimPrefix(name, dist.BuildpacksDir+"/")), true
+	}
+
+	return walkModule(module, func(header *tar.Header, r io.Reader) error {
+		rel, ok := relPath(header.Name)
+		if !ok {
+			return nil
+		}
+		target, err := extractPath(dest, rel)
+		if err != nil {
+			return errors.Wrapf(err, "extracting %s", style.Symbol(header.Name))
+		}
+		mode := header.FileInfo().Mode()
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			return os.MkdirAll(target, mode.Perm()|0700)
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			_, err = io.Copy(f, r)
+			return err
+		case tar.TypeSymlink:
+			linkname := filepath.FromSlash(header.Linkname)
+			if filepath.IsAbs(linkname) || path.IsAbs(header.Linkname) || !withinDir(dest, filepath.Join(filepath.Dir(target), linkname)) {
+				return errors.Errorf("symlink %s points to %s, outside of the destination directory", style.Symbol(header.Name), style.Symbol(header.Linkname))
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			return os.Symlink(linkname, target)
+		case tar.TypeLink:
+			linkRel, ok := relPath(header.Linkname)
+			if !ok {
+				return nil
+			}
+			source, err := extractPath(dest, linkRel)
+			if err != nil {
+				return errors.Wrapf(err, "extracting hard link %s", style.Symbol(header.Name))
+			}
+			return os.Link(source, target)
+		}
+		return nil
+	})
+}
+
+// extractPath returns the path of rel within dest, making sure that none of the existing elements of the path,
+// from dest down, is a symlink.
+func extractPath(dest, rel string) (string, error) {
+	current := dest
+	for _, elem := range strings.Split(rel, string(filepath.Separator)) {
+		current = filepath.Join(current, elem)
+		fi, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			return "", errors.Errorf("path %s is a symlink", style.Symbol(current))
+		}
+	}
+	return filepath.Join(dest, rel), nil
+}
+
+// withinDir returns whether target, once cleaned, is dir or a path beneath it.
+func withinDir(dir, target string) bool {
+	rel, err := filepath.Rel(dir, target)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,240 @@
+package client_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/golang/mock/gomock"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/client"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/testmocks"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestExtractBuildpack(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ExtractBuildpack", testExtractBuildpack, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testExtractBuildpack(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject          *client.Client
+		mockController   *gomock.Controller
+		mockImageFetcher *testmocks.MockImageFetcher
+		out              bytes.Buffer
+		tmpDir           string
+	)
+
+	it.Before(func() {
+		mockController = gomock.NewController(t)
+		mockImageFetcher = testmocks.NewMockImageFetcher(mockController)
+
+		subject = &client.Client{}
+		client.WithLogger(logging.NewLogWithWriters(&out, &out))(subject)
+		client.WithFetcher(mockImageFetcher)(subject)
+
+		tmpDir = t.TempDir()
+
+		bpImage := fakes.NewImage("some/package", "", nil)
+		h.AssertNil(t, bpImage.SetLabel(buildpack.MetadataLabel, `{"id": "some/bp", "version": "1.0.0"}`))
+		h.AssertNil(t, bpImage.SetLabel(dist.BuildpackLayersLabel, `{
+  "some/bp": {"1.0.0": {"api": "0.10", "layerDiffID": "sha256:bp-diff-id"}},
+  "some/dep": {"2.0.0": {"api": "0.10", "layerDiffID": "sha256:dep-diff-id"}}
+}`))
+
+		bpLayer := writeModuleLayer(t, tmpDir, "bp.tar", map[string]int64{
+			"/cnb/buildpacks/some_bp/1.0.0/bin/build":      0755,
+			"/cnb/buildpacks/some_bp/1.0.0/buildpack.toml": 0644,
+		})
+		h.AssertNil(t, bpImage.AddLayerWithDiffID(bpLayer, "sha256:bp-diff-id"))
+		depLayer := writeModuleLayer(t, tmpDir, "dep.tar", map[string]int64{
+			"/cnb/buildpacks/some_dep/2.0.0/bin/detect": 0755,
+		})
+		h.AssertNil(t, bpImage.AddLayerWithDiffID(depLayer, "sha256:dep-diff-id"))
+
+		mockImageFetcher.EXPECT().
+			Fetch(gomock.Any(), "some/package", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+			Return(bpImage, nil).AnyTimes()
+	})
+
+	it.After(func() {
+		mockController.Finish()
+	})
+
+	when("#ExtractBuildpack", func() {
+		it("writes each buildpack into its own directory", func() {
+			dest := filepath.Join(tmpDir, "out")
+			extracted, err := subject.ExtractBuildpack(context.TODO(), client.ExtractBuildpackOptions{
+				BuildpackName: "docker://some/package",
+				Destination:   dest,
+				Daemon:        true,
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, extracted, []dist.ModuleInfo{{ID: "some/bp", Version: "1.0.0"}, {ID: "some/dep", Version: "2.0.0"}})
+
+			h.AssertPathExists(t, filepath.Join(dest, "some_bp", "1.0.0", "buildpack.toml"))
+			h.AssertPathExists(t, filepath.Join(dest, "some_dep", "2.0.0", "bin", "detect"))
+
+			if runtime.GOOS != "windows" {
+				fi, err := os.Stat(filepath.Join(dest, "some_bp", "1.0.0", "bin", "build"))
+				h.AssertNil(t, err)
+				h.AssertEq(t, fi.Mode().Perm(), os.FileMode(0755))
+			}
+		})
+
+		it("requires a destination", func() {
+			_, err := subject.ExtractBuildpack(context.TODO(), client.ExtractBuildpackOptions{BuildpackName: "docker://some/package"})
+			h.AssertError(t, err, "destination must be provided")
+		})
+
+		when("the package has entries that would write outside of the destination", func() {
+			var (
+				outside string
+				extract = func(entries ...*tar.Header) error {
+					hostileImage := fakes.NewImage("some/hostile", "", nil)
+					h.AssertNil(t, hostileImage.SetLabel(buildpack.MetadataLabel, `{"id": "some/hostile", "version": "1.0.0"}`))
+					h.AssertNil(t, hostileImage.SetLabel(dist.BuildpackLayersLabel, `{
+  "some/hostile": {"1.0.0": {"api": "0.10", "layerDiffID": "sha256:hostile-diff-id"}}
+}`))
+					h.AssertNil(t, hostileImage.AddLayerWithDiffID(writeTarEntries(t, tmpDir, "hostile.tar", entries), "sha256:hostile-diff-id"))
+					mockImageFetcher.EXPECT().
+						Fetch(gomock.Any(), "some/hostile", image.FetchOptions{Daemon: true, PullPolicy: image.PullNever}).
+						Return(hostileImage, nil)
+
+					_, err := subject.ExtractBuildpack(context.TODO(), client.ExtractBuildpackOptions{
+						BuildpackName: "docker://some/hostile",
+						Destination:   filepath.Join(tmpDir, "out"),
+						Daemon:        true,
+					})
+					return err
+				}
+			)
+
+			it.Before(func() {
+				h.SkipIf(t, runtime.GOOS == "windows", "symlinks require privileges on windows")
+				outside = filepath.Join(tmpDir, "outside")
+				h.AssertNil(t, os.MkdirAll(outside, 0755))
+			})
+
+			it("rejects symlinks to absolute paths", func() {
+				err := extract(
+					&tar.Header{Typeflag: tar.TypeSymlink, Name: "/cnb/buildpacks/some_hostile/1.0.0/x", Linkname: outside},
+					&tar.Header{Typeflag: tar.TypeReg, Name: "/cnb/buildpacks/some_hostile/1.0.0/x/foo", Mode: 0644},
+				)
+				h.AssertError(t, err, "symlink '/cnb/buildpacks/some_hostile/1.0.0/x' points to")
+				h.AssertPathDoesNotExists(t, filepath.Join(outside, "foo"))
+			})
+
+			it("rejects symlinks that resolve outside of the destination", func() {
+				err := extract(
+					&tar.Header{Typeflag: tar.TypeSymlink, Name: "/cnb/buildpacks/some_hostile/1.0.0/x", Linkname: "../../../outside"},
+					&tar.Header{Typeflag: tar.TypeReg, Name: "/cnb/buildpacks/some_hostile/1.0.0/x/foo", Mode: 0644},
+				)
+				h.AssertError(t, err, "outside of the destination directory")
+				h.AssertPathDoesNotExists(t, filepath.Join(outside, "foo"))
+			})
+
+			it("doesn't write files through symlinks", func() {
+				err := extract(
+					&tar.Header{Typeflag: tar.TypeDir, Name: "/cnb/buildpacks/some_hostile/1.0.0/dir", Mode: 0755},
+					&tar.Header{Typeflag: tar.TypeSymlink, Name: "/cnb/buildpacks/some_hostile/1.0.0/x", Linkname: "dir"},
+					&tar.Header{Typeflag: tar.TypeReg, Name: "/cnb/buildpacks/some_hostile/1.0.0/x/foo", Mode: 0644},
+				)
+				h.AssertError(t, err, "is a symlink")
+				h.AssertPathDoesNotExists(t, filepath.Join(tmpDir, "out", "some_hostile", "1.0.0", "dir", "foo"))
+			})
+
+			it("doesn't hard link through symlinks", func() {
+				err := extract(
+					&tar.Header{Typeflag: tar.TypeSymlink, Name: "/cnb/buildpacks/some_hostile/1.0.0/x", Linkname: "."},
+					&tar.Header{Typeflag: tar.TypeLink, Name: "/cnb/buildpacks/some_hostile/1.0.0/link", Linkname: "/cnb/buildpacks/some_hostile/1.0.0/x/foo"},
+				)
+				h.AssertError(t, err, "extracting hard link")
+			})
+		})
+	})
+
+	when("#InspectBuildpack with files", func() {
+		it("lists the files of each buildpack", func() {
+			info, err := subject.InspectBuildpack(client.InspectBuildpackOptions{
+				BuildpackName: "docker://some/package",
+				Daemon:        true,
+				Files:         true,
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(info.Files), 2)
+			h.AssertEq(t, info.Files[0].Module.FullName(), "some/bp@1.0.0")
+			h.AssertEq(t, info.Files[0].Files, []client.ModuleFile{
+				{Path: "/cnb/buildpacks/some_bp/1.0.0/bin/build", Mode: 0755, Size: 7},
+				{Path: "/cnb/buildpacks/some_bp/1.0.0/buildpack.toml", Mode: 0644, Size: 7},
+			})
+			h.AssertEq(t, info.Files[1].Module.FullName(), "some/dep@2.0.0")
+		})
+	})
+}
+
+// writeModuleLayer writes a layer with a file per path, each with the given mode and "content" as contents
+func writeModuleLayer(t *testing.T, dir, name string, files map[string]int64) string {
+	t.Helper()
+
+	layerPath := filepath.Join(dir, name)
+	f, err := os.Create(layerPath)
+	h.AssertNil(t, err)
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	defer tw.Close()
+
+	var paths []string
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		h.AssertNil(t, tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: path, Mode: files[path], Size: int64(len("content"))}))
+		_, err := tw.Write([]byte("content"))
+		h.AssertNil(t, err)
+	}
+	return layerPath
+}
+
+// writeTarEntries writes a layer with the given entries, regular files have "content" as contents
+func writeTarEntries(t *testing.T, dir, name string, entries []*tar.Header) string {
+	t.Helper()
+
+	layerPath := filepath.Join(dir, name)
+	f, err := os.Create(layerPath)
+	h.AssertNil(t, err)
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+	defer tw.Close()
+
+	for _, entry := range entries {
+		if entry.Typeflag == tar.TypeReg {
+			entry.Size = int64(len("content"))
+		}
+		h.AssertNil(t, tw.WriteHeader(entry))
+		if entry.Typeflag == tar.TypeReg {
+			_, err := tw.Write([]byte("content"))
+			h.AssertNil(t, err)
+		}
+	}
+	return layerPath
+}
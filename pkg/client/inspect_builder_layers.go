@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// BuilderLayersInfo describes the layers of a builder and what contributed them.
+type BuilderLayersInfo struct {
+	// Layers of the builder image, ordered from the bottom layer to the top layer.
+	Layers []builder.LayerInfo
+
+	// Estimated effect of flattening each of the requested groups of modules. The bytes saved are approximated
+	// from the number of layers removed, they are not computed from the layer contents.
+	FlattenEstimates []builder.FlattenEstimate
+}
+
+// InspectBuilderLayersOptions are the options for inspecting the layers of a builder.
+type InspectBuilderLayersOptions struct {
+	// Whether to inspect the builder image in the daemon rather than in the registry.
+	Daemon bool
+
+	// Groups of modules to estimate flattening savings for.
+	Flatten buildpack.FlattenModuleInfos
+}
+
+// InspectBuilderLayers attributes each layer of a local or remote builder image to the entity that contributed it,
+// such as the base image, the lifecycle or a module. It returns nil if the builder image cannot be found.
+func (c *Client) InspectBuilderLayers(name string, opts InspectBuilderLayersOptions) (*BuilderLayersInfo, error) {
+	img, err := c.imageFetcher.Fetch(context.Background(), name, image.FetchOptions{Daemon: opts.Daemon, PullPolicy: image.PullNever})
+	if err != nil {
+		if errors.Is(err, image.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "fetching builder image")
+	}
+
+	bpLayers := dist.ModuleLayers{}
+	if _, err := dist.GetLabel(img, dist.BuildpackLayersLabel, &bpLayers); err != nil {
+		return nil, errors.Wrap(err, "reading image buildpack layers")
+	}
+
+	extLayers := dist.ModuleLayers{}
+	if _, err := dist.GetLabel(img, dist.ExtensionLayersLabel, &extLayers); err != nil {
+		return nil, errors.Wrap(err, "reading image extension layers")
+	}
+
+	layers, err := builder.AnalyzeLayers(img.UnderlyingImage(), bpLayers, extLayers)
+	if err != nil {
+		return nil, errors.Wrap(err, "analyzing builder layers")
+	}
+
+	return &BuilderLayersInfo{
+		Layers:           layers,
+		FlattenEstimates: builder.EstimateFlatten(layers, opts.Flatten),
+	}, nil
+}
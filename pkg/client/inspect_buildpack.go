@@ -19,12 +19,15 @@ type BuildpackInfo struct {
 	Order             dist.Order
 	BuildpackLayers   dist.ModuleLayers
 	Location          buildpack.LocatorType
+	Files             []ModuleFiles
 }
 
 type InspectBuildpackOptions struct {
 	BuildpackName string
 	Daemon        bool
 	Registry      string
+	// List the files in the layer of each buildpack
+	Files bool
 }
 
 type ImgWrapper struct {
@@ -57,13 +60,25 @@ func (c *Client) InspectBuildpack(opts InspectBuildpackOptions) (*BuildpackInfo,
 		return nil, err
 	}
 
-	return &BuildpackInfo{
+	info := &BuildpackInfo{
 		BuildpackMetadata: buildpackMd,
 		BuildpackLayers:   layersMd,
 		Order:             extractOrder(buildpackMd),
 		Buildpacks:        extractBuildpacks(layersMd),
 		Location:          locatorType,
-	}, nil
+	}
+
+	if opts.Files {
+		modules, err := c.buildpackModules(context.Background(), opts.BuildpackName, opts.Registry, opts.Daemon)
+		if err != nil {
+			return nil, err
+		}
+		if info.Files, err = moduleFiles(modules); err != nil {
+			return nil, err
+		}
+	}
+
+	return info, nil
 }
 
 func metadataFromRegistry(client *Client, name, registry string) (buildpackMd buildpack.Metadata, layersMd dist.ModuleLayers, err error) {
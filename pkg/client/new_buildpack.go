@@ -10,6 +10,7 @@ import (
 	"github.com/buildpacks/lifecycle/api"
 
 	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/dist"
 )
 
@@ -48,6 +49,10 @@ type NewBuildpackOptions struct {
 
 	// the targets this buildpack will work with
 	Targets []dist.Target
+
+	// Optional. The name of a builtin template, or the path to a directory or tar archive with a template,
+	// to generate the buildpack from. If not set, only bash bin/detect and bin/build scripts are generated.
+	Template string
 }
 
 func (c *Client) NewBuildpack(ctx context.Context, opts NewBuildpackOptions) error {
@@ -55,6 +60,13 @@ func (c *Client) NewBuildpack(ctx context.Context, opts NewBuildpackOptions) err
 	if err != nil {
 		return err
 	}
+	if opts.Template != "" {
+		data, err := newBuildpackTemplateData(opts)
+		if err != nil {
+			return err
+		}
+		return createFromTemplate(buildpack.KindBuildpack, opts.Template, opts.Path, data, c)
+	}
 	return createBashBuildpack(opts.Path, c)
 }
 
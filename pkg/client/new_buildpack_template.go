@@ -0,0 +1,233 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"embed"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+)
+
+// templateSuffix marks template files that are rendered, all other files are copied as is
+const templateSuffix = ".tmpl"
+
+//go:embed templates/buildpack templates/extension
+var builtinTemplates embed.FS
+
+// BuildpackTemplates returns the names of the templates NewBuildpack can generate a buildpack from, in addition to
+// templates stored in a local directory or archive.
+func BuildpackTemplates() []string {
+	return builtinTemplateNames(buildpack.KindBuildpack)
+}
+
+func builtinTemplateNames(kind string) []string {
+	entries, err := builtinTemplates.ReadDir(path.Join("templates", kind))
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// buildpackTemplateData is provided to templates when they are rendered
+type buildpackTemplateData struct {
+	ID      string
+	Name    string
+	Version string
+	API     string
+
+	// buildpack API dependent file formats
+	EnvVars        bool // paths are provided as CNB_* environment variables instead of arguments
+	LayerTypes     bool // layer types are set in a [types] table of <layer>.toml
+	DefaultProcess bool // processes can be marked as default in launch.toml
+	CommandArray   bool // process commands in launch.toml are arrays
+}
+
+type templateFile struct {
+	path     string
+	mode     int64
+	contents []byte
+}
+
+func newBuildpackTemplateData(opts NewBuildpackOptions) (buildpackTemplateData, error) {
+	bpAPI, err := api.NewVersion(opts.API)
+	if err != nil {
+		return buildpackTemplateData{}, err
+	}
+
+	idParts := strings.Split(opts.ID, "/")
+	return buildpackTemplateData{
+		ID:             opts.ID,
+		Name:           idParts[len(idParts)-1],
+		Version:        opts.Version,
+		API:            opts.API,
+		EnvVars:        bpAPI.AtLeast("0.8"),
+		LayerTypes:     bpAPI.AtLeast("0.6"),
+		DefaultProcess: bpAPI.AtLeast("0.6"),
+		CommandArray:   bpAPI.AtLeast("0.9"),
+	}, nil
+}
+
+// createFromTemplate generates the files of a builtin template of the kind of module, or of a template stored in a
+// local directory or archive, at basePath
+func createFromTemplate(kind, nameOrPath, basePath string, data interface{}, c *Client) error {
+	files, err := readTemplate(kind, nameOrPath)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		name, contents := file.path, file.contents
+		if strings.HasSuffix(name, templateSuffix) {
+			name = strings.TrimSuffix(name, templateSuffix)
+			if contents, err = renderTemplateFile(name, contents, data); err != nil {
+				return err
+			}
+		}
+
+		if err := createTemplateFile(basePath, name, file.mode, contents, c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readTemplate reads the files of a builtin template of the kind of module, or of a template stored in a local
+// directory or (optionally gzipped) tar archive. Builtin template names take precedence over local paths, so a
+// local template with the name of a builtin one must be given as a path, such as ./go.
+func readTemplate(kind, nameOrPath string) ([]templateFile, error) {
+	if !contains(builtinTemplateNames(kind), nameOrPath) {
+		if _, err := os.Stat(nameOrPath); err != nil {
+			return nil, errors.Errorf("unknown template %s, must be one of %s or a path to a template directory or archive",
+				style.Symbol(nameOrPath), strings.Join(builtinTemplateNames(kind), ", "))
+		}
+		return readTemplateArchive(nameOrPath)
+	}
+
+	root := path.Join("templates", kind, nameOrPath)
+
+	var files []templateFile
+	err := fs.WalkDir(builtinTemplates, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		contents, err := builtinTemplates.ReadFile(p)
+		if err != nil {
+			return err
+		}
+
+		rel := strings.TrimPrefix(p, root+"/")
+		files = append(files, templateFile{path: rel, mode: templateFileMode(rel, 0644), contents: contents})
+		return nil
+	})
+
+	return files, err
+}
+
+func readTemplateArchive(templatePath string) ([]templateFile, error) {
+	rc, err := blob.NewBlob(templatePath).Open()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading template %s", style.Symbol(templatePath))
+	}
+	defer rc.Close()
+
+	var files []templateFile
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading template %s", style.Symbol(templatePath))
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading template %s", style.Symbol(templatePath))
+		}
+
+		rel := path.Clean(strings.TrimPrefix(filepath.ToSlash(header.Name), "/"))
+		if rel == ".." || strings.HasPrefix(rel, "../") {
+			return nil, errors.Errorf("template %s contains file %s outside of the template", style.Symbol(templatePath), style.Symbol(header.Name))
+		}
+		files = append(files, templateFile{path: rel, mode: templateFileMode(rel, header.Mode), contents: contents})
+	}
+
+	return files, nil
+}
+
+// templateFileMode makes bin/* and shell scripts executable, since modes are not kept by embedded files or every archive
+func templateFileMode(name string, mode int64) int64 {
+	name = strings.TrimSuffix(name, templateSuffix)
+	if mode&0111 != 0 || strings.HasPrefix(name, "bin/") || path.Ext(name) == ".sh" {
+		return 0755
+	}
+
+	return 0644
+}
+
+func renderTemplateFile(name string, contents []byte, data interface{}) ([]byte, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(string(contents))
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing template file %s", style.Symbol(name))
+	}
+
+	buf := &bytes.Buffer{}
+	if err := tmpl.Execute(buf, data); err != nil {
+		return nil, errors.Wrapf(err, "rendering template file %s", style.Symbol(name))
+	}
+
+	return buf.Bytes(), nil
+}
+
+func createTemplateFile(basePath, name string, mode int64, contents []byte, c *Client) error {
+	filePath := filepath.Join(basePath, filepath.FromSlash(name))
+	if _, err := os.Stat(filePath); !os.IsNotExist(err) {
+		return nil
+	}
+
+	// The following line's comment is for gosec, it will ignore rule 301 in this case
+	// G301: Expect directory permissions to be 0750 or less
+	/* #nosec G301 */
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+
+	// The following line's comment is for gosec, it will ignore rule 306 in this case
+	// G306: Expect WriteFile permissions to be 0600 or less
+	/* #nosec G306 */
+	if err := os.WriteFile(filePath, contents, os.FileMode(mode)); err != nil {
+		return err
+	}
+
+	if c != nil {
+		c.logger.Infof("    %s  %s", style.Symbol("create"), name)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package client_test
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -23,6 +24,42 @@ func TestNewBuildpack(t *testing.T) {
 	spec.Run(t, "NewBuildpack", testNewBuildpack, spec.Parallel(), spec.Report(report.Terminal{}))
 }
 
+// TestNewBuildpackBuiltinTemplatePrecedence changes the working directory, so it doesn't run in parallel
+func TestNewBuildpackBuiltinTemplatePrecedence(t *testing.T) {
+	workDir := t.TempDir()
+	h.AssertNil(t, os.MkdirAll(filepath.Join(workDir, "go"), 0755))
+	h.AssertNil(t, os.WriteFile(filepath.Join(workDir, "go", "LOCAL.txt"), []byte("local"), 0644))
+
+	wd, err := os.Getwd()
+	h.AssertNil(t, err)
+	h.AssertNil(t, os.Chdir(workDir))
+	defer func() { h.AssertNil(t, os.Chdir(wd)) }()
+
+	subject, err := client.NewClient()
+	h.AssertNil(t, err)
+
+	bpDir := filepath.Join(workDir, "builtin")
+	h.AssertNil(t, subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+		API:      "0.10",
+		Path:     bpDir,
+		ID:       "example/my-cnb",
+		Version:  "0.0.0",
+		Template: "go",
+	}))
+	h.AssertPathExists(t, filepath.Join(bpDir, "go.mod"))
+	h.AssertPathDoesNotExists(t, filepath.Join(bpDir, "LOCAL.txt"))
+
+	localDir := filepath.Join(workDir, "local")
+	h.AssertNil(t, subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+		API:      "0.10",
+		Path:     localDir,
+		ID:       "example/my-cnb",
+		Version:  "0.0.0",
+		Template: "./go",
+	}))
+	h.AssertPathExists(t, filepath.Join(localDir, "LOCAL.txt"))
+}
+
 func testNewBuildpack(t *testing.T, when spec.G, it spec.S) {
 	var (
 		subject *client.Client
@@ -116,6 +153,100 @@ func testNewBuildpack(t *testing.T, when spec.G, it spec.S) {
 				h.AssertEq(t, content, []byte("expected value"))
 			})
 		})
+
+		when("a template is provided", func() {
+			for _, template := range client.BuildpackTemplates() {
+				template := template
+				it(fmt.Sprintf("generates a %s buildpack", template), func() {
+					err := subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+						API:      "0.10",
+						Path:     tmpDir,
+						ID:       "example/my-cnb",
+						Version:  "0.0.0",
+						Template: template,
+					})
+					h.AssertNil(t, err)
+
+					assertBuildpackToml(t, tmpDir, "example/my-cnb")
+					h.AssertPathExists(t, filepath.Join(tmpDir, "package.toml"))
+					h.AssertPathExists(t, filepath.Join(tmpDir, "README.md"))
+
+					expect, err := os.ReadFile(filepath.Join(tmpDir, "testdata", "apps", "sample", "expect.toml"))
+					h.AssertNil(t, err)
+					h.AssertContains(t, string(expect), `layers = ["my-cnb"]`)
+				})
+			}
+
+			it("uses the file formats of the buildpack API", func() {
+				h.AssertNil(t, subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+					API:      "0.10",
+					Path:     filepath.Join(tmpDir, "new"),
+					ID:       "example/my-cnb",
+					Version:  "0.0.0",
+					Template: "bash",
+				}))
+				h.AssertNil(t, subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+					API:      "0.4",
+					Path:     filepath.Join(tmpDir, "old"),
+					ID:       "example/my-cnb",
+					Version:  "0.0.0",
+					Template: "bash",
+				}))
+
+				build, err := os.ReadFile(filepath.Join(tmpDir, "new", "bin", "build"))
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(build), `layers_dir="${CNB_LAYERS_DIR}"`)
+				h.AssertContains(t, string(build), "[types]\nlaunch = true")
+				h.AssertContains(t, string(build), `command = ["bash", "-c",`)
+
+				build, err = os.ReadFile(filepath.Join(tmpDir, "old", "bin", "build"))
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(build), `layers_dir="$1"`)
+				h.AssertNotContains(t, string(build), "[types]")
+				h.AssertNotContains(t, string(build), "default = true")
+
+				if runtime.GOOS != "windows" {
+					info, err := os.Stat(filepath.Join(tmpDir, "old", "bin", "build"))
+					h.AssertNil(t, err)
+					h.AssertTrue(t, info.Mode()&0100 != 0)
+				}
+			})
+
+			it("generates the buildpack from a template directory", func() {
+				templateDir := filepath.Join(tmpDir, "template")
+				h.AssertNil(t, os.MkdirAll(filepath.Join(templateDir, "bin"), 0755))
+				h.AssertNil(t, os.WriteFile(filepath.Join(templateDir, "bin", "detect.tmpl"), []byte("#!/bin/sh\necho {{.ID}}@{{.Version}}\n"), 0644))
+				h.AssertNil(t, os.WriteFile(filepath.Join(templateDir, "NOTES.txt"), []byte("{{.ID}}"), 0644))
+
+				bpDir := filepath.Join(tmpDir, "buildpack")
+				h.AssertNil(t, subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+					API:      "0.10",
+					Path:     bpDir,
+					ID:       "example/my-cnb",
+					Version:  "1.2.3",
+					Template: templateDir,
+				}))
+
+				detect, err := os.ReadFile(filepath.Join(bpDir, "bin", "detect"))
+				h.AssertNil(t, err)
+				h.AssertEq(t, string(detect), "#!/bin/sh\necho example/my-cnb@1.2.3\n")
+
+				notes, err := os.ReadFile(filepath.Join(bpDir, "NOTES.txt"))
+				h.AssertNil(t, err)
+				h.AssertEq(t, string(notes), "{{.ID}}")
+			})
+
+			it("errors on an unknown template", func() {
+				err := subject.NewBuildpack(context.TODO(), client.NewBuildpackOptions{
+					API:      "0.10",
+					Path:     tmpDir,
+					ID:       "example/my-cnb",
+					Version:  "0.0.0",
+					Template: "cobol",
+				})
+				h.AssertError(t, err, "unknown template 'cobol', must be one of bash, go, node, python")
+			})
+		})
 	})
 }
 
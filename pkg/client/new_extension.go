@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+// minExtensionAPI is the first buildpack API version supporting image extensions
+const minExtensionAPI = "0.9"
+
+var (
+	bashBinGenerate = `#!/usr/bin/env bash
+
+set -euo pipefail
+
+output_dir="${CNB_OUTPUT_DIR}"
+
+# Write build.Dockerfile and/or run.Dockerfile to ${output_dir} to extend the build and run images
+
+exit 0
+`
+)
+
+// NewExtensionOptions defines configuration settings for NewExtension.
+type NewExtensionOptions struct {
+	// api compat version of the output extension artifact.
+	API string
+
+	// The base directory to generate assets
+	Path string
+
+	// The ID of the output extension artifact.
+	ID string
+
+	// version of the output extension artifact.
+	Version string
+
+	// Optional. The name of a builtin template, or the path to a directory or tar archive with a template,
+	// to generate the extension from. If not set, only bash bin/detect and bin/generate scripts are generated.
+	Template string
+}
+
+// extensionTemplateData is provided to extension templates when they are rendered
+type extensionTemplateData struct {
+	ID      string
+	Name    string
+	Version string
+	API     string
+}
+
+// ExtensionTemplates returns the names of the templates NewExtension can generate an extension from, in addition to
+// templates stored in a local directory or archive.
+func ExtensionTemplates() []string {
+	return builtinTemplateNames(buildpack.KindExtension)
+}
+
+// NewExtension generates the scaffolding of an image extension
+func (c *Client) NewExtension(ctx context.Context, opts NewExtensionOptions) error {
+	extAPI, err := api.NewVersion(opts.API)
+	if err != nil {
+		return err
+	}
+	if extAPI.LessThan(minExtensionAPI) {
+		return errors.Errorf("extensions require buildpack API %s or later, found %s", style.Symbol(minExtensionAPI), style.Symbol(opts.API))
+	}
+
+	if err := createExtensionTOML(opts.Path, opts.ID, opts.Version, extAPI, c); err != nil {
+		return err
+	}
+
+	if opts.Template != "" {
+		idParts := strings.Split(opts.ID, "/")
+		return createFromTemplate(buildpack.KindExtension, opts.Template, opts.Path, extensionTemplateData{
+			ID:      opts.ID,
+			Name:    idParts[len(idParts)-1],
+			Version: opts.Version,
+			API:     opts.API,
+		}, c)
+	}
+
+	if err := createBinScript(opts.Path, "detect", bashBinDetect, c); err != nil {
+		return err
+	}
+	return createBinScript(opts.Path, "generate", bashBinGenerate, c)
+}
+
+func createExtensionTOML(path, id, version string, extAPI *api.Version, c *Client) error {
+	extensionTOML := dist.ExtensionDescriptor{
+		WithAPI: extAPI,
+		WithInfo: dist.ModuleInfo{
+			ID:      id,
+			Version: version,
+		},
+	}
+
+	// The following line's comment is for gosec, it will ignore rule 301 in this case
+	// G301: Expect directory permissions to be 0750 or less
+	/* #nosec G301 */
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return err
+	}
+
+	extensionTOMLPath := filepath.Join(path, "extension.toml")
+	if _, err := os.Stat(extensionTOMLPath); !os.IsNotExist(err) {
+		return nil
+	}
+
+	f, err := os.Create(extensionTOMLPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := toml.NewEncoder(f).Encode(extensionTOML); err != nil {
+		return err
+	}
+	if c != nil {
+		c.logger.Infof("    %s  extension.toml", style.Symbol("create"))
+	}
+
+	return nil
+}
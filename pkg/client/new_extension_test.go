@@ -0,0 +1,106 @@
+package client_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/client"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestNewExtension(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "NewExtension", testNewExtension, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testNewExtension(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject *client.Client
+		tmpDir  string
+	)
+
+	it.Before(func() {
+		var err error
+
+		tmpDir, err = os.MkdirTemp("", "new-extension-test")
+		h.AssertNil(t, err)
+
+		subject, err = client.NewClient()
+		h.AssertNil(t, err)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#NewExtension", func() {
+		it("creates extension.toml and bash scripts", func() {
+			h.AssertNil(t, subject.NewExtension(context.TODO(), client.NewExtensionOptions{
+				API:     "0.10",
+				Path:    tmpDir,
+				ID:      "example/my-extension",
+				Version: "1.0.0",
+			}))
+
+			extensionTOML, err := os.ReadFile(filepath.Join(tmpDir, "extension.toml"))
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(extensionTOML), `api = "0.10"`)
+			h.AssertContains(t, string(extensionTOML), `id = "example/my-extension"`)
+
+			for _, script := range []string{"detect", "generate"} {
+				info, err := os.Stat(filepath.Join(tmpDir, "bin", script))
+				h.AssertNil(t, err)
+				h.AssertEq(t, info.Mode().Perm()&0100 != 0, true)
+			}
+		})
+
+		it("renders a builtin template", func() {
+			h.AssertNil(t, subject.NewExtension(context.TODO(), client.NewExtensionOptions{
+				API:      "0.10",
+				Path:     tmpDir,
+				ID:       "example/my-extension",
+				Version:  "1.0.0",
+				Template: "apt-install",
+			}))
+
+			generate, err := os.ReadFile(filepath.Join(tmpDir, "bin", "generate"))
+			h.AssertNil(t, err)
+			h.AssertContains(t, string(generate), "---> example/my-extension 1.0.0")
+			h.AssertContains(t, string(generate), "ARG base_image\nFROM \\${base_image}")
+			h.AssertPathExists(t, filepath.Join(tmpDir, "package.toml"))
+			h.AssertPathExists(t, filepath.Join(tmpDir, "README.md"))
+		})
+
+		it("lists the builtin templates", func() {
+			h.AssertEq(t, client.ExtensionTemplates(), []string{"apt-install", "run-image"})
+		})
+
+		it("errors for an unknown template", func() {
+			err := subject.NewExtension(context.TODO(), client.NewExtensionOptions{
+				API:      "0.10",
+				Path:     tmpDir,
+				ID:       "example/my-extension",
+				Version:  "1.0.0",
+				Template: "rust",
+			})
+			h.AssertError(t, err, "unknown template 'rust', must be one of apt-install, run-image")
+		})
+
+		it("errors for a buildpack API without extensions", func() {
+			err := subject.NewExtension(context.TODO(), client.NewExtensionOptions{
+				API:     "0.8",
+				Path:    tmpDir,
+				ID:      "example/my-extension",
+				Version: "1.0.0",
+			})
+			h.AssertError(t, err, "extensions require buildpack API '0.9' or later, found '0.8'")
+		})
+	})
+}
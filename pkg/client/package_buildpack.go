@@ -65,6 +65,9 @@ type PackageBuildpackOptions struct {
 
 	// Target platforms to build packages for
 	Targets []dist.Target
+
+	// Download the dependencies declared in buildpack.toml and embed them into the buildpack
+	VendorDependencies bool
 }
 
 // PackageBuildpack packages buildpack(s) into either an image or file.
@@ -138,6 +141,12 @@ func (c *Client) packageBuildpackTarget(ctx context.Context, opts PackageBuildpa
 		return digest, err
 	}
 
+	if opts.VendorDependencies {
+		if mainBlob, err = buildpack.VendorDependencies(ctx, mainBlob, c.downloader, target, c.logger); err != nil {
+			return digest, errors.Wrapf(err, "vendoring dependencies of %s", style.Symbol(bpURI))
+		}
+	}
+
 	bp, err := buildpack.FromBuildpackRootBlob(mainBlob, writerFactory, c.logger)
 	if err != nil {
 		return digest, errors.Wrapf(err, "creating buildpack from %s", style.Symbol(bpURI))
@@ -3,9 +3,11 @@ package client_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/buildpacks/imgutil"
@@ -1184,6 +1186,104 @@ func testPackageBuildpack(t *testing.T, when spec.G, it spec.S) {
 		})
 	})
 
+	when("vendoring dependencies", func() {
+		var (
+			bpDir   string
+			depPath string
+			depSHA  string
+		)
+
+		it.Before(func() {
+			bpDir = t.TempDir()
+			h.AssertNil(t, os.MkdirAll(filepath.Join(bpDir, "bin"), 0755))
+			h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "bin", "build"), []byte("build-contents"), 0755))
+			h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "bin", "detect"), []byte("detect-contents"), 0755))
+
+			depPath = filepath.Join(t.TempDir(), "runtime.tgz")
+			h.AssertNil(t, os.WriteFile(depPath, []byte("runtime-contents"), 0644))
+			depSHA = fmt.Sprintf("%x", sha256.Sum256([]byte("runtime-contents")))
+			mockDownloader.EXPECT().Download(gomock.Any(), "https://example.com/runtime.tgz").Return(blob.NewBlob(depPath), nil).AnyTimes()
+			prepareDownloadedBuildpackBlobAtURI(t, mockDownloader, bpDir)
+		})
+
+		writeDescriptor := func(sha string) {
+			h.AssertNil(t, os.WriteFile(filepath.Join(bpDir, "buildpack.toml"), []byte(fmt.Sprintf(`
+api = "0.10"
+
+[buildpack]
+id = "bp.vendor"
+version = "1.0.0"
+
+[[targets]]
+os = "linux"
+
+[[metadata.dependencies]]
+id = "runtime"
+version = "1.2.3"
+uri = "https://example.com/runtime.tgz"
+sha256 = "%s"
+
+[[metadata.dependencies]]
+id = "runtime"
+version = "1.2.3"
+uri = "https://example.com/runtime-arm64.tgz"
+sha256 = "%s"
+arch = "arm64"
+`, sha, sha)), 0644))
+		}
+
+		packageVendored := func(packagePath string) error {
+			bpURI, err := paths.FilePathToURI(bpDir, "")
+			h.AssertNil(t, err)
+
+			return subject.PackageBuildpack(context.TODO(), client.PackageBuildpackOptions{
+				Format: client.FormatFile,
+				Name:   packagePath,
+				Config: pubbldpkg.Config{
+					Platform:  dist.Platform{OS: "linux"},
+					Buildpack: dist.BuildpackURI{URI: bpURI},
+				},
+				Targets:            []dist.Target{{OS: "linux", Arch: "amd64"}},
+				PullPolicy:         image.PullNever,
+				VendorDependencies: true,
+			})
+		}
+
+		it("embeds the dependencies of the target and a manifest into the buildpack", func() {
+			writeDescriptor(depSHA)
+			packagePath := filepath.Join(t.TempDir(), "test.cnb")
+			h.AssertNil(t, packageVendored(packagePath))
+
+			mainBP, _, err := buildpack.BuildpacksFromOCILayoutBlob(blob.NewBlob(packagePath))
+			h.AssertNil(t, err)
+
+			readEntry := func(entryPath string) []byte {
+				rc, err := mainBP.Open()
+				h.AssertNil(t, err)
+				defer rc.Close()
+
+				_, contents, err := archive.ReadTarEntry(rc, entryPath)
+				h.AssertNil(t, err)
+				return contents
+			}
+
+			depEntry := fmt.Sprintf("/cnb/buildpacks/bp.vendor/1.0.0/dependencies/%s/runtime.tgz", depSHA)
+			h.AssertEq(t, string(readEntry(depEntry)), "runtime-contents")
+
+			manifest := string(readEntry("/cnb/buildpacks/bp.vendor/1.0.0/dependencies/manifest.toml"))
+			h.AssertContains(t, manifest, fmt.Sprintf(`path = "dependencies/%s/runtime.tgz"`, depSHA))
+			h.AssertNotContains(t, manifest, "runtime-arm64.tgz")
+			h.AssertContains(t, out.String(), "Vendoring dependency 'runtime@1.2.3'")
+		})
+
+		it("fails when the checksum does not match", func() {
+			writeDescriptor(strings.Repeat("0", 64))
+			err := packageVendored(filepath.Join(t.TempDir(), "test.cnb"))
+			h.AssertError(t, err, "verifying dependency 'runtime'")
+			h.AssertError(t, err, fmt.Sprintf("found '%s'", depSHA))
+		})
+	})
+
 	when("unknown format is provided", func() {
 		it("should error", func() {
 			mockDockerClient.EXPECT().Info(context.TODO()).Return(system.Info{OSType: "linux"}, nil).AnyTimes()
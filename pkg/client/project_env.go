@@ -0,0 +1,117 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	projectTypes "github.com/buildpacks/pack/pkg/project/types"
+)
+
+// projectBuildEnv returns the env vars of descriptor, which are set in the build execution environment.
+//
+// The lifecycle can't provide an env var to some buildpacks only, nor set it in the launch or test execution
+// environments, so entries scoped that way fail the build instead of being provided to every buildpack or dropped.
+func projectBuildEnv(descriptor projectTypes.Descriptor) (map[string]string, error) {
+	buildEnvs := map[string]string{}
+	for _, envVar := range descriptor.Build.Env {
+		if err := checkBuildScope("env var", envVar.Name, envVar.Buildpacks, envVar.ExecEnv); err != nil {
+			return nil, err
+		}
+		buildEnvs[envVar.Name] = envVar.Value
+	}
+	return buildEnvs, nil
+}
+
+// projectSecrets returns the values of the secrets of descriptor, which are read when building and written to the
+// platform env of the build containers only, so they are never stored in an image.
+//
+// The descriptor comes with the app and isn't trusted: secrets are read from files within baseDir, and from the env
+// of pack only when their name is in allowedEnvSecrets. Secrets are scoped like env vars.
+func projectSecrets(descriptor projectTypes.Descriptor, baseDir string, allowedEnvSecrets []string) (map[string]string, error) {
+	secrets := map[string]string{}
+	for _, secret := range descriptor.Build.Secrets {
+		if err := checkBuildScope("secret", secret.Name, secret.Buildpacks, secret.ExecEnv); err != nil {
+			return nil, err
+		}
+		if secret.Env != "" && !contains(allowedEnvSecrets, secret.Name) {
+			return nil, errors.Errorf("secret %s reads env var %s, allow it with %s",
+				style.Symbol(secret.Name), style.Symbol(secret.Env), style.Symbol("--secret "+secret.Name))
+		}
+		value, err := readSecret(secret, baseDir)
+		if err != nil {
+			return nil, err
+		}
+		secrets[secret.Name] = value
+	}
+	return secrets, nil
+}
+
+// checkBuildScope returns an error if an env var or secret is scoped to buildpacks or to execution environments
+// other than build
+func checkBuildScope(kind, name string, buildpacks, execEnvs []string) error {
+	if len(buildpacks) > 0 {
+		return errors.Errorf("%s %s is scoped to buildpacks %s, which is not supported yet, remove %s to provide it to every buildpack",
+			kind, style.Symbol(name), strings.Join(buildpacks, ", "), style.Symbol("buildpacks"))
+	}
+	for _, execEnv := range execEnvs {
+		if execEnv != projectTypes.ExecEnvBuild {
+			return errors.Errorf("%s %s targets exec-env %s, which is not supported yet, only %s is",
+				kind, style.Symbol(name), style.Symbol(execEnv), style.Symbol(projectTypes.ExecEnvBuild))
+		}
+	}
+	return nil
+}
+
+func readSecret(secret projectTypes.Secret, baseDir string) (string, error) {
+	if secret.Env != "" {
+		value, ok := os.LookupEnv(secret.Env)
+		if !ok {
+			return "", errors.Errorf("secret %s references env var %s, which is not set", style.Symbol(secret.Name), style.Symbol(secret.Env))
+		}
+		return value, nil
+	}
+
+	if filepath.IsAbs(secret.File) {
+		return "", errors.Errorf("secret %s file %s must be relative to the project", style.Symbol(secret.Name), style.Symbol(secret.File))
+	}
+	if baseDir == "" {
+		baseDir = "."
+	}
+	dir, err := filepath.EvalSymlinks(baseDir)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading secret %s", style.Symbol(secret.Name))
+	}
+	path, err := filepath.EvalSymlinks(filepath.Join(dir, secret.File))
+	if err != nil {
+		return "", errors.Wrapf(err, "reading secret %s", style.Symbol(secret.Name))
+	}
+	if !withinDir(dir, path) {
+		return "", errors.Errorf("secret %s file %s is outside of the project", style.Symbol(secret.Name), style.Symbol(secret.File))
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading secret %s", style.Symbol(secret.Name))
+	}
+	return strings.TrimRight(string(contents), "\r\n"), nil
+}
+
+// projectDefaultProcess returns the process type declared as the default in descriptor. The lifecycle can't
+// override the command or args of a process, so processes setting them fail the build instead of being ignored.
+func projectDefaultProcess(descriptor projectTypes.Descriptor) (string, error) {
+	defaultProcess := ""
+	for _, process := range descriptor.Run.Processes {
+		if len(process.Command) > 0 || len(process.Args) > 0 {
+			return "", errors.Errorf("process %s overrides command or args, which is not supported yet, only %s is",
+				style.Symbol(process.Type), style.Symbol("default"))
+		}
+		if process.Default {
+			defaultProcess = process.Type
+		}
+	}
+	return defaultProcess, nil
+}
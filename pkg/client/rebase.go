@@ -4,11 +4,15 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/layout"
 	"github.com/buildpacks/lifecycle/phase"
 	"github.com/buildpacks/lifecycle/platform"
 	"github.com/buildpacks/lifecycle/platform/files"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 
 	"github.com/buildpacks/pack/internal/build"
@@ -21,6 +25,7 @@ import (
 // RebaseOptions is a configuration struct that controls image rebase behavior.
 type RebaseOptions struct {
 	// Name of image we wish to rebase.
+	// An 'oci:' prefixed path refers to an image in OCI layout format, which is rebased in place.
 	RepoName string
 
 	// Flag to publish image to remote registry after rebase completion.
@@ -31,6 +36,7 @@ type RebaseOptions struct {
 
 	// Image to rebase against. This image must have
 	// the same StackID as the previous run image.
+	// An 'oci:' prefixed path refers to a run image in OCI layout format.
 	RunImage string
 
 	// A mapping from StackID to an array of mirrors.
@@ -39,6 +45,14 @@ type RebaseOptions struct {
 	// based on the registry we are publishing to.
 	AdditionalMirrors map[string][]string
 
+	// Only select a run image mirror with the same digest as the run image,
+	// skipping mirrors that don't respond within RunImageMirrorTimeout.
+	VerifyRunImageMirrors bool
+
+	// How long the run image and each mirror may take to respond when VerifyRunImageMirrors is set.
+	// Defaults to DefaultRunImageMirrorTimeout when not positive.
+	RunImageMirrorTimeout time.Duration
+
 	// If provided, directory to which report.toml will be copied
 	ReportDestinationDir string
 
@@ -47,43 +61,99 @@ type RebaseOptions struct {
 	Force bool
 
 	// Image reference to use as the previous image for rebase.
+	// It must be in OCI layout format when RepoName is, and only then.
 	PreviousImage string
 }
 
 // Rebase updates the run image layers in an app image.
 // This operation mutates the image specified in opts.
 func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
-	imageRef, err := c.parseTagReference(opts.RepoName)
-	if err != nil {
-		return errors.Wrapf(err, "invalid image name '%s'", opts.RepoName)
-	}
+	_, err := c.rebase(ctx, opts, c.imageFetcher.Fetch, false)
+	return err
+}
+
+// rebaseResult describes the outcome of rebasing a single app image.
+type rebaseResult struct {
+	runImage string
+	skipped  bool
+}
+
+type fetchFunc func(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error)
 
-	repoName := opts.RepoName
+// rebaseImages holds an app image to rebase along with its lifecycle metadata, the run image it should be based on
+// and the name the rebased image is saved as.
+type rebaseImages struct {
+	app        imgutil.Image
+	metadata   files.LayersMetadataCompat
+	run        imgutil.Image
+	outputName string
+}
 
-	if opts.PreviousImage != "" {
-		repoName = opts.PreviousImage
+// fetchRebaseImages fetches the app image in opts and the run image it should be rebased on, as returned by fetchRunImage.
+func (c *Client) fetchRebaseImages(ctx context.Context, opts RebaseOptions, fetchRunImage fetchFunc) (rebaseImages, error) {
+	inputImage := ParseInputImageReference(opts.RepoName)
+	previousImage := ParseInputImageReference(opts.PreviousImage)
+	if opts.PreviousImage != "" && previousImage.Layout() != inputImage.Layout() {
+		return rebaseImages{}, errors.Errorf("previous image %s and image %s must both be in OCI layout format, or neither",
+			style.Symbol(opts.PreviousImage), style.Symbol(opts.RepoName))
 	}
 
-	appImage, err := c.imageFetcher.Fetch(ctx, repoName, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
-	if err != nil {
-		return err
+	var (
+		appImage    imgutil.Image
+		outputName  string
+		imgRegistry string
+		err         error
+	)
+	if inputImage.Layout() {
+		if opts.Publish {
+			return rebaseImages{}, errors.Errorf("image %s in OCI layout format can't be published", style.Symbol(opts.RepoName))
+		}
+		if outputName, err = inputImage.FullName(); err != nil {
+			return rebaseImages{}, errors.Wrapf(err, "invalid layout image name '%s'", opts.RepoName)
+		}
+		fromPath := outputName
+		if opts.PreviousImage != "" {
+			if fromPath, err = previousImage.FullName(); err != nil {
+				return rebaseImages{}, errors.Wrapf(err, "invalid layout image name '%s'", opts.PreviousImage)
+			}
+		}
+		if appImage, err = openLayoutImage(fromPath); err != nil {
+			return rebaseImages{}, err
+		}
+	} else {
+		var imageRef name.Reference
+		if imageRef, err = c.parseTagReference(opts.RepoName); err != nil {
+			return rebaseImages{}, errors.Wrapf(err, "invalid image name '%s'", opts.RepoName)
+		}
+		outputName = opts.RepoName
+		imgRegistry = imageRef.Context().RegistryStr()
+
+		repoName := opts.RepoName
+		if opts.PreviousImage != "" {
+			repoName = opts.PreviousImage
+		}
+
+		appImage, err = c.imageFetcher.Fetch(ctx, repoName, image.FetchOptions{Daemon: !opts.Publish, PullPolicy: opts.PullPolicy})
+		if err != nil {
+			return rebaseImages{}, err
+		}
 	}
 
 	appOS, err := appImage.OS()
 	if err != nil {
-		return errors.Wrapf(err, "getting app OS")
+		return rebaseImages{}, errors.Wrapf(err, "getting app OS")
 	}
 
 	appArch, err := appImage.Architecture()
 	if err != nil {
-		return errors.Wrapf(err, "getting app architecture")
+		return rebaseImages{}, errors.Wrapf(err, "getting app architecture")
 	}
 
 	var md files.LayersMetadataCompat
 	if ok, err := dist.GetLabel(appImage, platform.LifecycleMetadataLabel, &md); err != nil {
-		return err
+		return rebaseImages{}, err
 	} else if !ok {
-		return errors.Errorf("could not find label %s on image", style.Symbol(platform.LifecycleMetadataLabel))
+		return rebaseImages{}, errors.Errorf("could not find label %s on image", style.Symbol(platform.LifecycleMetadataLabel))
 	}
 	var runImageMD builder.RunImageMetadata
 	if md.RunImage.Image != "" {
@@ -98,42 +168,105 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 		}
 	}
 
+	if runImage := ParseInputImageReference(opts.RunImage); runImage.Layout() {
+		runImagePath, err := runImage.FullName()
+		if err != nil {
+			return rebaseImages{}, errors.Wrapf(err, "invalid layout run image name '%s'", opts.RunImage)
+		}
+		c.logger.Debugf("Using provided run-image %s", style.Symbol(runImagePath))
+		baseImage, err := openLayoutImage(runImagePath)
+		if err != nil {
+			return rebaseImages{}, err
+		}
+		if runImageMD.Image != "" {
+			// the lifecycle only rebases on run images named in the run image metadata of the app, the provided
+			// run image stands in for the one the app was built on
+			c.logger.Debugf("Rebasing on run image %s as %s", style.Symbol(runImagePath), style.Symbol(runImageMD.Image))
+			baseImage.Rename(runImageMD.Image)
+		}
+		return rebaseImages{app: appImage, metadata: md, run: baseImage, outputName: outputName}, nil
+	}
+
 	target := &dist.Target{OS: appOS, Arch: appArch}
 	fetchOptions := image.FetchOptions{
-		Daemon:     !opts.Publish,
+		// app images in OCI layout format are rebased on run images from a registry
+		Daemon:     !opts.Publish && !inputImage.Layout(),
 		PullPolicy: opts.PullPolicy,
 		Target:     target,
 	}
 
-	runImageName := c.resolveRunImage(
-		opts.RunImage,
-		imageRef.Context().RegistryStr(),
-		"",
-		runImageMD,
-		opts.AdditionalMirrors,
-		opts.Publish,
-		fetchOptions,
-	)
+	var runImageName string
+	if opts.VerifyRunImageMirrors && opts.RunImage == "" {
+		runImageName = c.resolveVerifiedRunImage(ctx, imgRegistry, "", runImageMD, opts.AdditionalMirrors, opts.Publish, target, opts.RunImageMirrorTimeout)
+	} else {
+		runImageName = c.resolveRunImage(
+			opts.RunImage,
+			imgRegistry,
+			"",
+			runImageMD,
+			opts.AdditionalMirrors,
+			opts.Publish,
+			fetchOptions,
+		)
+	}
 
 	if runImageName == "" {
-		return errors.New("run image must be specified")
+		return rebaseImages{}, errors.New("run image must be specified")
+	}
+
+	baseImage, err := fetchRunImage(ctx, runImageName, fetchOptions)
+	if err != nil {
+		return rebaseImages{}, err
+	}
+
+	return rebaseImages{app: appImage, metadata: md, run: baseImage, outputName: outputName}, nil
+}
+
+// openLayoutImage loads the image saved in OCI layout format at path.
+func openLayoutImage(path string) (imgutil.Image, error) {
+	if _, err := os.Stat(filepath.Join(path, "index.json")); err != nil {
+		return nil, errors.Errorf("no image in OCI layout format found at %s", style.Symbol(path))
 	}
+	return layout.NewImage(path, layout.FromBaseImagePath(path))
+}
 
-	baseImage, err := c.imageFetcher.Fetch(ctx, runImageName, fetchOptions)
+// rebase rebases the app image in opts on the run image returned by fetchRunImage. When skipUpToDate is
+// set, app images already based on the top layer of the run image are left untouched.
+func (c *Client) rebase(ctx context.Context, opts RebaseOptions, fetchRunImage fetchFunc, skipUpToDate bool) (rebaseResult, error) {
+	images, err := c.fetchRebaseImages(ctx, opts, fetchRunImage)
 	if err != nil {
-		return err
+		return rebaseResult{}, err
+	}
+	appImage, md, baseImage := images.app, images.metadata, images.run
+
+	result := rebaseResult{runImage: baseImage.Name()}
+
+	if skipUpToDate && md.RunImage.TopLayer != "" {
+		topLayer, err := baseImage.TopLayer()
+		if err != nil {
+			return rebaseResult{}, errors.Wrapf(err, "getting top layer of run image %s", style.Symbol(baseImage.Name()))
+		}
+		if topLayer == md.RunImage.TopLayer {
+			c.logger.Infof("Skipping %s, already based on run image %s", style.Symbol(appImage.Name()), style.Symbol(baseImage.Name()))
+			result.skipped = true
+			return result, nil
+		}
 	}
 
 	c.logger.Infof("Rebasing %s on run image %s", style.Symbol(appImage.Name()), style.Symbol(baseImage.Name()))
 	rebaser := &phase.Rebaser{Logger: c.logger, PlatformAPI: build.SupportedPlatformAPIVersions.Latest(), Force: opts.Force}
-	report, err := rebaser.Rebase(appImage, baseImage, opts.RepoName, nil)
+	report, err := rebaser.Rebase(appImage, baseImage, images.outputName, nil)
 	if err != nil {
-		return err
+		return rebaseResult{}, err
+	}
+	if _, ok := appImage.(*layout.Image); ok {
+		// a previous image in OCI layout format is loaded from a different path than the one the rebased image is saved at
+		appImage.Rename(images.outputName)
 	}
 
 	appImageIdentifier, err := appImage.Identifier()
 	if err != nil {
-		return err
+		return rebaseResult{}, err
 	}
 
 	c.logger.Infof("Rebased Image: %s", style.Symbol(appImageIdentifier.String()))
@@ -143,15 +276,15 @@ func (c *Client) Rebase(ctx context.Context, opts RebaseOptions) error {
 		reportFile, err := os.OpenFile(reportPath, os.O_RDWR|os.O_CREATE, 0644)
 		if err != nil {
 			c.logger.Warnf("unable to open %s for writing rebase report", reportPath)
-			return err
+			return rebaseResult{}, err
 		}
 
 		defer reportFile.Close()
 		err = toml.NewEncoder(reportFile).Encode(report)
 		if err != nil {
 			c.logger.Warnf("unable to write rebase report to %s", reportPath)
-			return err
+			return rebaseResult{}, err
 		}
 	}
-	return nil
+	return result, nil
 }
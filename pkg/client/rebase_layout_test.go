@@ -0,0 +1,185 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/imgutil/layout"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRebaseLayout(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "rebase_layout", testRebaseLayout, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testRebaseLayout(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject    *Client
+		tmpDir     string
+		appPath    string
+		newRunPath string
+		appLayer   v1.Hash
+		newRunTop  v1.Hash
+		out        bytes.Buffer
+	)
+
+	var topLayer = func(img v1.Image) v1.Hash {
+		layers, err := img.Layers()
+		h.AssertNil(t, err)
+		diffID, err := layers[len(layers)-1].DiffID()
+		h.AssertNil(t, err)
+		return diffID
+	}
+
+	var saveLayout = func(path string, img v1.Image, labels map[string]string) {
+		layoutImage, err := layout.NewImage(path, layout.FromBaseImageInstance(img))
+		h.AssertNil(t, err)
+		for k, v := range labels {
+			h.AssertNil(t, layoutImage.SetLabel(k, v))
+		}
+		h.AssertNil(t, layoutImage.Save())
+	}
+
+	var diffIDs = func(path string) []v1.Hash {
+		layoutImage, err := layout.NewImage(path, layout.FromBaseImagePath(path))
+		h.AssertNil(t, err)
+		layers, err := layoutImage.UnderlyingImage().Layers()
+		h.AssertNil(t, err)
+		var hashes []v1.Hash
+		for _, l := range layers {
+			diffID, err := l.DiffID()
+			h.AssertNil(t, err)
+			hashes = append(hashes, diffID)
+		}
+		return hashes
+	}
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "rebase-layout")
+		h.AssertNil(t, err)
+
+		oldRun, err := random.Image(256, 2)
+		h.AssertNil(t, err)
+		layer, err := random.Layer(256, "application/vnd.docker.image.rootfs.diff.tar.gzip")
+		h.AssertNil(t, err)
+		appLayer, err = layer.DiffID()
+		h.AssertNil(t, err)
+		app, err := mutate.AppendLayers(oldRun, layer)
+		h.AssertNil(t, err)
+
+		appPath = filepath.Join(tmpDir, "app")
+		saveLayout(appPath, app, map[string]string{
+			"io.buildpacks.stack.id":           "io.buildpacks.stacks.jammy",
+			"io.buildpacks.lifecycle.metadata": `{"runImage":{"image":"some/run","topLayer":"` + topLayer(oldRun).String() + `"}}`,
+		})
+
+		newRun, err := random.Image(256, 3)
+		h.AssertNil(t, err)
+		newRunTop = topLayer(newRun)
+		newRunPath = filepath.Join(tmpDir, "run")
+		saveLayout(newRunPath, newRun, map[string]string{"io.buildpacks.stack.id": "io.buildpacks.stacks.jammy"})
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: ifakes.NewFakeImageFetcher(),
+		}
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#Rebase", func() {
+		it("rebases an image in OCI layout format on a run image in OCI layout format", func() {
+			h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{
+				RepoName: "oci:" + appPath,
+				RunImage: "oci:" + newRunPath,
+			}))
+
+			rebased := diffIDs(appPath)
+			h.AssertEq(t, len(rebased), 4)
+			h.AssertEq(t, rebased[:3], diffIDs(newRunPath))
+			h.AssertEq(t, rebased[3], appLayer)
+
+			rebasedImage, err := layout.NewImage(appPath, layout.FromBaseImagePath(appPath))
+			h.AssertNil(t, err)
+			md, err := rebasedImage.Label("io.buildpacks.lifecycle.metadata")
+			h.AssertNil(t, err)
+			h.AssertContains(t, md, `"image":"some/run"`)
+
+			reportedCheck, err := subject.CheckRebase(context.TODO(), RebaseOptions{
+				RepoName: "oci:" + appPath,
+				RunImage: "oci:" + newRunPath,
+			})
+			h.AssertNil(t, err)
+			h.AssertEq(t, reportedCheck.CurrentTopLayer, newRunTop.String())
+			h.AssertFalse(t, reportedCheck.RebaseAvailable)
+		})
+
+		it("saves the rebased previous image at the image path", func() {
+			rebasedPath := filepath.Join(tmpDir, "rebased-app")
+			h.AssertNil(t, subject.Rebase(context.TODO(), RebaseOptions{
+				RepoName:      "oci:" + rebasedPath,
+				PreviousImage: "oci:" + appPath,
+				RunImage:      "oci:" + newRunPath,
+			}))
+
+			h.AssertEq(t, diffIDs(rebasedPath)[:3], diffIDs(newRunPath))
+			h.AssertEq(t, len(diffIDs(appPath)), 3)
+		})
+
+		it("requires force to rebase an image without run image metadata", func() {
+			app, err := random.Image(256, 2)
+			h.AssertNil(t, err)
+			saveLayout(appPath, app, map[string]string{
+				"io.buildpacks.stack.id":           "io.buildpacks.stacks.jammy",
+				"io.buildpacks.lifecycle.metadata": `{"runImage":{"topLayer":"` + topLayer(app).String() + `"}}`,
+			})
+
+			err = subject.Rebase(context.TODO(), RebaseOptions{
+				RepoName: "oci:" + appPath,
+				RunImage: "oci:" + newRunPath,
+			})
+			h.AssertError(t, err, "not found in existing run image metadata")
+		})
+
+		it("errors when there is no image at the layout path", func() {
+			err := subject.Rebase(context.TODO(), RebaseOptions{
+				RepoName: "oci:" + filepath.Join(tmpDir, "missing"),
+				RunImage: "oci:" + newRunPath,
+			})
+			h.AssertError(t, err, "no image in OCI layout format found at")
+		})
+
+		it("errors when only the previous image is in OCI layout format", func() {
+			err := subject.Rebase(context.TODO(), RebaseOptions{
+				RepoName:      "some/app",
+				PreviousImage: "oci:" + appPath,
+			})
+			h.AssertError(t, err, "must both be in OCI layout format, or neither")
+		})
+
+		it("errors when publishing an image in OCI layout format", func() {
+			err := subject.Rebase(context.TODO(), RebaseOptions{
+				RepoName: "oci:" + appPath,
+				Publish:  true,
+			})
+			h.AssertError(t, err, "in OCI layout format can't be published")
+		})
+	})
+}
@@ -3,14 +3,17 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/url"
 	"runtime"
 	"strings"
 
 	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/buildpack"
 	"github.com/buildpacks/pack/pkg/dist"
 	"github.com/buildpacks/pack/pkg/image"
+	registrytypes "github.com/buildpacks/pack/registry"
 )
 
 // RegisterBuildpackOptions is a configuration struct that controls the
@@ -77,24 +80,46 @@ func (c *Client) RegisterBuildpack(ctx context.Context, opts RegisterBuildpackOp
 
 		return cmd.Start()
 	} else if opts.Type == "git" {
-		registryCache, err := getRegistry(c.logger, opts.Name)
+		registryIndex, err := getRegistry(c.logger, opts.Name)
 		if err != nil {
 			return err
 		}
+		registryCache, ok := registryIndex.(*registry.Cache)
+		if !ok {
+			return fmt.Errorf("registry %s is not a git registry", style.Symbol(opts.Name))
+		}
 
 		username, err := parseUsernameFromURL(opts.URL)
 		if err != nil {
 			return err
 		}
 
-		if err := registry.GitCommit(buildpack, username, registryCache); err != nil {
+		if err := registry.GitCommit(buildpack, username, *registryCache); err != nil {
 			return err
 		}
+	} else if opts.Type == registrytypes.TypeDir || opts.Type == registrytypes.TypeOCI {
+		return c.writeRegistry(opts.Name, func(index registry.WritableIndex) error {
+			return index.Register(buildpack)
+		})
 	}
 
 	return nil
 }
 
+// writeRegistry updates a registry that is written to directly, rather than through a GitHub issue or a git commit
+func (c *Client) writeRegistry(registryName string, update func(index registry.WritableIndex) error) error {
+	registryIndex, err := getRegistry(c.logger, registryName)
+	if err != nil {
+		return err
+	}
+
+	writable, ok := registryIndex.(registry.WritableIndex)
+	if !ok {
+		return fmt.Errorf("registry %s cannot be written to directly", style.Symbol(registryName))
+	}
+	return update(writable)
+}
+
 func parseUsernameFromURL(url string) (string, error) {
 	parts := strings.Split(url, "/")
 	if len(parts) < 3 {
@@ -3,6 +3,8 @@ package client
 import (
 	"bytes"
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/buildpacks/imgutil/fakes"
@@ -10,6 +12,7 @@ import (
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
+	cfg "github.com/buildpacks/pack/internal/config"
 	ifakes "github.com/buildpacks/pack/internal/fakes"
 	"github.com/buildpacks/pack/internal/registry"
 	"github.com/buildpacks/pack/pkg/logging"
@@ -133,5 +136,58 @@ func testRegisterBuildpack(t *testing.T, when spec.G, it spec.S) {
 					Name:      registry.DefaultRegistryName,
 				}))
 		})
+
+		when("registry is written to directly (dir)", func() {
+			var (
+				tmpDir      string
+				registryDir string
+			)
+
+			it.Before(func() {
+				var err error
+				tmpDir, err = os.MkdirTemp("", "register-buildpack-dir")
+				h.AssertNil(t, err)
+
+				registryDir = filepath.Join(tmpDir, "registry-index")
+				packHome := filepath.Join(tmpDir, "packHome")
+				h.AssertNil(t, os.Setenv("PACK_HOME", packHome))
+				h.AssertNil(t, cfg.Write(cfg.Config{
+					Registries: []cfg.Registry{{Name: "private", Type: "dir", URL: registryDir}},
+				}, filepath.Join(packHome, "config.toml")))
+			})
+
+			it.After(func() {
+				h.AssertNil(t, os.Unsetenv("PACK_HOME"))
+				h.AssertNil(t, os.RemoveAll(tmpDir))
+			})
+
+			it("registers and yanks the buildpack in the registry", func() {
+				h.AssertNil(t, subject.RegisterBuildpack(context.TODO(),
+					RegisterBuildpackOptions{
+						ImageName: "buildpack/image",
+						Type:      "dir",
+						URL:       registryDir,
+						Name:      "private",
+					}))
+
+				indexFile := filepath.Join(registryDir, "ja", "va", "heroku_java-function")
+				contents, err := os.ReadFile(indexFile)
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(contents), `{"ns":"heroku","name":"java-function","version":"1.1.1","yanked":false,"addr":"buildpack-image"}`)
+
+				h.AssertNil(t, subject.YankBuildpack(YankBuildpackOptions{
+					ID:      "heroku/java-function",
+					Version: "1.1.1",
+					Type:    "dir",
+					URL:     registryDir,
+					Name:    "private",
+					Yank:    true,
+				}))
+
+				contents, err = os.ReadFile(indexFile)
+				h.AssertNil(t, err)
+				h.AssertContains(t, string(contents), `"yanked":true`)
+			})
+		})
 	})
 }
@@ -0,0 +1,211 @@
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/builder"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+// DefaultRunImageMirrorTimeout is how long a run image or mirror may take to respond when mirrors are verified.
+const DefaultRunImageMirrorTimeout = 10 * time.Second
+
+// RunImageMirrorStatus describes how a run image mirror compares with its run image.
+type RunImageMirrorStatus string
+
+const (
+	// RunImageMirrorInSync is a mirror with the same digest as its run image.
+	RunImageMirrorInSync RunImageMirrorStatus = "in-sync"
+	// RunImageMirrorStale is a mirror with a different digest than its run image.
+	RunImageMirrorStale RunImageMirrorStatus = "stale"
+	// RunImageMirrorUnreachable is a mirror that could not be fetched, or that could not be compared with its run image.
+	RunImageMirrorUnreachable RunImageMirrorStatus = "unreachable"
+)
+
+// VerifyRunImageMirrorsOptions is a configuration struct that controls which run image mirrors are verified.
+type VerifyRunImageMirrorsOptions struct {
+	// A mapping from run image to its mirrors.
+	Mirrors map[string][]string
+
+	// How long each run image and mirror may take to respond.
+	// Defaults to DefaultRunImageMirrorTimeout when not positive.
+	Timeout time.Duration
+}
+
+// RunImageMirrorsReport compares the mirrors of a run image with the run image.
+type RunImageMirrorsReport struct {
+	RunImage string               `json:"runImage"`
+	Digest   string               `json:"digest,omitempty"`
+	Error    string               `json:"error,omitempty"`
+	Mirrors  []RunImageMirrorInfo `json:"mirrors"`
+}
+
+// RunImageMirrorInfo reports the digest and status of a single run image mirror.
+type RunImageMirrorInfo struct {
+	Mirror string               `json:"mirror"`
+	Digest string               `json:"digest,omitempty"`
+	Status RunImageMirrorStatus `json:"status"`
+	Error  string               `json:"error,omitempty"`
+}
+
+// VerifyRunImageMirrors fetches every run image in opts and its mirrors from their registries and
+// reports whether each mirror has the same digest as its run image. Reports are sorted by run image.
+func (c *Client) VerifyRunImageMirrors(ctx context.Context, opts VerifyRunImageMirrorsOptions) ([]RunImageMirrorsReport, error) {
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = DefaultRunImageMirrorTimeout
+	}
+
+	var runImages []string
+	for runImage := range opts.Mirrors {
+		runImages = append(runImages, runImage)
+	}
+	sort.Strings(runImages)
+
+	var reports []RunImageMirrorsReport
+	for _, runImage := range runImages {
+		report := RunImageMirrorsReport{RunImage: runImage, Mirrors: []RunImageMirrorInfo{}}
+		digest, err := c.remoteDigest(ctx, runImage, nil, timeout)
+		if err != nil {
+			report.Error = err.Error()
+		}
+		report.Digest = digest
+
+		for _, mirror := range opts.Mirrors[runImage] {
+			info := RunImageMirrorInfo{Mirror: mirror}
+			info.Digest, err = c.remoteDigest(ctx, mirror, nil, timeout)
+			switch {
+			case err != nil:
+				info.Status = RunImageMirrorUnreachable
+				info.Error = err.Error()
+			case report.Error != "":
+				info.Status = RunImageMirrorUnreachable
+				info.Error = "run image could not be fetched"
+			case info.Digest == report.Digest:
+				info.Status = RunImageMirrorInSync
+			default:
+				info.Status = RunImageMirrorStale
+			}
+			report.Mirrors = append(report.Mirrors, info)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// resolveVerifiedRunImage selects the run image, or one of its mirrors, in the order resolveRunImage considers them,
+// skipping mirrors that don't respond within timeout or whose digest differs from the run image.
+// When the run image itself can't be fetched, the first mirror that responds is used.
+func (c *Client) resolveVerifiedRunImage(ctx context.Context, imgRegistry, bldrRegistry string, runImageMetadata builder.RunImageMetadata, additionalMirrors map[string][]string, publish bool, target *dist.Target, timeout time.Duration) string {
+	if timeout <= 0 {
+		timeout = DefaultRunImageMirrorTimeout
+	}
+
+	preferredRegistry := bldrRegistry
+	if publish || bldrRegistry == "" {
+		preferredRegistry = imgRegistry
+	}
+	candidates := runImageCandidates(
+		preferredRegistry,
+		runImageMetadata.Image,
+		runImageMetadata.Mirrors,
+		additionalMirrors[runImageMetadata.Image],
+	)
+
+	runImageDigest, err := c.remoteDigest(ctx, runImageMetadata.Image, target, timeout)
+	if err != nil {
+		c.logger.Warnf("Unable to verify run image mirrors, run image %s could not be fetched: %s", style.Symbol(runImageMetadata.Image), err)
+	}
+
+	for _, candidate := range candidates {
+		if candidate == runImageMetadata.Image {
+			if runImageDigest != "" {
+				c.logger.Infof("Selected run image %s", style.Symbol(candidate))
+				return candidate
+			}
+			continue
+		}
+
+		digest, err := c.remoteDigest(ctx, candidate, target, timeout)
+		switch {
+		case err != nil:
+			c.logger.Warnf("Skipping run image mirror %s: %s", style.Symbol(candidate), err)
+		case runImageDigest == "":
+			c.logger.Infof("Selected run image mirror %s, its digest could not be compared with run image %s", style.Symbol(candidate), style.Symbol(runImageMetadata.Image))
+			return candidate
+		case digest != runImageDigest:
+			c.logger.Warnf("Skipping run image mirror %s: digest %s does not match run image %s digest %s",
+				style.Symbol(candidate), style.Symbol(digest), style.Symbol(runImageMetadata.Image), style.Symbol(runImageDigest))
+		default:
+			c.logger.Infof("Selected run image mirror %s, its digest matches run image %s", style.Symbol(candidate), style.Symbol(runImageMetadata.Image))
+			return candidate
+		}
+	}
+
+	c.logger.Warnf("No run image or mirror could be verified, using run image %s", style.Symbol(runImageMetadata.Image))
+	return runImageMetadata.Image
+}
+
+// runImageCandidates lists the run image and its mirrors in the order getBestRunMirror considers them,
+// with the images on registry first.
+func runImageCandidates(registry string, runImage string, mirrors []string, preferredMirrors []string) []string {
+	all := append(append(append([]string{}, preferredMirrors...), runImage), mirrors...)
+
+	var onRegistry, others []string
+	for _, img := range all {
+		if contains(onRegistry, img) || contains(others, img) {
+			continue
+		}
+		if ref, err := name.ParseReference(img, name.WeakValidation); err == nil && ref.Context().RegistryStr() == registry {
+			onRegistry = append(onRegistry, img)
+		} else {
+			others = append(others, img)
+		}
+	}
+	return append(onRegistry, others...)
+}
+
+// remoteDigest fetches the manifest digest of imageName from its registry, giving up after timeout.
+func (c *Client) remoteDigest(ctx context.Context, imageName string, target *dist.Target, timeout time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		img imgutil.Image
+		err error
+	}
+	fetched := make(chan result, 1)
+	go func() {
+		img, err := c.imageFetcher.Fetch(ctx, imageName, image.FetchOptions{Daemon: false, Target: target})
+		fetched <- result{img: img, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", errors.Errorf("timed out after %s", timeout)
+		}
+		return "", ctx.Err()
+	case r := <-fetched:
+		if r.err != nil {
+			return "", r.err
+		}
+		identifier, err := r.img.Identifier()
+		if err != nil {
+			return "", errors.Wrapf(err, "getting identifier of %s", style.Symbol(imageName))
+		}
+		digest, err := name.NewDigest(identifier.String(), name.WeakValidation)
+		if err != nil {
+			return "", errors.Wrapf(err, "parsing digest of %s", style.Symbol(imageName))
+		}
+		return digest.DigestStr(), nil
+	}
+}
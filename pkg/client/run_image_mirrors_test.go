@@ -0,0 +1,146 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/fakes"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/internal/builder"
+	ifakes "github.com/buildpacks/pack/internal/fakes"
+	"github.com/buildpacks/pack/pkg/image"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestRunImageMirrors(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "run_image_mirrors", testRunImageMirrors, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+// blockingImageFetcher never responds for the images in blocked, until the context is done.
+type blockingImageFetcher struct {
+	*ifakes.FakeImageFetcher
+
+	blocked map[string]bool
+}
+
+func (f *blockingImageFetcher) Fetch(ctx context.Context, name string, options image.FetchOptions) (imgutil.Image, error) {
+	if f.blocked[name] {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return f.FakeImageFetcher.Fetch(ctx, name, options)
+}
+
+func testRunImageMirrors(t *testing.T, when spec.G, it spec.S) {
+	const (
+		digestA = "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+		digestB = "sha256:bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"
+	)
+
+	var (
+		fetcher *blockingImageFetcher
+		subject *Client
+		out     bytes.Buffer
+	)
+
+	var addRemoteImage = func(name, digest string) {
+		fetcher.RemoteImages[name] = fakes.NewImage(name, "", &fakeIdentifier{name: name + "@" + digest})
+	}
+
+	it.Before(func() {
+		fetcher = &blockingImageFetcher{FakeImageFetcher: ifakes.NewFakeImageFetcher(), blocked: map[string]bool{}}
+		addRemoteImage("registry.example.com/some/run", digestA)
+		addRemoteImage("mirror-1.example.com/some/run", digestA)
+		addRemoteImage("mirror-2.example.com/some/run", digestB)
+
+		subject = &Client{
+			logger:       logging.NewLogWithWriters(&out, &out),
+			imageFetcher: fetcher,
+		}
+	})
+
+	when("#VerifyRunImageMirrors", func() {
+		it("reports whether each mirror has the digest of its run image", func() {
+			fetcher.blocked["mirror-3.example.com/some/run"] = true
+
+			reports, err := subject.VerifyRunImageMirrors(context.TODO(), VerifyRunImageMirrorsOptions{
+				Mirrors: map[string][]string{
+					"registry.example.com/some/run": {
+						"mirror-1.example.com/some/run",
+						"mirror-2.example.com/some/run",
+						"mirror-3.example.com/some/run",
+					},
+				},
+				Timeout: 10 * time.Millisecond,
+			})
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, len(reports), 1)
+			h.AssertEq(t, reports[0].RunImage, "registry.example.com/some/run")
+			h.AssertEq(t, reports[0].Digest, digestA)
+			h.AssertEq(t, reports[0].Mirrors[0], RunImageMirrorInfo{Mirror: "mirror-1.example.com/some/run", Digest: digestA, Status: RunImageMirrorInSync})
+			h.AssertEq(t, reports[0].Mirrors[1], RunImageMirrorInfo{Mirror: "mirror-2.example.com/some/run", Digest: digestB, Status: RunImageMirrorStale})
+			h.AssertEq(t, reports[0].Mirrors[2].Status, RunImageMirrorUnreachable)
+			h.AssertEq(t, reports[0].Mirrors[2].Error, "timed out after 10ms")
+		})
+
+		it("reports mirrors as unreachable when the run image can't be fetched", func() {
+			reports, err := subject.VerifyRunImageMirrors(context.TODO(), VerifyRunImageMirrorsOptions{
+				Mirrors: map[string][]string{"missing.example.com/some/run": {"mirror-1.example.com/some/run"}},
+			})
+			h.AssertNil(t, err)
+
+			h.AssertContains(t, reports[0].Error, "does not exist in registry")
+			h.AssertEq(t, reports[0].Mirrors[0].Status, RunImageMirrorUnreachable)
+			h.AssertEq(t, reports[0].Mirrors[0].Error, "run image could not be fetched")
+		})
+	})
+
+	when("#resolveVerifiedRunImage", func() {
+		var runImageMetadata = builder.RunImageMetadata{
+			Image: "registry.example.com/some/run",
+			Mirrors: []string{
+				"mirror-2.example.com/some/run",
+				"mirror-3.example.com/some/run",
+				"mirror-1.example.com/some/run",
+			},
+		}
+
+		it("prefers the mirror on the image registry when its digest matches", func() {
+			selected := subject.resolveVerifiedRunImage(context.TODO(), "mirror-1.example.com", "", runImageMetadata, nil, true, nil, time.Second)
+			h.AssertEq(t, selected, "mirror-1.example.com/some/run")
+			h.AssertContains(t, out.String(), "Selected run image mirror 'mirror-1.example.com/some/run', its digest matches run image 'registry.example.com/some/run'")
+		})
+
+		it("skips stale mirrors and mirrors that time out", func() {
+			fetcher.blocked["mirror-3.example.com/some/run"] = true
+
+			selected := subject.resolveVerifiedRunImage(context.TODO(), "other.example.com", "", runImageMetadata, map[string][]string{
+				"registry.example.com/some/run": {"mirror-2.example.com/some/run", "mirror-3.example.com/some/run"},
+			}, true, nil, 10*time.Millisecond)
+
+			h.AssertEq(t, selected, "registry.example.com/some/run")
+			h.AssertContains(t, out.String(), "Skipping run image mirror 'mirror-2.example.com/some/run': digest '"+digestB+"' does not match")
+			h.AssertContains(t, out.String(), "Skipping run image mirror 'mirror-3.example.com/some/run': timed out after 10ms")
+			h.AssertContains(t, out.String(), "Selected run image 'registry.example.com/some/run'")
+		})
+
+		it("fails over to a responding mirror when the run image times out", func() {
+			fetcher.blocked["registry.example.com/some/run"] = true
+
+			selected := subject.resolveVerifiedRunImage(context.TODO(), "registry.example.com", "", runImageMetadata, nil, true, nil, 10*time.Millisecond)
+
+			h.AssertEq(t, selected, "mirror-2.example.com/some/run")
+			h.AssertContains(t, out.String(), "run image 'registry.example.com/some/run' could not be fetched: timed out after 10ms")
+		})
+	})
+}
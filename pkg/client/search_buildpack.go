@@ -0,0 +1,112 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/buildpack"
+)
+
+// RegistryBuildpackVersion is a version of a buildpack in a buildpack registry.
+type RegistryBuildpackVersion struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+	Address string `json:"address"`
+	Yanked  bool   `json:"yanked"`
+}
+
+// SearchBuildpacksOptions is a configuration struct that controls the SearchBuildpacks function.
+type SearchBuildpacksOptions struct {
+	// Text to look for in the <namespace>/<name> of buildpacks, matched case-insensitively.
+	Term string
+
+	// Name of the buildpack registry to search. Defaults to the official registry.
+	Registry string
+}
+
+// BuildpackVersionsOptions is a configuration struct that controls the BuildpackVersions function.
+type BuildpackVersionsOptions struct {
+	// ID of the buildpack, as <namespace>/<name>.
+	ID string
+
+	// Name of the buildpack registry to look in. Defaults to the official registry.
+	Registry string
+}
+
+// SearchBuildpacks returns the latest version, excluding yanked versions, of each buildpack in the registry whose id
+// contains the search term, sorted by id. Buildpacks with only yanked versions are skipped.
+func (c *Client) SearchBuildpacks(opts SearchBuildpacksOptions) ([]RegistryBuildpackVersion, error) {
+	registryIndex, err := getRegistry(c.logger, opts.Registry)
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry %s: %q", opts.Registry, err)
+	}
+
+	entries, err := registryIndex.Entries()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading registry entries")
+	}
+
+	term := strings.ToLower(opts.Term)
+	var results []RegistryBuildpackVersion
+	for _, entry := range entries {
+		versions := sortedVersions(entry)
+		if len(versions) == 0 || !strings.Contains(strings.ToLower(versions[0].ID), term) {
+			continue
+		}
+		for _, v := range versions {
+			if !v.Yanked {
+				results = append(results, v)
+				break
+			}
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].ID < results[j].ID
+	})
+	return results, nil
+}
+
+// BuildpackVersions returns every version of a buildpack in the registry, including yanked versions, from the
+// highest to the lowest.
+func (c *Client) BuildpackVersions(opts BuildpackVersionsOptions) ([]RegistryBuildpackVersion, error) {
+	registryIndex, err := getRegistry(c.logger, opts.Registry)
+	if err != nil {
+		return nil, fmt.Errorf("invalid registry %s: %q", opts.Registry, err)
+	}
+
+	entry, err := registryIndex.LookupEntry(opts.ID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "looking up %s", style.Symbol(opts.ID))
+	}
+
+	versions := sortedVersions(entry)
+	if len(versions) == 0 {
+		return nil, errors.Errorf("no versions of %s were found", style.Symbol(opts.ID))
+	}
+	return versions, nil
+}
+
+// sortedVersions returns the versions of a registry entry from the highest to the lowest. Versions that are not
+// valid semver sort last, by their text.
+func sortedVersions(entry registry.Entry) []RegistryBuildpackVersion {
+	var versions []RegistryBuildpackVersion
+	for _, bp := range entry.Buildpacks {
+		versions = append(versions, RegistryBuildpackVersion{
+			ID:      bp.Namespace + "/" + bp.Name,
+			Version: bp.Version,
+			Address: bp.Address,
+			Yanked:  bp.Yanked,
+		})
+	}
+
+	sort.SliceStable(versions, func(i, j int) bool {
+		return buildpack.CompareVersions(versions[i].Version, versions[j].Version) > 0
+	})
+	return versions
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	cfg "github.com/buildpacks/pack/internal/config"
+	"github.com/buildpacks/pack/internal/registry"
+	"github.com/buildpacks/pack/pkg/logging"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestSearchBuildpacks(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "search_buildpack", testSearchBuildpacks, spec.Report(report.Terminal{}))
+}
+
+func testSearchBuildpacks(t *testing.T, when spec.G, it spec.S) {
+	var (
+		subject *Client
+		tmpDir  string
+		out     bytes.Buffer
+	)
+
+	var register = func(index *registry.DirIndex, id, version string, yanked bool) {
+		ns, name, err := registry.ParseNamespaceName(id)
+		h.AssertNil(t, err)
+		h.AssertNil(t, index.Register(registry.Buildpack{
+			Namespace: ns,
+			Name:      name,
+			Version:   version,
+			Address:   "example.com/" + id + "@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566",
+		}))
+		if yanked {
+			h.AssertNil(t, index.Yank(registry.Buildpack{Namespace: ns, Name: name, Version: version, Yanked: true}))
+		}
+	}
+
+	it.Before(func() {
+		var err error
+		tmpDir, err = os.MkdirTemp("", "search-buildpacks")
+		h.AssertNil(t, err)
+
+		logger := logging.NewLogWithWriters(&out, &out)
+		subject = &Client{logger: logger}
+
+		registryDir := filepath.Join(tmpDir, "registry-index")
+		packHome := filepath.Join(tmpDir, "packHome")
+		h.AssertNil(t, os.Setenv("PACK_HOME", packHome))
+		h.AssertNil(t, cfg.Write(cfg.Config{
+			Registries: []cfg.Registry{{Name: "private", Type: "dir", URL: registryDir}},
+		}, filepath.Join(packHome, "config.toml")))
+
+		index, err := registry.NewDirIndex(logger, registryDir)
+		h.AssertNil(t, err)
+		register(index, "example/nodejs", "1.9.0", false)
+		register(index, "example/nodejs", "1.10.0", false)
+		register(index, "example/nodejs", "2.0.0", true)
+		register(index, "other/node-engine", "0.1.0", false)
+		register(index, "example/java", "1.0.0", false)
+		register(index, "example/retired-node", "1.0.0", true)
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.Unsetenv("PACK_HOME"))
+		h.AssertNil(t, os.RemoveAll(tmpDir))
+	})
+
+	when("#SearchBuildpacks", func() {
+		it("returns the latest version that is not yanked of matching buildpacks", func() {
+			results, err := subject.SearchBuildpacks(SearchBuildpacksOptions{Term: "NODE", Registry: "private"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, results, []RegistryBuildpackVersion{
+				{ID: "example/nodejs", Version: "1.10.0", Address: "example.com/example/nodejs@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566"},
+				{ID: "other/node-engine", Version: "0.1.0", Address: "example.com/other/node-engine@sha256:8c27fe111c11b722081701dfed3bd55e039b9ce92865473cf4cdfa918071c566"},
+			})
+		})
+
+		it("returns nothing when no buildpacks match", func() {
+			results, err := subject.SearchBuildpacks(SearchBuildpacksOptions{Term: "ruby", Registry: "private"})
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(results), 0)
+		})
+
+		it("errors for an unknown registry", func() {
+			_, err := subject.SearchBuildpacks(SearchBuildpacksOptions{Term: "node", Registry: "missing"})
+			h.AssertError(t, err, "registry 'missing' is not defined in your config file")
+		})
+	})
+
+	when("#BuildpackVersions", func() {
+		it("returns every version from the highest to the lowest", func() {
+			versions, err := subject.BuildpackVersions(BuildpackVersionsOptions{ID: "example/nodejs", Registry: "private"})
+			h.AssertNil(t, err)
+
+			var listed []string
+			for _, v := range versions {
+				listed = append(listed, v.Version)
+			}
+			h.AssertEq(t, listed, []string{"2.0.0", "1.10.0", "1.9.0"})
+			h.AssertTrue(t, versions[0].Yanked)
+			h.AssertFalse(t, versions[1].Yanked)
+		})
+
+		it("errors for a missing buildpack", func() {
+			_, err := subject.BuildpackVersions(BuildpackVersionsOptions{ID: "example/ruby", Registry: "private"})
+			h.AssertError(t, err, "looking up 'example/ruby'")
+		})
+	})
+}
@@ -0,0 +1,10 @@
+#!/usr/bin/env bash
+
+# Compiles the buildpack into bin/build, with bin/detect linked to the same binary
+set -euo pipefail
+
+cd "$(dirname "$0")/.."
+
+mkdir -p bin
+GOOS=linux CGO_ENABLED=0 go build -o bin/build .
+ln -sf build bin/detect
@@ -0,0 +1,649 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/imgutil"
+	lbuildpack "github.com/buildpacks/lifecycle/buildpack"
+	"github.com/docker/docker/api/types"
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/container"
+	"github.com/buildpacks/pack/internal/layer"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+const (
+	// ExpectationsFile is read from each fixture app to know the expected outcome of running the buildpack against it
+	ExpectationsFile = "expect.toml"
+
+	testDir         = "/cnbtest"
+	testAppDir      = "/workspace"
+	detectExitFail  = 100
+	detectStatusErr = "error"
+)
+
+// TestBuildpackOptions defines configuration settings for TestBuildpack.
+type TestBuildpackOptions struct {
+	// Path to the buildpack directory.
+	BuildpackPath string
+
+	// Path to a directory with one fixture app per subdirectory.
+	FixturesPath string
+
+	// Image bin/detect and bin/build are run in.
+	BuildImage string
+
+	// Strategy for pulling the build image.
+	PullPolicy image.PullPolicy
+}
+
+// BuildpackTestExpectations are the expected outcome of running a buildpack against a fixture app.
+type BuildpackTestExpectations struct {
+	// Either pass or fail, defaults to pass. Build expectations are only checked when detection passes.
+	Detect string `toml:"detect"`
+
+	// Names of the layers the buildpack creates.
+	Layers []string `toml:"layers"`
+
+	// Types of the processes the buildpack writes to launch.toml.
+	Processes []string `toml:"processes"`
+
+	// Environment variables the buildpack writes to its layers, along with their values.
+	Env map[string]string `toml:"env"`
+}
+
+// BuildpackTestResult is the outcome of running a buildpack against a single fixture app.
+type BuildpackTestResult struct {
+	Fixture  string
+	Duration time.Duration
+
+	// Expectations that were not met. Empty if the test passed.
+	Failures []string
+
+	// Output of bin/detect and bin/build.
+	Output string
+}
+
+// Passed returns true if all expectations were met.
+func (r BuildpackTestResult) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// BuildpackTestReport is the outcome of running a buildpack against all fixture apps.
+type BuildpackTestReport struct {
+	Buildpack dist.ModuleInfo
+	Results   []BuildpackTestResult
+}
+
+// Failed returns the number of fixtures that did not meet their expectations.
+func (r *BuildpackTestReport) Failed() int {
+	failed := 0
+	for _, result := range r.Results {
+		if !result.Passed() {
+			failed++
+		}
+	}
+
+	return failed
+}
+
+// TestBuildpack runs bin/detect and bin/build of a buildpack against each fixture app in the build image, and checks
+// the outcome against the expectations of the fixture.
+func (c *Client) TestBuildpack(ctx context.Context, opts TestBuildpackOptions) (*BuildpackTestReport, error) {
+	writerFactory, err := layer.NewWriterFactory("linux")
+	if err != nil {
+		return nil, err
+	}
+
+	bp, err := buildpack.FromBuildpackRootBlob(blob.NewBlob(opts.BuildpackPath), writerFactory, c.logger)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading buildpack %s", style.Symbol(opts.BuildpackPath))
+	}
+
+	buildImage, err := c.imageFetcher.Fetch(ctx, opts.BuildImage, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching build image %s", style.Symbol(opts.BuildImage))
+	}
+
+	uid, gid, err := buildImageUser(buildImage)
+	if err != nil {
+		return nil, err
+	}
+
+	fixtures, err := readFixtures(opts.FixturesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	runner := &buildpackTestRunner{
+		client:     c,
+		buildpack:  bp,
+		buildImage: buildImage.Name(),
+		uid:        uid,
+		gid:        gid,
+	}
+
+	report := &BuildpackTestReport{Buildpack: bp.Descriptor().Info()}
+	for _, fixture := range fixtures {
+		c.logger.Debugf("Testing fixture %s", style.Symbol(fixture))
+
+		start := time.Now()
+		result, err := runner.run(ctx, filepath.Join(opts.FixturesPath, fixture))
+		if err != nil {
+			return nil, errors.Wrapf(err, "testing fixture %s", style.Symbol(fixture))
+		}
+		result.Fixture = fixture
+		result.Duration = time.Since(start)
+
+		report.Results = append(report.Results, result)
+	}
+
+	return report, nil
+}
+
+func buildImageUser(img imgutil.Image) (int, int, error) {
+	var ids []int
+	for _, key := range []string{"CNB_USER_ID", "CNB_GROUP_ID"} {
+		value, err := img.Env(key)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, 0, errors.Errorf("build image must set %s to a numeric id, found %s", style.Symbol(key), style.Symbol(value))
+		}
+		ids = append(ids, id)
+	}
+
+	return ids[0], ids[1], nil
+}
+
+func readFixtures(fixturesPath string) ([]string, error) {
+	entries, err := os.ReadDir(fixturesPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading fixtures from %s", style.Symbol(fixturesPath))
+	}
+
+	var fixtures []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			fixtures = append(fixtures, entry.Name())
+		}
+	}
+	if len(fixtures) == 0 {
+		return nil, errors.Errorf("no fixtures found in %s", style.Symbol(fixturesPath))
+	}
+	sort.Strings(fixtures)
+
+	return fixtures, nil
+}
+
+// ReadBuildpackTestExpectations reads the expectations of a fixture app, which default to passing detection.
+func ReadBuildpackTestExpectations(fixturePath string) (BuildpackTestExpectations, error) {
+	var expectations BuildpackTestExpectations
+	if _, err := toml.DecodeFile(filepath.Join(fixturePath, ExpectationsFile), &expectations); err != nil && !os.IsNotExist(err) {
+		return BuildpackTestExpectations{}, errors.Wrapf(err, "reading %s", style.Symbol(ExpectationsFile))
+	}
+
+	switch expectations.Detect {
+	case "":
+		expectations.Detect = string(DetectStatusPass)
+	case string(DetectStatusPass), string(DetectStatusFail):
+	default:
+		return BuildpackTestExpectations{}, errors.Errorf("invalid detect expectation %s, must be one of %s or %s",
+			style.Symbol(expectations.Detect), style.Symbol(string(DetectStatusPass)), style.Symbol(string(DetectStatusFail)))
+	}
+
+	return expectations, nil
+}
+
+type buildpackTestRunner struct {
+	client     *Client
+	buildpack  buildpack.BuildModule
+	buildImage string
+	uid, gid   int
+
+	// build environment variables provided in the platform directory
+	env map[string]string
+}
+
+func (r *buildpackTestRunner) run(ctx context.Context, fixturePath string) (result BuildpackTestResult, err error) {
+	expectations, err := ReadBuildpackTestExpectations(fixturePath)
+	if err != nil {
+		return result, err
+	}
+
+	output := &bytes.Buffer{}
+	defer func() {
+		result.Output = output.String()
+	}()
+
+	var planFile []byte
+	detectCode, err := r.runPhase(ctx, "detect", fixturePath, nil, output,
+		[]string{path.Join(testDir, "platform"), path.Join(testDir, "plan.toml")},
+		[]string{"CNB_PLATFORM_DIR=" + path.Join(testDir, "platform"), "CNB_BUILD_PLAN_PATH=" + path.Join(testDir, "plan.toml")},
+		build.CopyOut(func(rc io.ReadCloser) (readErr error) {
+			defer rc.Close()
+			planFile, readErr = readTarFile(rc, "plan.toml")
+			return readErr
+		}, path.Join(testDir, "plan.toml")),
+	)
+	if err != nil {
+		return result, err
+	}
+
+	detect := detectOutcome(detectCode)
+	if detect != expectations.Detect {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected detect to %s, but it exited with status code %d", expectations.Detect, detectCode))
+		return result, nil
+	}
+	if detect != string(DetectStatusPass) {
+		return result, nil
+	}
+
+	bpPlan, err := resolveBuildpackPlan(planFile)
+	if err != nil {
+		result.Failures = append(result.Failures, err.Error())
+		return result, nil
+	}
+
+	var layers map[string][]byte
+	buildCode, err := r.runPhase(ctx, "build", fixturePath, bpPlan, output,
+		[]string{path.Join(testDir, "layers"), path.Join(testDir, "platform"), path.Join(testDir, "bpplan.toml")},
+		[]string{
+			"CNB_LAYERS_DIR=" + path.Join(testDir, "layers"),
+			"CNB_PLATFORM_DIR=" + path.Join(testDir, "platform"),
+			"CNB_BP_PLAN_PATH=" + path.Join(testDir, "bpplan.toml"),
+		},
+		build.CopyOut(func(rc io.ReadCloser) (readErr error) {
+			defer rc.Close()
+			layers, readErr = readTarFiles(rc)
+			return readErr
+		}, path.Join(testDir, "layers")),
+	)
+	if err != nil {
+		return result, err
+	}
+	if buildCode != 0 {
+		result.Failures = append(result.Failures, fmt.Sprintf("expected build to pass, but it exited with status code %d", buildCode))
+		return result, nil
+	}
+
+	result.Failures = checkBuildExpectations(expectations, layers)
+	return result, nil
+}
+
+// runPhase runs bin/<phase> of the buildpack in a new container, with the fixture app as the working directory, and
+// returns its exit code
+func (r *buildpackTestRunner) runPhase(ctx context.Context, phase, fixturePath string, bpPlan *lbuildpack.Plan, output io.Writer, args, env []string, postOp build.ContainerOperation) (int64, error) {
+	info := r.buildpack.Descriptor().Info()
+	modulesDir, dirEnv := dist.BuildpacksDir, "CNB_BUILDPACK_DIR"
+	if r.buildpack.Descriptor().Kind() == buildpack.KindExtension {
+		modulesDir, dirEnv = dist.ExtensionsDir, "CNB_EXTENSION_DIR"
+	}
+	bpDir := path.Join(modulesDir, r.buildpack.Descriptor().EscapedID(), info.Version)
+
+	tmpDir, err := os.MkdirTemp("", "pack.buildpack.test")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := prepareTestDir(tmpDir, bpPlan, r.env); err != nil {
+		return 0, err
+	}
+
+	ctr, err := r.client.docker.ContainerCreate(ctx, &dcontainer.Config{
+		Image:      r.buildImage,
+		User:       fmt.Sprintf("%d:%d", r.uid, r.gid),
+		Entrypoint: []string{path.Join(bpDir, "bin", phase)},
+		Cmd:        args,
+		Env:        append(env, dirEnv+"="+bpDir),
+		WorkingDir: testAppDir,
+		Labels:     map[string]string{"author": "pack"},
+	}, &dcontainer.HostConfig{}, nil, nil, "")
+	if err != nil {
+		return 0, errors.Wrapf(err, "creating %s container", phase)
+	}
+	defer r.client.docker.ContainerRemove(context.Background(), ctr.ID, dcontainer.RemoveOptions{Force: true})
+
+	ops := []build.ContainerOperation{
+		r.copyBuildpack,
+		build.CopyDir(fixturePath, testAppDir, r.uid, r.gid, "linux", true, func(relPath string) bool {
+			return relPath != ExpectationsFile
+		}),
+		build.CopyDir(tmpDir, testDir, r.uid, r.gid, "linux", true, nil),
+	}
+	for _, op := range ops {
+		if err := op(r.client.docker, ctx, ctr.ID, output, output); err != nil {
+			return 0, err
+		}
+	}
+
+	var exitCode int64
+	if err := container.RunWithHandler(ctx, r.client.docker, ctr.ID, exitCodeHandler(output, &exitCode)); err != nil {
+		return 0, errors.Wrapf(err, "running %s", phase)
+	}
+
+	if exitCode == 0 {
+		if err := postOp(r.client.docker, ctx, ctr.ID, output, output); err != nil {
+			return 0, err
+		}
+	}
+
+	return exitCode, nil
+}
+
+func (r *buildpackTestRunner) copyBuildpack(ctrClient build.DockerClient, ctx context.Context, containerID string, _, _ io.Writer) error {
+	rc, err := r.buildpack.Open()
+	if err != nil {
+		return errors.Wrap(err, "opening buildpack")
+	}
+	defer rc.Close()
+
+	return ctrClient.CopyToContainer(ctx, containerID, "/", rc, types.CopyToContainerOptions{})
+}
+
+func prepareTestDir(dir string, bpPlan *lbuildpack.Plan, env map[string]string) error {
+	for _, subDir := range []string{"layers", "output", filepath.Join("platform", "env")} {
+		// The following line's comment is for gosec, it will ignore rule 301 in this case
+		// G301: Expect directory permissions to be 0750 or less
+		/* #nosec G301 */
+		if err := os.MkdirAll(filepath.Join(dir, subDir), 0755); err != nil {
+			return err
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "plan.toml"), nil, 0600); err != nil {
+		return err
+	}
+
+	for name, value := range env {
+		if err := os.WriteFile(filepath.Join(dir, "platform", "env", name), []byte(value), 0600); err != nil {
+			return err
+		}
+	}
+
+	if bpPlan == nil {
+		return nil
+	}
+
+	f, err := os.Create(filepath.Join(dir, "bpplan.toml"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return toml.NewEncoder(f).Encode(bpPlan)
+}
+
+func exitCodeHandler(output io.Writer, exitCode *int64) container.Handler {
+	return func(bodyChan <-chan dcontainer.WaitResponse, errChan <-chan error, reader io.Reader) error {
+		copyErr := make(chan error)
+		go func() {
+			_, err := stdcopy.StdCopy(output, output, reader)
+			copyErr <- err
+		}()
+
+		select {
+		case body := <-bodyChan:
+			*exitCode = body.StatusCode
+		case err := <-errChan:
+			return err
+		}
+
+		return <-copyErr
+	}
+}
+
+func detectOutcome(exitCode int64) string {
+	switch exitCode {
+	case 0:
+		return string(DetectStatusPass)
+	case detectExitFail:
+		return string(DetectStatusFail)
+	default:
+		return detectStatusErr
+	}
+}
+
+// resolveBuildpackPlan returns the entries of the first section of the build plan written by detect that the buildpack
+// satisfies on its own, as the buildpack runs without any other buildpack in the group
+func resolveBuildpackPlan(planFile []byte) (*lbuildpack.Plan, error) {
+	var plan lbuildpack.BuildPlan
+	if _, err := toml.Decode(string(planFile), &plan); err != nil {
+		return nil, errors.Wrap(err, "reading build plan written by detect")
+	}
+
+	sections := []lbuildpack.PlanSections{plan.PlanSections}
+	for _, or := range plan.Or {
+		sections = append(sections, lbuildpack.PlanSections{Requires: or.Requires, Provides: or.Provides})
+	}
+
+	for _, section := range sections {
+		if planSectionSatisfied(section) {
+			return &lbuildpack.Plan{Entries: section.Requires}, nil
+		}
+	}
+
+	return nil, errors.New("detect passed, but no section of the build plan has requires and provides that match each other")
+}
+
+func planSectionSatisfied(section lbuildpack.PlanSections) bool {
+	provided := map[string]bool{}
+	for _, provide := range section.Provides {
+		provided[provide.Name] = true
+	}
+
+	required := map[string]bool{}
+	for _, require := range section.Requires {
+		if !provided[require.Name] {
+			return false
+		}
+		required[require.Name] = true
+	}
+
+	return len(required) == len(provided)
+}
+
+func checkBuildExpectations(expectations BuildpackTestExpectations, layers map[string][]byte) []string {
+	var failures []string
+
+	for _, name := range expectations.Layers {
+		if _, ok := layers[name+".toml"]; !ok {
+			failures = append(failures, fmt.Sprintf("expected layer %s to be created", style.Symbol(name)))
+		}
+	}
+
+	var launch struct {
+		Processes []struct {
+			Type string `toml:"type"`
+		} `toml:"processes"`
+	}
+	if _, err := toml.Decode(string(layers["launch.toml"]), &launch); err != nil {
+		failures = append(failures, fmt.Sprintf("reading launch.toml: %s", err))
+	}
+	for _, processType := range expectations.Processes {
+		found := false
+		for _, process := range launch.Processes {
+			found = found || process.Type == processType
+		}
+		if !found {
+			failures = append(failures, fmt.Sprintf("expected process %s in launch.toml", style.Symbol(processType)))
+		}
+	}
+
+	env := layerEnv(layers)
+	var names []string
+	for name := range expectations.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values, ok := env[name]
+		switch {
+		case !ok:
+			failures = append(failures, fmt.Sprintf("expected environment variable %s to be written", style.Symbol(name)))
+		case !contains(values, expectations.Env[name]):
+			failures = append(failures, fmt.Sprintf("expected environment variable %s to be %s, found %s",
+				style.Symbol(name), style.Symbol(expectations.Env[name]), style.Symbol(strings.Join(values, ", "))))
+		}
+	}
+
+	return failures
+}
+
+// layerEnv returns the values of environment variables written to the env directories of all layers
+func layerEnv(layers map[string][]byte) map[string][]string {
+	env := map[string][]string{}
+	for name, contents := range layers {
+		parts := strings.Split(name, "/")
+		if len(parts) < 3 || !strings.HasPrefix(parts[len(parts)-2], "env") {
+			continue
+		}
+
+		varName := parts[len(parts)-1]
+		if ext := path.Ext(varName); ext != "" {
+			varName = strings.TrimSuffix(varName, ext)
+		}
+		env[varName] = append(env[varName], strings.TrimSuffix(string(contents), "\n"))
+	}
+
+	return env
+}
+
+// readTarFiles returns the contents of the regular files of a tar copied out of a container, relative to its root directory
+func readTarFiles(r io.Reader) (map[string][]byte, error) {
+	result := map[string][]byte{}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		contents, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+
+		_, rel, _ := strings.Cut(path.Clean(header.Name), "/")
+		result[rel] = contents
+	}
+}
+
+func readTarFile(r io.Reader, name string) ([]byte, error) {
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, errors.Errorf("%s not found", style.Symbol(name))
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if path.Clean(header.Name) == name {
+			return io.ReadAll(tr)
+		}
+	}
+}
+
+// WriteJUnit writes the report in JUnit XML format.
+func (r *BuildpackTestReport) WriteJUnit(w io.Writer) error {
+	suite := junitTestSuite{
+		Name:     r.Buildpack.FullName(),
+		Tests:    len(r.Results),
+		Failures: r.Failed(),
+	}
+
+	var total time.Duration
+	for _, result := range r.Results {
+		total += result.Duration
+		testCase := junitTestCase{
+			Name:      result.Fixture,
+			ClassName: r.Buildpack.ID,
+			Time:      seconds(result.Duration),
+			SystemOut: result.Output,
+		}
+		if !result.Passed() {
+			testCase.Failure = &junitFailure{
+				Message: result.Failures[0],
+				Text:    strings.Join(result.Failures, "\n"),
+			}
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	suite.Time = seconds(total)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
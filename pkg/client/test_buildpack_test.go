@@ -0,0 +1,130 @@
+package client
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/dist"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestTestBuildpack(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "TestBuildpack", testTestBuildpack, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testTestBuildpack(t *testing.T, when spec.G, it spec.S) {
+	when("#resolveBuildpackPlan", func() {
+		it("uses the first section the buildpack satisfies on its own", func() {
+			plan, err := resolveBuildpackPlan([]byte(`
+[[requires]]
+name = "node"
+
+[[or]]
+[[or.provides]]
+name = "node"
+
+[[or.requires]]
+name = "node"
+version = "18.x"
+`))
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(plan.Entries), 1)
+			h.AssertEq(t, plan.Entries[0].Name, "node")
+			h.AssertEq(t, plan.Entries[0].Version, "18.x")
+		})
+
+		it("passes with an empty plan", func() {
+			plan, err := resolveBuildpackPlan(nil)
+			h.AssertNil(t, err)
+			h.AssertEq(t, len(plan.Entries), 0)
+		})
+
+		it("errors when provides are not required", func() {
+			_, err := resolveBuildpackPlan([]byte("[[provides]]\nname = \"node\"\n"))
+			h.AssertError(t, err, "no section of the build plan")
+		})
+	})
+
+	when("#checkBuildExpectations", func() {
+		var layers map[string][]byte
+
+		it.Before(func() {
+			layers = map[string][]byte{
+				"my-layer.toml":                         []byte("[types]\nlaunch = true\n"),
+				"my-layer/env.launch/BUILT_BY.default":  []byte("some/bp"),
+				"my-layer/env/PATH.append":              []byte("/some/bin\n"),
+				"my-layer/env.launch/web/ONLY_WEB.file": []byte("ignored"),
+				"launch.toml":                           []byte("[[processes]]\ntype = \"web\"\ncommand = \"run\"\n"),
+			}
+		})
+
+		it("passes when all expectations are met", func() {
+			failures := checkBuildExpectations(BuildpackTestExpectations{
+				Layers:    []string{"my-layer"},
+				Processes: []string{"web"},
+				Env:       map[string]string{"BUILT_BY": "some/bp", "PATH": "/some/bin"},
+			}, layers)
+			h.AssertEq(t, len(failures), 0)
+		})
+
+		it("reports each unmet expectation", func() {
+			failures := checkBuildExpectations(BuildpackTestExpectations{
+				Layers:    []string{"other-layer"},
+				Processes: []string{"worker"},
+				Env:       map[string]string{"BUILT_BY": "other/bp", "MISSING": "value", "ONLY_WEB": "ignored"},
+			}, layers)
+			h.AssertEq(t, failures, []string{
+				"expected layer 'other-layer' to be created",
+				"expected process 'worker' in launch.toml",
+				"expected environment variable 'BUILT_BY' to be 'other/bp', found 'some/bp'",
+				"expected environment variable 'MISSING' to be written",
+				"expected environment variable 'ONLY_WEB' to be written",
+			})
+		})
+	})
+
+	when("#ReadBuildpackTestExpectations", func() {
+		it("defaults to passing detection", func() {
+			expectations, err := ReadBuildpackTestExpectations(t.TempDir())
+			h.AssertNil(t, err)
+			h.AssertEq(t, expectations.Detect, "pass")
+		})
+
+		it("errors on an invalid detect expectation", func() {
+			dir := t.TempDir()
+			h.AssertNil(t, os.WriteFile(filepath.Join(dir, ExpectationsFile), []byte(`detect = "maybe"`), 0600))
+
+			_, err := ReadBuildpackTestExpectations(dir)
+			h.AssertError(t, err, "invalid detect expectation 'maybe'")
+		})
+	})
+
+	when("#WriteJUnit", func() {
+		it("writes a test case per fixture", func() {
+			testReport := &BuildpackTestReport{
+				Buildpack: dist.ModuleInfo{ID: "some/bp", Version: "1.2.3"},
+				Results: []BuildpackTestResult{
+					{Fixture: "app-a", Duration: 1500 * time.Millisecond, Output: "---> some/bp"},
+					{Fixture: "app-b", Duration: time.Second, Failures: []string{"expected detect to pass, but it exited with status code 100"}},
+				},
+			}
+
+			buf := &bytes.Buffer{}
+			h.AssertNil(t, testReport.WriteJUnit(buf))
+
+			h.AssertContains(t, buf.String(), `<testsuite name="some/bp@1.2.3" tests="2" failures="1" time="2.500">`)
+			h.AssertContains(t, buf.String(), `<testcase name="app-a" classname="some/bp" time="1.500">`)
+			h.AssertContains(t, buf.String(), `<system-out>---&gt; some/bp</system-out>`)
+			h.AssertContains(t, buf.String(), `<failure message="expected detect to pass, but it exited with status code 100">`)
+		})
+	})
+}
@@ -0,0 +1,332 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/api"
+	lbuildpack "github.com/buildpacks/lifecycle/buildpack"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/build"
+	"github.com/buildpacks/pack/internal/layer"
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/blob"
+	"github.com/buildpacks/pack/pkg/buildpack"
+	"github.com/buildpacks/pack/pkg/dist"
+	"github.com/buildpacks/pack/pkg/image"
+)
+
+const (
+	buildDockerfile  = "build.Dockerfile"
+	runDockerfile    = "run.Dockerfile"
+	extendConfigFile = "extend-config.toml"
+
+	// directories generate can write the build context of the Dockerfiles to
+	sharedContextDir = "context"
+	buildContextDir  = "context.build"
+	runContextDir    = "context.run"
+
+	// runExtendAPI is the first buildpack API version where run args can be set in extend-config.toml
+	runExtendAPI = "0.10"
+)
+
+var contextDirs = []string{sharedContextDir, buildContextDir, runContextDir}
+
+// reservedBuildArgs are provided by the lifecycle, and can't be set in extend-config.toml
+var reservedBuildArgs = []string{"base_image", "build_id", "user_id", "group_id"}
+
+// ValidateExtensionOptions defines configuration settings for ValidateExtension.
+type ValidateExtensionOptions struct {
+	// Path to the extension directory.
+	ExtensionPath string
+
+	// Optional. Path to the app that bin/detect and bin/generate run against. Defaults to an empty app.
+	AppPath string
+
+	// Build environment variables provided to the extension in the platform directory.
+	Env map[string]string
+
+	// Image bin/detect and bin/generate are run in.
+	BuildImage string
+
+	// Strategy for pulling the build image.
+	PullPolicy image.PullPolicy
+}
+
+// ExtensionValidationReport is the outcome of validating an extension.
+type ExtensionValidationReport struct {
+	Extension dist.ModuleInfo
+
+	// Files written by bin/generate.
+	Generated []string
+
+	// Problems found with the extension or the files it generated. Empty if the extension is valid.
+	Problems []string
+
+	// Instructions of the generated Dockerfiles the lifecycle doesn't recommend. They don't make the extension invalid.
+	Warnings []string
+
+	// Output of bin/detect and bin/generate.
+	Output string
+}
+
+// Valid returns true if no problems were found.
+func (r *ExtensionValidationReport) Valid() bool {
+	return len(r.Problems) == 0
+}
+
+// ValidateExtension runs bin/detect and bin/generate of an extension in the build image, and checks the Dockerfiles
+// and extend-config.toml it generates against the requirements of the lifecycle.
+func (c *Client) ValidateExtension(ctx context.Context, opts ValidateExtensionOptions) (*ExtensionValidationReport, error) {
+	writerFactory, err := layer.NewWriterFactory("linux")
+	if err != nil {
+		return nil, err
+	}
+
+	ext, err := buildpack.FromExtensionRootBlob(blob.NewBlob(opts.ExtensionPath), writerFactory, c.logger)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading extension %s", style.Symbol(opts.ExtensionPath))
+	}
+
+	buildImage, err := c.imageFetcher.Fetch(ctx, opts.BuildImage, image.FetchOptions{Daemon: true, PullPolicy: opts.PullPolicy})
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching build image %s", style.Symbol(opts.BuildImage))
+	}
+
+	uid, gid, err := buildImageUser(buildImage)
+	if err != nil {
+		return nil, err
+	}
+
+	appPath := opts.AppPath
+	if appPath == "" {
+		if appPath, err = os.MkdirTemp("", "pack.extension.validate"); err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(appPath)
+	}
+
+	runner := &buildpackTestRunner{
+		client:     c,
+		buildpack:  ext,
+		buildImage: buildImage.Name(),
+		uid:        uid,
+		gid:        gid,
+		env:        opts.Env,
+	}
+
+	report := &ExtensionValidationReport{Extension: ext.Descriptor().Info()}
+	output := &bytes.Buffer{}
+	defer func() {
+		report.Output = output.String()
+	}()
+
+	var planFile []byte
+	detectCode, err := runner.runPhase(ctx, "detect", appPath, nil, output, nil,
+		[]string{"CNB_PLATFORM_DIR=" + path.Join(testDir, "platform"), "CNB_BUILD_PLAN_PATH=" + path.Join(testDir, "plan.toml")},
+		build.CopyOut(func(rc io.ReadCloser) (readErr error) {
+			defer rc.Close()
+			planFile, readErr = readTarFile(rc, "plan.toml")
+			return readErr
+		}, path.Join(testDir, "plan.toml")),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if detectCode != 0 {
+		report.Problems = append(report.Problems, fmt.Sprintf("detect exited with status code %d, so generate was not run", detectCode))
+		return report, nil
+	}
+
+	extPlan, err := providedPlan(planFile)
+	if err != nil {
+		report.Problems = append(report.Problems, err.Error())
+		return report, nil
+	}
+
+	var files map[string][]byte
+	generateCode, err := runner.runPhase(ctx, "generate", appPath, extPlan, output, nil,
+		[]string{
+			"CNB_OUTPUT_DIR=" + path.Join(testDir, "output"),
+			"CNB_PLATFORM_DIR=" + path.Join(testDir, "platform"),
+			"CNB_BP_PLAN_PATH=" + path.Join(testDir, "bpplan.toml"),
+		},
+		build.CopyOut(func(rc io.ReadCloser) (readErr error) {
+			defer rc.Close()
+			files, readErr = readTarFiles(rc)
+			return readErr
+		}, path.Join(testDir, "output")),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if generateCode != 0 {
+		report.Problems = append(report.Problems, fmt.Sprintf("generate exited with status code %d", generateCode))
+		return report, nil
+	}
+
+	for name := range files {
+		report.Generated = append(report.Generated, name)
+	}
+	sort.Strings(report.Generated)
+
+	report.Problems, report.Warnings, err = CheckExtensionOutput(ext.Descriptor().API(), files)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// providedPlan returns the entries the extension provides in the build plan written by detect, as the extension runs
+// without any buildpack requiring them
+func providedPlan(planFile []byte) (*lbuildpack.Plan, error) {
+	var plan lbuildpack.BuildPlan
+	if _, err := toml.Decode(string(planFile), &plan); err != nil {
+		return nil, errors.Wrap(err, "reading build plan written by detect")
+	}
+
+	extPlan := &lbuildpack.Plan{}
+	for _, provide := range plan.Provides {
+		extPlan.Entries = append(extPlan.Entries, lbuildpack.Require{Name: provide.Name})
+	}
+	return extPlan, nil
+}
+
+// CheckExtensionOutput checks the files written by bin/generate of an extension implementing the buildpack API version.
+// Dockerfiles are validated by the lifecycle, the same way the generator does, and the instructions the lifecycle
+// doesn't recommend are returned as warnings.
+func CheckExtensionOutput(extensionAPI *api.Version, files map[string][]byte) (problems []string, warnings []string, err error) {
+	outputDir, err := os.MkdirTemp("", "pack.extension.output")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "creating temp dir")
+	}
+	defer os.RemoveAll(outputDir)
+
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	contexts := map[string]bool{}
+	for _, name := range names {
+		contextDir, _, _ := strings.Cut(name, "/")
+		switch {
+		case name == buildDockerfile || name == runDockerfile:
+			dockerfile := filepath.Join(outputDir, name)
+			if err := os.WriteFile(dockerfile, files[name], 0600); err != nil {
+				return nil, nil, errors.Wrapf(err, "writing %s", name)
+			}
+			logger := &warningLogger{}
+			if err := validateDockerfile(name, dockerfile, logger); err != nil {
+				problems = append(problems, dockerfileProblem(name, err))
+			}
+			warnings = append(warnings, logger.warnings...)
+		case name == extendConfigFile:
+			problems = append(problems, checkExtendConfig(files[name], extensionAPI)...)
+		case contains(contextDirs, contextDir) && contextDir != name:
+			contexts[contextDir] = true
+		default:
+			problems = append(problems, fmt.Sprintf("%s: unexpected file, generate may only write %s, %s, %s and files in the %s directories",
+				name, buildDockerfile, runDockerfile, extendConfigFile, strings.Join(contextDirs, ", ")))
+		}
+	}
+
+	if contexts[sharedContextDir] && (contexts[buildContextDir] || contexts[runContextDir]) {
+		problems = append(problems, fmt.Sprintf("%s: the shared context directory can't be written together with %s or %s",
+			sharedContextDir, buildContextDir, runContextDir))
+	}
+	return problems, warnings, nil
+}
+
+func validateDockerfile(name, path string, logger *warningLogger) error {
+	if name == buildDockerfile {
+		return lbuildpack.ValidateBuildDockerfile(path, logger)
+	}
+	return lbuildpack.ValidateRunDockerfile(&lbuildpack.DockerfileInfo{Kind: lbuildpack.DockerfileKindRun, Path: path}, logger)
+}
+
+// dockerfileProblem names the Dockerfile in err, the lifecycle only does so for some errors
+func dockerfileProblem(name string, err error) string {
+	if strings.HasPrefix(err.Error(), name) {
+		return err.Error()
+	}
+	return fmt.Sprintf("%s: %s", name, err)
+}
+
+// warningLogger is a lifecycle logger that keeps the warnings it is given and discards everything else
+type warningLogger struct {
+	warnings []string
+}
+
+func (l *warningLogger) Debug(string)                  {}
+func (l *warningLogger) Debugf(string, ...interface{}) {}
+func (l *warningLogger) Info(string)                   {}
+func (l *warningLogger) Infof(string, ...interface{})  {}
+func (l *warningLogger) Error(string)                  {}
+func (l *warningLogger) Errorf(string, ...interface{}) {}
+
+func (l *warningLogger) Warn(msg string) {
+	l.warnings = append(l.warnings, msg)
+}
+
+func (l *warningLogger) Warnf(format string, v ...interface{}) {
+	l.warnings = append(l.warnings, fmt.Sprintf(format, v...))
+}
+
+type extendConfig struct {
+	Build extendPhaseConfig `toml:"build"`
+	Run   extendPhaseConfig `toml:"run"`
+}
+
+type extendPhaseConfig struct {
+	Args []extendArg `toml:"args"`
+}
+
+type extendArg struct {
+	Name  string `toml:"name"`
+	Value string `toml:"value"`
+}
+
+// checkExtendConfig checks extend-config.toml only sets build args that are not provided by the lifecycle
+func checkExtendConfig(contents []byte, extensionAPI *api.Version) []string {
+	var config extendConfig
+	md, err := toml.Decode(string(contents), &config)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: %s", extendConfigFile, err)}
+	}
+
+	var problems []string
+	for _, key := range md.Undecoded() {
+		problems = append(problems, fmt.Sprintf("%s: unknown key %s", extendConfigFile, style.Symbol(key.String())))
+	}
+
+	if len(config.Run.Args) > 0 && extensionAPI.LessThan(runExtendAPI) {
+		problems = append(problems, fmt.Sprintf("%s: run args require buildpack API %s or later, the extension implements %s",
+			extendConfigFile, runExtendAPI, extensionAPI.String()))
+	}
+
+	for _, phase := range []struct {
+		name string
+		args []extendArg
+	}{{"build", config.Build.Args}, {"run", config.Run.Args}} {
+		for i, arg := range phase.args {
+			switch {
+			case arg.Name == "":
+				problems = append(problems, fmt.Sprintf("%s: %s arg %d is missing a name", extendConfigFile, phase.name, i+1))
+			case contains(reservedBuildArgs, arg.Name):
+				problems = append(problems, fmt.Sprintf("%s: %s arg %s is provided by the lifecycle and can't be set", extendConfigFile, phase.name, style.Symbol(arg.Name)))
+			}
+		}
+	}
+	return problems
+}
@@ -0,0 +1,148 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/buildpacks/lifecycle/api"
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/client"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestValidateExtension(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+	spec.Run(t, "ValidateExtension", testValidateExtension, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testValidateExtension(t *testing.T, when spec.G, it spec.S) {
+	var (
+		warnings []string
+		check    = func(apiVersion string, files map[string]string) []string {
+			output := map[string][]byte{}
+			for name, contents := range files {
+				output[name] = []byte(contents)
+			}
+			problems, found, err := client.CheckExtensionOutput(api.MustParse(apiVersion), output)
+			h.AssertNil(t, err)
+			warnings = found
+			return problems
+		}
+	)
+
+	when("#CheckExtensionOutput", func() {
+		it("accepts Dockerfiles extending the build image and switching the run image", func() {
+			h.AssertEq(t, len(check("0.9", map[string]string{
+				"build.Dockerfile": `ARG base_image
+FROM ${base_image}
+
+# install packages
+USER root
+RUN apt-get update && \
+  apt-get install -y curl
+RUN <<SCRIPT
+CMD is not an instruction here
+SCRIPT
+ARG user_id
+USER ${user_id}
+`,
+				"run.Dockerfile": "FROM some/run-image:latest\n",
+				"extend-config.toml": `[[build.args]]
+name = "some_arg"
+value = "some-value"
+`,
+			})), 0)
+			h.AssertEq(t, len(warnings), 0)
+		})
+
+		it("accepts Dockerfiles extending the run image", func() {
+			h.AssertEq(t, len(check("0.10", map[string]string{
+				"run.Dockerfile": "ARG base_image\nFROM ${base_image}\nRUN true\n",
+			})), 0)
+		})
+
+		it("flags a missing ARG base_image", func() {
+			h.AssertEq(t, check("0.9", map[string]string{
+				"build.Dockerfile": "FROM ${base_image}\nRUN true\n",
+			}), []string{"build.Dockerfile did not start with required ARG command"})
+		})
+
+		it("flags more than one ARG before FROM", func() {
+			h.AssertEq(t, check("0.9", map[string]string{
+				"build.Dockerfile": "ARG base_image\nARG version\nFROM ${base_image}\n",
+			}), []string{"build.Dockerfile did not start with required ARG command"})
+		})
+
+		it("warns about instructions that are not recommended", func() {
+			h.AssertEq(t, len(check("0.9", map[string]string{
+				"build.Dockerfile": "ARG base_image\nFROM ${base_image}\nEXPOSE 8080\nCMD [\"run\"]\n",
+			})), 0)
+			h.AssertEq(t, warnings, []string{
+				"build.Dockerfile command EXPOSE on line 3 is not recommended",
+				"build.Dockerfile command CMD on line 4 is not recommended",
+			})
+		})
+
+		it("flags switching the build image and multi-stage builds", func() {
+			h.AssertEq(t, check("0.9", map[string]string{
+				"build.Dockerfile": "ARG base_image\nFROM ubuntu\n",
+			}), []string{"build.Dockerfile did not contain required FROM ${base_image} command"})
+
+			h.AssertEq(t, check("0.9", map[string]string{
+				"build.Dockerfile": "ARG base_image\nFROM golang AS builder\nFROM ${base_image}\n",
+			}), []string{"build.Dockerfile is not permitted to use multistage build"})
+		})
+
+		it("flags Dockerfiles that can't be parsed", func() {
+			problems := check("0.9", map[string]string{
+				"run.Dockerfile": "FROM some/run-image\nNOTANINSTRUCTION true\n",
+			})
+			h.AssertEq(t, len(problems), 1)
+			h.AssertContains(t, problems[0], "run.Dockerfile: ")
+		})
+
+		it("flags problems with extend-config.toml", func() {
+			h.AssertEq(t, check("0.9", map[string]string{
+				"extend-config.toml": `[[build.args]]
+value = "no-name"
+
+[[build.args]]
+name = "user_id"
+value = "0"
+
+[[run.args]]
+name = "some_arg"
+
+[build]
+unknown = true
+`,
+			}), []string{
+				"extend-config.toml: unknown key 'build.unknown'",
+				"extend-config.toml: run args require buildpack API 0.10 or later, the extension implements 0.9",
+				"extend-config.toml: build arg 1 is missing a name",
+				"extend-config.toml: build arg 'user_id' is provided by the lifecycle and can't be set",
+			})
+		})
+
+		it("accepts build contexts", func() {
+			h.AssertEq(t, len(check("0.10", map[string]string{
+				"build.Dockerfile":        "ARG base_image\nFROM ${base_image}\nCOPY some-file /some-file\n",
+				"context.build/some-file": "content",
+			})), 0)
+
+			h.AssertEq(t, check("0.10", map[string]string{
+				"context/some-file":      "content",
+				"context.run/other-file": "content",
+			}), []string{"context: the shared context directory can't be written together with context.build or context.run"})
+		})
+
+		it("flags unexpected files", func() {
+			h.AssertEq(t, check("0.9", map[string]string{"Dockerfile": "FROM ubuntu\n"}), []string{
+				"Dockerfile: unexpected file, generate may only write build.Dockerfile, run.Dockerfile, extend-config.toml and files in the context, context.build, context.run directories",
+			})
+		})
+	})
+}
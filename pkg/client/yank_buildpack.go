@@ -5,6 +5,7 @@ import (
 	"runtime"
 
 	"github.com/buildpacks/pack/internal/registry"
+	registrytypes "github.com/buildpacks/pack/registry"
 )
 
 // YankBuildpackOptions is a configuration struct that controls the Yanking a buildpack
@@ -15,6 +16,9 @@ type YankBuildpackOptions struct {
 	Type    string
 	URL     string
 	Yank    bool
+
+	// Name of the registry, used for registries that are written to directly (types "dir" and "oci").
+	Name string
 }
 
 // YankBuildpack marks a buildpack on the Buildpack Registry as 'yanked'. This forbids future
@@ -24,10 +28,6 @@ func (c *Client) YankBuildpack(opts YankBuildpackOptions) error {
 	if err != nil {
 		return err
 	}
-	issueURL, err := registry.GetIssueURL(opts.URL)
-	if err != nil {
-		return err
-	}
 
 	buildpack := registry.Buildpack{
 		Namespace: namespace,
@@ -36,6 +36,17 @@ func (c *Client) YankBuildpack(opts YankBuildpackOptions) error {
 		Yanked:    opts.Yank,
 	}
 
+	if opts.Type == registrytypes.TypeDir || opts.Type == registrytypes.TypeOCI {
+		return c.writeRegistry(opts.Name, func(index registry.WritableIndex) error {
+			return index.Yank(buildpack)
+		})
+	}
+
+	issueURL, err := registry.GetIssueURL(opts.URL)
+	if err != nil {
+		return err
+	}
+
 	issue, err := registry.CreateGithubIssue(buildpack)
 	if err != nil {
 		return err
@@ -21,6 +21,9 @@ type ModuleInfo struct {
 	Homepage    string    `toml:"homepage,omitempty" json:"homepage,omitempty" yaml:"homepage,omitempty"`
 	Keywords    []string  `toml:"keywords,omitempty" json:"keywords,omitempty" yaml:"keywords,omitempty"`
 	Licenses    []License `toml:"licenses,omitempty" json:"licenses,omitempty" yaml:"licenses,omitempty"`
+
+	// Deprecation is set when the author no longer recommends using this version of the module.
+	Deprecation *Deprecation `toml:"deprecation,omitempty" json:"deprecation,omitempty" yaml:"deprecation,omitempty"`
 }
 
 func (b ModuleInfo) FullName() string {
@@ -45,6 +48,27 @@ func (b ModuleInfo) Match(o ModuleInfo) bool {
 	return b.ID == o.ID && b.Version == o.Version
 }
 
+// Deprecation is the notice given by the author of a deprecated module
+type Deprecation struct {
+	// Message explains why the module is deprecated
+	Message string `toml:"message,omitempty" json:"message,omitempty" yaml:"message,omitempty"`
+
+	// Replacement is the id, optionally with a version, of the module to use instead
+	Replacement string `toml:"replacement,omitempty" json:"replacement,omitempty" yaml:"replacement,omitempty"`
+}
+
+// Notice describes the deprecation in a sentence, naming the replacement when there is one
+func (d Deprecation) Notice() string {
+	notice := "is deprecated"
+	if d.Message != "" {
+		notice += ": " + strings.TrimSuffix(d.Message, ".")
+	}
+	if d.Replacement != "" {
+		notice += fmt.Sprintf(", use %s instead", style.Symbol(d.Replacement))
+	}
+	return notice
+}
+
 type License struct {
 	Type string `toml:"type"`
 	URI  string `toml:"uri"`
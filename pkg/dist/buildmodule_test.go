@@ -100,4 +100,14 @@ func testBuildModule(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 	})
+	when("Deprecation#Notice", func() {
+		it("includes the message and replacement", func() {
+			deprecation := dist.Deprecation{Message: "No longer maintained.", Replacement: "other-id"}
+			h.AssertEq(t, deprecation.Notice(), "is deprecated: No longer maintained, use 'other-id' instead")
+		})
+
+		it("is short without a message or replacement", func() {
+			h.AssertEq(t, dist.Deprecation{}.Notice(), "is deprecated")
+		})
+	})
 }
@@ -0,0 +1,142 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/buildpacks/imgutil"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	ggcrlayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/dist"
+)
+
+const (
+	// OCILayoutPrefix references an image stored as an OCI image layout directory, e.g. oci:/path/to/layout
+	OCILayoutPrefix = "oci:"
+
+	// DockerArchivePrefix references an image stored as a tarball produced by `docker save`, e.g. docker-archive:/path/to/image.tar
+	DockerArchivePrefix = "docker-archive:"
+
+	archiveRepo = "pack.local/archive"
+)
+
+// IsArchiveReference returns true if the name references an image stored as an OCI layout or docker archive.
+func IsArchiveReference(name string) bool {
+	return strings.HasPrefix(name, OCILayoutPrefix) || strings.HasPrefix(name, DockerArchivePrefix)
+}
+
+// fetchArchiveImage loads an image stored as an OCI layout or docker archive into the daemon, under a name derived from
+// its digest so that loading the same image again is a no-op.
+func (f *Fetcher) fetchArchiveImage(ctx context.Context, ref string, target *dist.Target) (imgutil.Image, error) {
+	img, err := readArchiveImage(ref, target)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading digest of %s", style.Symbol(ref))
+	}
+
+	tag, err := name.NewTag(fmt.Sprintf("%s/%s:latest", archiveRepo, digest.Hex[:12]), name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	if loaded, err := f.fetchDaemonImage(tag.Name()); err == nil {
+		return loaded, nil
+	} else if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	f.logger.Debugf("Loading image %s into the daemon as %s", style.Symbol(ref), style.Symbol(tag.Name()))
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(tarball.Write(tag, img, pw))
+	}()
+
+	res, err := f.docker.ImageLoad(ctx, pr, true)
+	if err != nil {
+		pr.CloseWithError(err)
+		return nil, errors.Wrapf(err, "loading %s into the daemon", style.Symbol(ref))
+	}
+	defer res.Body.Close()
+
+	if _, err := io.Copy(io.Discard, res.Body); err != nil {
+		return nil, errors.Wrapf(err, "loading %s into the daemon", style.Symbol(ref))
+	}
+
+	return f.fetchDaemonImage(tag.Name())
+}
+
+func readArchiveImage(ref string, target *dist.Target) (v1.Image, error) {
+	switch {
+	case strings.HasPrefix(ref, OCILayoutPrefix):
+		path := strings.TrimPrefix(ref, OCILayoutPrefix)
+		index, err := ggcrlayout.ImageIndexFromPath(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading OCI layout %s", style.Symbol(path))
+		}
+
+		img, err := imageFromIndex(index, target)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading OCI layout %s", style.Symbol(path))
+		}
+
+		return img, nil
+	case strings.HasPrefix(ref, DockerArchivePrefix):
+		path := strings.TrimPrefix(ref, DockerArchivePrefix)
+		if _, err := os.Stat(path); err != nil {
+			return nil, errors.Wrapf(err, "reading docker archive %s", style.Symbol(path))
+		}
+
+		img, err := tarball.ImageFromPath(path, nil)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading docker archive %s", style.Symbol(path))
+		}
+
+		return img, nil
+	default:
+		return nil, errors.Errorf("%s is not an OCI layout or docker archive reference", style.Symbol(ref))
+	}
+}
+
+// imageFromIndex returns the first image of the index, or of its nested indexes, that matches the target
+func imageFromIndex(index v1.ImageIndex, target *dist.Target) (v1.Image, error) {
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, desc := range manifest.Manifests {
+		switch {
+		case desc.MediaType.IsIndex():
+			child, err := index.ImageIndex(desc.Digest)
+			if err != nil {
+				return nil, err
+			}
+			if img, err := imageFromIndex(child, target); err == nil {
+				return img, nil
+			}
+		case desc.MediaType.IsImage():
+			if target != nil && desc.Platform != nil && !desc.Platform.Satisfies(v1.Platform{OS: target.OS, Architecture: target.Arch, Variant: target.ArchVariant}) {
+				continue
+			}
+			return index.Image(desc.Digest)
+		}
+	}
+
+	if target != nil {
+		return nil, errors.Errorf("no image found for platform %s", style.Symbol(target.ValuesAsPlatform()))
+	}
+
+	return nil, errors.New("no image found")
+}
@@ -44,6 +44,17 @@ func WithRegistryMirrors(registryMirrors map[string]string) FetcherOption {
 	}
 }
 
+// RegistryMirrorRule redirects images matching a registry and repository, or repository prefix, to mirrors tried in order.
+type RegistryMirrorRule = pname.MirrorRule
+
+// WithRegistryMirrorRules supply ordered rules rewriting images to mirrors. The first rule matching an image is used,
+// and takes precedence over the mirrors supplied with WithRegistryMirrors.
+func WithRegistryMirrorRules(rules []RegistryMirrorRule) FetcherOption {
+	return func(c *Fetcher) {
+		c.registryMirrorRules = rules
+	}
+}
+
 func WithKeychain(keychain authn.Keychain) FetcherOption {
 	return func(c *Fetcher) {
 		c.keychain = keychain
@@ -56,10 +67,11 @@ type DockerClient interface {
 }
 
 type Fetcher struct {
-	docker          DockerClient
-	logger          logging.Logger
-	registryMirrors map[string]string
-	keychain        authn.Keychain
+	docker              DockerClient
+	logger              logging.Logger
+	registryMirrors     map[string]string
+	registryMirrorRules []RegistryMirrorRule
+	keychain            authn.Keychain
 }
 
 type FetchOptions struct {
@@ -86,11 +98,45 @@ func NewFetcher(logger logging.Logger, docker DockerClient, opts ...FetcherOptio
 var ErrNotFound = errors.New("not found")
 
 func (f *Fetcher) Fetch(ctx context.Context, name string, options FetchOptions) (imgutil.Image, error) {
-	name, err := pname.TranslateRegistry(name, f.registryMirrors, f.logger)
+	// images stored as files are always loaded into the daemon, regardless of the pull policy
+	if IsArchiveReference(name) {
+		return f.fetchArchiveImage(ctx, name, options.Target)
+	}
+
+	candidates, err := pname.MirrorCandidates(name, f.registryMirrorRules)
 	if err != nil {
 		return nil, err
 	}
+	if len(candidates) > 0 {
+		return f.fetchFromMirrors(ctx, name, candidates, options)
+	}
+
+	name, err = pname.TranslateRegistry(name, f.registryMirrors, f.logger)
+	if err != nil {
+		return nil, err
+	}
+	return f.fetch(ctx, name, options)
+}
+
+// fetchFromMirrors fetches the first of candidates that can be fetched, in order.
+func (f *Fetcher) fetchFromMirrors(ctx context.Context, name string, candidates []string, options FetchOptions) (imgutil.Image, error) {
+	var err error
+	for i, candidate := range candidates {
+		f.logger.Infof("Using mirror %s for %s", style.Symbol(candidate), name)
+
+		var img imgutil.Image
+		if img, err = f.fetch(ctx, candidate, options); err == nil {
+			return img, nil
+		}
+		if i < len(candidates)-1 {
+			f.logger.Warnf("Unable to fetch %s from mirror %s, trying the next mirror: %s", name, style.Symbol(candidate), err)
+		}
+	}
+	return nil, err
+}
 
+func (f *Fetcher) fetch(ctx context.Context, name string, options FetchOptions) (imgutil.Image, error) {
+	var err error
 	if (options.LayoutOption != LayoutOption{}) {
 		return f.fetchLayoutImage(name, options.LayoutOption)
 	}
@@ -16,6 +16,11 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-containerregistry/pkg/authn"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	ggcrlayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
 	"github.com/heroku/color"
 	"github.com/pkg/errors"
 	"github.com/sclevine/spec"
@@ -388,6 +393,26 @@ func testFetcher(t *testing.T, when spec.G, it spec.S) {
 			})
 		})
 
+		when("registry mirror rules match the image", func() {
+			it.Before(func() {
+				img, err := remote.NewImage(repoName, authn.DefaultKeychain)
+				h.AssertNil(t, err)
+				h.AssertNil(t, img.Save())
+
+				imageFetcher = image.NewFetcher(logging.NewLogWithWriters(&outBuf, &outBuf), docker, image.WithRegistryMirrorRules([]image.RegistryMirrorRule{{
+					Match:   "mirrored.example.com/some-org/*",
+					Mirrors: []string{registryConfig.RepoName("missing-org/*"), registryConfig.RepoName("some-org/*")},
+				}}))
+			})
+
+			it("falls back to the next mirror", func() {
+				img, err := imageFetcher.Fetch(context.TODO(), "mirrored.example.com/"+repo, image.FetchOptions{Daemon: false, PullPolicy: image.PullAlways})
+				h.AssertNil(t, err)
+				h.AssertEq(t, img.Name(), repoName)
+				h.AssertContains(t, outBuf.String(), "trying the next mirror")
+			})
+		})
+
 		when("layout option is provided", func() {
 			var (
 				layoutOption image.LayoutOption
@@ -447,6 +472,58 @@ func testFetcher(t *testing.T, when spec.G, it spec.S) {
 				})
 			})
 		})
+
+		when("the image is stored as a file", func() {
+			var (
+				tmpDir string
+				img    v1.Image
+			)
+
+			it.Before(func() {
+				var err error
+				tmpDir, err = os.MkdirTemp("", "pack.fetcher.archive.test")
+				h.AssertNil(t, err)
+
+				img, err = random.Image(1024, 1)
+				h.AssertNil(t, err)
+			})
+
+			it.After(func() {
+				h.AssertNil(t, os.RemoveAll(tmpDir))
+			})
+
+			it("loads an OCI layout into the daemon", func() {
+				layoutPath := filepath.Join(tmpDir, "layout")
+				_, err := ggcrlayout.Write(layoutPath, empty.Index)
+				h.AssertNil(t, err)
+				p, err := ggcrlayout.FromPath(layoutPath)
+				h.AssertNil(t, err)
+				h.AssertNil(t, p.AppendImage(img))
+
+				loaded, err := imageFetcher.Fetch(context.TODO(), image.OCILayoutPrefix+layoutPath, image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+				h.AssertNil(t, err)
+				defer h.DockerRmi(docker, loaded.Name())
+
+				h.AssertContains(t, loaded.Name(), "pack.local/archive/")
+				h.AssertEq(t, loaded.Found(), true)
+			})
+
+			it("loads a docker archive into the daemon", func() {
+				archivePath := filepath.Join(tmpDir, "image.tar")
+				h.AssertNil(t, tarball.WriteToFile(archivePath, nil, img))
+
+				loaded, err := imageFetcher.Fetch(context.TODO(), image.DockerArchivePrefix+archivePath, image.FetchOptions{Daemon: true, PullPolicy: image.PullNever})
+				h.AssertNil(t, err)
+				defer h.DockerRmi(docker, loaded.Name())
+
+				h.AssertEq(t, loaded.Found(), true)
+			})
+
+			it("errors when the file does not exist", func() {
+				_, err := imageFetcher.Fetch(context.TODO(), image.DockerArchivePrefix+filepath.Join(tmpDir, "missing.tar"), image.FetchOptions{Daemon: true})
+				h.AssertError(t, err, "reading docker archive")
+			})
+		})
 	})
 
 	when("#CheckReadAccess", func() {
@@ -0,0 +1,23 @@
+package image
+
+import (
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+)
+
+// ListTags lists the tags of an image repository in the registry.
+func (f *Fetcher) ListTags(repository string) ([]string, error) {
+	repo, err := name.NewRepository(repository, name.WeakValidation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing repository %s", style.Symbol(repository))
+	}
+
+	tags, err := remote.List(repo, remote.WithAuthFromKeychain(f.keychain))
+	if err != nil {
+		return nil, errors.Wrapf(err, "listing tags of %s", style.Symbol(repository))
+	}
+	return tags, nil
+}
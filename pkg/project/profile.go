@@ -0,0 +1,87 @@
+package project
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/pack/internal/style"
+	"github.com/buildpacks/pack/pkg/project/types"
+)
+
+// applyProfile merges the build settings of the named profile into the build settings of descriptor:
+//   - builder, include/exclude and the buildpack group are replaced when the profile sets them
+//   - env vars and secrets are added, replacing the ones with the same name
+//   - pre and post group buildpacks are appended
+func applyProfile(descriptor types.Descriptor, name string) (types.Descriptor, error) {
+	profile, ok := descriptor.Profiles[name]
+	if !ok {
+		return types.Descriptor{}, errors.Errorf("project.toml: profile %s is not defined%s", style.Symbol(name), availableProfiles(descriptor))
+	}
+
+	build := descriptor.Build
+	if profile.Builder != "" {
+		build.Builder = profile.Builder
+	}
+	if profile.Include != nil || profile.Exclude != nil {
+		build.Include = profile.Include
+		build.Exclude = profile.Exclude
+	}
+	if profile.Buildpacks != nil {
+		build.Buildpacks = profile.Buildpacks
+	}
+
+	var env []types.EnvVar
+	for _, envVar := range build.Env {
+		if !containsEnvVar(profile.Env, envVar.Name) {
+			env = append(env, envVar)
+		}
+	}
+	build.Env = append(env, profile.Env...)
+
+	var secrets []types.Secret
+	for _, secret := range build.Secrets {
+		if !containsSecret(profile.Secrets, secret.Name) {
+			secrets = append(secrets, secret)
+		}
+	}
+	build.Secrets = append(secrets, profile.Secrets...)
+
+	build.Pre.Buildpacks = append(append([]types.Buildpack{}, build.Pre.Buildpacks...), profile.Pre.Buildpacks...)
+	build.Post.Buildpacks = append(append([]types.Buildpack{}, build.Post.Buildpacks...), profile.Post.Buildpacks...)
+
+	descriptor.Build = build
+	return descriptor, nil
+}
+
+func availableProfiles(descriptor types.Descriptor) string {
+	if len(descriptor.Profiles) == 0 {
+		return ", no profiles are defined"
+	}
+
+	var names []string
+	for name := range descriptor.Profiles {
+		names = append(names, style.Symbol(name))
+	}
+	sort.Strings(names)
+	return ", available profiles are " + strings.Join(names, ", ")
+}
+
+func containsEnvVar(env []types.EnvVar, name string) bool {
+	for _, envVar := range env {
+		if envVar.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSecret(secrets []types.Secret, name string) bool {
+	for _, secret := range secrets {
+		if secret.Name == name {
+			return true
+		}
+	}
+	return false
+}
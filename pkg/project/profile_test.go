@@ -0,0 +1,117 @@
+package project
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/heroku/color"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project/types"
+	h "github.com/buildpacks/pack/testhelpers"
+)
+
+func TestProfile(t *testing.T) {
+	color.Disable(true)
+	defer color.Disable(false)
+
+	spec.Run(t, "Profile", testProfile, spec.Parallel(), spec.Report(report.Terminal{}))
+}
+
+func testProfile(t *testing.T, when spec.G, it spec.S) {
+	const projectToml = `
+[_]
+schema-version = "0.2"
+
+[io.buildpacks]
+builder = "example/builder:base"
+include = ["src"]
+
+[[io.buildpacks.group]]
+id = "example/java"
+
+[[io.buildpacks.build.env]]
+name = "JAVA_OPTS"
+value = "-Xmx300m"
+
+[[io.buildpacks.build.env]]
+name = "BP_LOG_LEVEL"
+value = "INFO"
+
+[[io.buildpacks.post.group]]
+id = "example/procfile"
+
+[io.buildpacks.profiles.dev]
+[[io.buildpacks.profiles.dev.build.env]]
+name = "BP_LOG_LEVEL"
+value = "DEBUG"
+
+[[io.buildpacks.profiles.dev.post.group]]
+id = "example/debug"
+
+[io.buildpacks.profiles.prod]
+builder = "example/builder:full"
+exclude = ["*.md"]
+
+[[io.buildpacks.profiles.prod.group]]
+id = "example/java-native"
+`
+
+	var (
+		logger      logging.Logger
+		projectPath string
+	)
+
+	it.Before(func() {
+		logger = logging.NewSimpleLogger(io.Discard)
+		file, err := createTmpProjectTomlFile(projectToml)
+		h.AssertNil(t, err)
+		projectPath = file.Name()
+	})
+
+	it.After(func() {
+		h.AssertNil(t, os.Remove(projectPath))
+	})
+
+	when("#ReadProjectDescriptorWithProfile", func() {
+		it("uses the build settings when no profile is selected", func() {
+			descriptor, err := ReadProjectDescriptorWithProfile(projectPath, "", logger)
+			h.AssertNil(t, err)
+			h.AssertEq(t, descriptor.Build.Builder, "example/builder:base")
+			h.AssertEq(t, len(descriptor.Profiles), 2)
+		})
+
+		it("extends env and the post group with the dev profile", func() {
+			descriptor, err := ReadProjectDescriptorWithProfile(projectPath, "dev", logger)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, descriptor.Build.Builder, "example/builder:base")
+			h.AssertEq(t, descriptor.Build.Include, []string{"src"})
+			h.AssertEq(t, descriptor.Build.Buildpacks, []types.Buildpack{{ID: "example/java"}})
+			h.AssertEq(t, descriptor.Build.Env, []types.EnvVar{
+				{Name: "JAVA_OPTS", Value: "-Xmx300m"},
+				{Name: "BP_LOG_LEVEL", Value: "DEBUG"},
+			})
+			h.AssertEq(t, descriptor.Build.Post.Buildpacks, []types.Buildpack{{ID: "example/procfile"}, {ID: "example/debug"}})
+		})
+
+		it("overrides the builder, group and include/exclude with the prod profile", func() {
+			descriptor, err := ReadProjectDescriptorWithProfile(projectPath, "prod", logger)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, descriptor.Build.Builder, "example/builder:full")
+			h.AssertEq(t, len(descriptor.Build.Include), 0)
+			h.AssertEq(t, descriptor.Build.Exclude, []string{"*.md"})
+			h.AssertEq(t, descriptor.Build.Buildpacks, []types.Buildpack{{ID: "example/java-native"}})
+			h.AssertEq(t, descriptor.Build.Post.Buildpacks, []types.Buildpack{{ID: "example/procfile"}})
+		})
+
+		it("errors when the profile isn't defined", func() {
+			_, err := ReadProjectDescriptorWithProfile(projectPath, "staging", logger)
+			h.AssertError(t, err, "project.toml: profile 'staging' is not defined, available profiles are 'dev', 'prod'")
+		})
+	})
+}
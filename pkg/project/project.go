@@ -9,10 +9,12 @@ import (
 	"github.com/BurntSushi/toml"
 	"github.com/pkg/errors"
 
+	"github.com/buildpacks/pack/internal/style"
 	"github.com/buildpacks/pack/pkg/logging"
 	"github.com/buildpacks/pack/pkg/project/types"
 	v01 "github.com/buildpacks/pack/pkg/project/v01"
 	v02 "github.com/buildpacks/pack/pkg/project/v02"
+	v03 "github.com/buildpacks/pack/pkg/project/v03"
 )
 
 type Project struct {
@@ -26,9 +28,16 @@ type VersionDescriptor struct {
 var parsers = map[string]func(string) (types.Descriptor, toml.MetaData, error){
 	"0.1": v01.NewDescriptor,
 	"0.2": v02.NewDescriptor,
+	"0.3": v03.NewDescriptor,
 }
 
 func ReadProjectDescriptor(pathToFile string, logger logging.Logger) (types.Descriptor, error) {
+	return ReadProjectDescriptorWithProfile(pathToFile, "", logger)
+}
+
+// ReadProjectDescriptorWithProfile reads the project descriptor at pathToFile and, when profile isn't empty,
+// merges the build settings of the profile declared as `[io.buildpacks.profiles.<profile>]` into its build settings.
+func ReadProjectDescriptorWithProfile(pathToFile string, profile string, logger logging.Logger) (types.Descriptor, error) {
 	projectTomlContents, err := os.ReadFile(filepath.Clean(pathToFile))
 	if err != nil {
 		return types.Descriptor{}, err
@@ -62,6 +71,12 @@ func ReadProjectDescriptor(pathToFile string, logger logging.Logger) (types.Desc
 
 	warnIfTomlContainsKeysNotSupportedBySchema(version, tomlMetaData, logger)
 
+	if profile != "" {
+		if descriptor, err = applyProfile(descriptor, profile); err != nil {
+			return types.Descriptor{}, err
+		}
+	}
+
 	return descriptor, validate(descriptor)
 }
 
@@ -88,7 +103,7 @@ func unsupportedKey(keyName, schemaVersion string) bool {
 	if schemaVersion == "0.1" {
 		// filter out any keys from [metadata] and any other custom table defined by end-users
 		return strings.HasPrefix(keyName, "project.") || strings.HasPrefix(keyName, "build.") || strings.Contains(keyName, "io.buildpacks")
-	} else if schemaVersion == "0.2" {
+	} else if schemaVersion == "0.2" || schemaVersion == "0.3" {
 		// filter out any keys from [_.metadata] and any other custom table defined by end-users
 		return strings.Contains(keyName, "io.buildpacks") || (strings.HasPrefix(keyName, "_.") && !strings.HasPrefix(keyName, "_.metadata"))
 	}
@@ -117,5 +132,57 @@ func validate(p types.Descriptor) error {
 		}
 	}
 
+	for _, envVar := range p.Build.Env {
+		if err := validateExecEnv("env var", envVar.Name, envVar.ExecEnv); err != nil {
+			return err
+		}
+	}
+
+	secrets := map[string]bool{}
+	for _, secret := range p.Build.Secrets {
+		if secret.Name == "" {
+			return errors.New("project.toml: secrets must have a name defined")
+		}
+		if (secret.File == "") == (secret.Env == "") {
+			return errors.Errorf("project.toml: secret %s must have exactly one of file or env defined", style.Symbol(secret.Name))
+		}
+		if secrets[secret.Name] {
+			return errors.Errorf("project.toml: secret %s is defined more than once", style.Symbol(secret.Name))
+		}
+		secrets[secret.Name] = true
+
+		if err := validateExecEnv("secret", secret.Name, secret.ExecEnv); err != nil {
+			return err
+		}
+	}
+
+	defaultProcess := ""
+	for _, process := range p.Run.Processes {
+		if process.Type == "" {
+			return errors.New("project.toml: processes must have a type defined")
+		}
+		if process.Default {
+			if defaultProcess != "" {
+				return errors.Errorf("project.toml: processes %s and %s cannot both be the default", style.Symbol(defaultProcess), style.Symbol(process.Type))
+			}
+			defaultProcess = process.Type
+		}
+	}
+
+	return nil
+}
+
+func validateExecEnv(kind, name string, execEnvs []string) error {
+	for _, execEnv := range execEnvs {
+		switch execEnv {
+		case types.ExecEnvBuild, types.ExecEnvLaunch, types.ExecEnvTest:
+		default:
+			return errors.Errorf(
+				"project.toml: %s %s has unknown exec-env %s, must be one of %s, %s or %s",
+				kind, style.Symbol(name), style.Symbol(execEnv),
+				style.Symbol(types.ExecEnvBuild), style.Symbol(types.ExecEnvLaunch), style.Symbol(types.ExecEnvTest),
+			)
+		}
+	}
 	return nil
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/sclevine/spec/report"
 
 	"github.com/buildpacks/pack/pkg/logging"
+	"github.com/buildpacks/pack/pkg/project/types"
 	h "github.com/buildpacks/pack/testhelpers"
 )
 
@@ -142,6 +143,172 @@ value = "this-should-get-overridden-because-its-deprecated"
 					expected, projectDescriptor.Metadata["pipeline"])
 			}
 		})
+		it("should parse a valid v0.3 project.toml file", func() {
+			projectToml := `
+[_]
+name = "gallant 0.3"
+schema-version = "0.3"
+
+[io.buildpacks]
+builder = "example/builder"
+
+[[io.buildpacks.group]]
+id = "example/java"
+
+[[io.buildpacks.build.env]]
+name = "JAVA_OPTS"
+value = "-Xmx300m"
+
+[[io.buildpacks.build.env]]
+name = "BP_JVM_VERSION"
+value = "21"
+buildpacks = ["example/java"]
+exec-env = ["build", "launch"]
+
+[[io.buildpacks.build.secrets]]
+name = "MAVEN_TOKEN"
+file = "secrets/maven-token"
+buildpacks = ["example/java"]
+
+[[io.buildpacks.build.secrets]]
+name = "NPM_TOKEN"
+env = "CI_NPM_TOKEN"
+exec-env = ["test"]
+
+[[io.buildpacks.run.processes]]
+type = "web"
+command = ["java"]
+args = ["-jar", "app.jar"]
+default = true
+
+[[io.buildpacks.run.processes]]
+type = "worker"
+
+[io.buildpacks.profiles.ci]
+[[io.buildpacks.profiles.ci.build.secrets]]
+name = "NPM_TOKEN"
+env = "GITHUB_NPM_TOKEN"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			h.AssertNil(t, err)
+
+			projectDescriptor, err := ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			h.AssertNil(t, err)
+
+			h.AssertEq(t, projectDescriptor.Project.Name, "gallant 0.3")
+			h.AssertEq(t, projectDescriptor.SchemaVersion, api.MustParse("0.3"))
+			h.AssertEq(t, projectDescriptor.Build.Builder, "example/builder")
+			h.AssertEq(t, projectDescriptor.Build.Buildpacks, []types.Buildpack{{ID: "example/java"}})
+			h.AssertEq(t, projectDescriptor.Build.Env, []types.EnvVar{
+				{Name: "JAVA_OPTS", Value: "-Xmx300m"},
+				{Name: "BP_JVM_VERSION", Value: "21", Buildpacks: []string{"example/java"}, ExecEnv: []string{"build", "launch"}},
+			})
+			h.AssertEq(t, projectDescriptor.Build.Secrets, []types.Secret{
+				{Name: "MAVEN_TOKEN", File: "secrets/maven-token", Buildpacks: []string{"example/java"}},
+				{Name: "NPM_TOKEN", Env: "CI_NPM_TOKEN", ExecEnv: []string{"test"}},
+			})
+			h.AssertEq(t, projectDescriptor.Run.Processes, []types.Process{
+				{Type: "web", Command: []string{"java"}, Args: []string{"-jar", "app.jar"}, Default: true},
+				{Type: "worker"},
+			})
+			h.AssertNotContains(t, readStdout(), "Warning")
+
+			projectDescriptor, err = ReadProjectDescriptorWithProfile(tmpProjectToml.Name(), "ci", logger)
+			h.AssertNil(t, err)
+			h.AssertEq(t, projectDescriptor.Build.Secrets, []types.Secret{
+				{Name: "MAVEN_TOKEN", File: "secrets/maven-token", Buildpacks: []string{"example/java"}},
+				{Name: "NPM_TOKEN", Env: "GITHUB_NPM_TOKEN"},
+			})
+		})
+
+		when("schema v0.3 is invalid", func() {
+			for _, tc := range []struct {
+				name        string
+				projectToml string
+				err         string
+			}{
+				{
+					name: "unknown exec-env",
+					projectToml: `
+[[io.buildpacks.build.env]]
+name = "JAVA_OPTS"
+value = "-Xmx300m"
+exec-env = ["run"]
+`,
+					err: "project.toml: env var 'JAVA_OPTS' has unknown exec-env 'run', must be one of 'build', 'launch' or 'test'",
+				},
+				{
+					name: "secret without a name",
+					projectToml: `
+[[io.buildpacks.build.secrets]]
+env = "TOKEN"
+`,
+					err: "project.toml: secrets must have a name defined",
+				},
+				{
+					name: "secret with both file and env",
+					projectToml: `
+[[io.buildpacks.build.secrets]]
+name = "TOKEN"
+file = "token.txt"
+env = "TOKEN"
+`,
+					err: "project.toml: secret 'TOKEN' must have exactly one of file or env defined",
+				},
+				{
+					name: "secret with neither file nor env",
+					projectToml: `
+[[io.buildpacks.build.secrets]]
+name = "TOKEN"
+`,
+					err: "project.toml: secret 'TOKEN' must have exactly one of file or env defined",
+				},
+				{
+					name: "duplicate secret",
+					projectToml: `
+[[io.buildpacks.build.secrets]]
+name = "TOKEN"
+env = "TOKEN"
+
+[[io.buildpacks.build.secrets]]
+name = "TOKEN"
+file = "token.txt"
+`,
+					err: "project.toml: secret 'TOKEN' is defined more than once",
+				},
+				{
+					name: "process without a type",
+					projectToml: `
+[[io.buildpacks.run.processes]]
+command = ["java"]
+`,
+					err: "project.toml: processes must have a type defined",
+				},
+				{
+					name: "several default processes",
+					projectToml: `
+[[io.buildpacks.run.processes]]
+type = "web"
+default = true
+
+[[io.buildpacks.run.processes]]
+type = "worker"
+default = true
+`,
+					err: "project.toml: processes 'web' and 'worker' cannot both be the default",
+				},
+			} {
+				tc := tc
+				it("should fail for "+tc.name, func() {
+					tmpProjectToml, err := createTmpProjectTomlFile("[_]\nschema-version = \"0.3\"\n" + tc.projectToml)
+					h.AssertNil(t, err)
+
+					_, err = ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+					h.AssertError(t, err, tc.err)
+				})
+			}
+		})
+
 		it("should be backwards compatible with older v0.2 project.toml file", func() {
 			projectToml := `
 [_]
@@ -469,6 +636,59 @@ buzz = ["a", "b", "c"]
 			// Assert we only warn
 			h.AssertContains(t, readStdout(), "Warning: The following keys declared in project.toml are not supported in schema version 0.2:\nWarning: - _.versions\nWarning: - _.licenses.foo\nWarning: - io.buildpacks.build.foo\nWarning: - io.buildpacks.build.foo.name\nWarning: The above keys will be ignored. If this is not intentional, try updating your schema version.\n")
 		})
+
+		it("should warn when schema v0.3 keys are declared with schema v0.2", func() {
+			projectToml := `
+[_]
+schema-version = "0.2"
+
+[[io.buildpacks.build.env]]
+name = "JAVA_OPTS"
+value = "-Xmx300m"
+exec-env = ["launch"]
+
+[[io.buildpacks.build.secrets]]
+name = "TOKEN"
+env = "TOKEN"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			h.AssertNil(t, err)
+
+			_, err = ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			h.AssertNil(t, err)
+			h.AssertContains(t, readStdout(), "Warning: The following keys declared in project.toml are not supported in schema version 0.2:\nWarning: - io.buildpacks.build.env.exec-env\nWarning: - io.buildpacks.build.secrets\nWarning: - io.buildpacks.build.secrets.name\nWarning: - io.buildpacks.build.secrets.env\n")
+		})
+
+		it("should warn when unsupported keys, on tables the project owns, are declared with schema v0.3", func() {
+			projectToml := `
+[_]
+schema-version = "0.3"
+
+# deprecated in schema v0.2 and removed in v0.3 - warning message expected
+[[io.buildpacks.env.build]]
+name = "JAVA_OPTS"
+value = "-Xmx300m"
+
+# invalid key under a valid table - warning message expected
+[[io.buildpacks.run.processes]]
+type = "web"
+working-dir = "/workspace"
+
+# something else defined by end-users - no warning message expected
+[io.docker]
+file = "./Dockerfile"
+
+# some metadata defined the end-user - no warning message expected
+[_.metadata]
+foo = "bar"
+`
+			tmpProjectToml, err := createTmpProjectTomlFile(projectToml)
+			h.AssertNil(t, err)
+
+			_, err = ReadProjectDescriptor(tmpProjectToml.Name(), logger)
+			h.AssertNil(t, err)
+			h.AssertContains(t, readStdout(), "Warning: The following keys declared in project.toml are not supported in schema version 0.3:\nWarning: - io.buildpacks.env.build\nWarning: - io.buildpacks.env.build.name\nWarning: - io.buildpacks.env.build.value\nWarning: - io.buildpacks.run.processes.working-dir\nWarning: The above keys will be ignored. If this is not intentional, try updating your schema version.\n")
+		})
 	})
 }
 
@@ -20,6 +20,38 @@ type Buildpack struct {
 type EnvVar struct {
 	Name  string `toml:"name"`
 	Value string `toml:"value"`
+
+	// IDs of the buildpacks the env var is meant for. Empty means every buildpack.
+	Buildpacks []string `toml:"-"`
+
+	// Execution environments the env var is set in. Empty means the build execution environment.
+	ExecEnv []string `toml:"-"`
+}
+
+// Secret is a build-time value read from a file or from an env var of the platform when building.
+// Its value is never stored in the descriptor.
+type Secret struct {
+	Name string
+	File string
+	Env  string
+
+	// IDs of the buildpacks the secret is meant for. Empty means every buildpack.
+	Buildpacks []string
+
+	// Execution environments the secret is set in. Empty means the build execution environment.
+	ExecEnv []string
+}
+
+// Process overrides a process type of the app image.
+type Process struct {
+	Type    string
+	Command []string
+	Args    []string
+	Default bool
+}
+
+type Run struct {
+	Processes []Process
 }
 
 type Build struct {
@@ -30,6 +62,7 @@ type Build struct {
 	Builder    string      `toml:"builder"`
 	Pre        GroupAddition
 	Post       GroupAddition
+	Secrets    []Secret `toml:"-"`
 }
 
 type Project struct {
@@ -49,8 +82,19 @@ type Descriptor struct {
 	Build         Build                  `toml:"build"`
 	Metadata      map[string]interface{} `toml:"metadata"`
 	SchemaVersion *api.Version
+	Run           Run `toml:"-"`
+
+	// Named build profiles, each overriding or extending Build when selected.
+	Profiles map[string]Build `toml:"-"`
 }
 
+// Execution environments that env vars and secrets can target.
+const (
+	ExecEnvBuild  = "build"
+	ExecEnvLaunch = "launch"
+	ExecEnvTest   = "test"
+)
+
 type GroupAddition struct {
 	Buildpacks []Buildpack `toml:"group"`
 }
@@ -16,6 +16,19 @@ type Buildpacks struct {
 	Builder string              `toml:"builder"`
 	Pre     types.GroupAddition `toml:"pre"`
 	Post    types.GroupAddition `toml:"post"`
+
+	Profiles map[string]Profile `toml:"profiles"`
+}
+
+// Profile is a named set of build settings, declared as `[io.buildpacks.profiles.<name>]`.
+type Profile struct {
+	Include []string            `toml:"include"`
+	Exclude []string            `toml:"exclude"`
+	Group   []types.Buildpack   `toml:"group"`
+	Build   Build               `toml:"build"`
+	Builder string              `toml:"builder"`
+	Pre     types.GroupAddition `toml:"pre"`
+	Post    types.GroupAddition `toml:"post"`
 }
 
 type Build struct {
@@ -56,6 +69,22 @@ func NewDescriptor(projectTomlContents string) (types.Descriptor, toml.MetaData,
 		env = versionedDescriptor.IO.Buildpacks.Env.Build
 	}
 
+	var profiles map[string]types.Build
+	for name, profile := range versionedDescriptor.IO.Buildpacks.Profiles {
+		if profiles == nil {
+			profiles = map[string]types.Build{}
+		}
+		profiles[name] = types.Build{
+			Include:    profile.Include,
+			Exclude:    profile.Exclude,
+			Buildpacks: profile.Group,
+			Env:        profile.Build.Env,
+			Builder:    profile.Builder,
+			Pre:        profile.Pre,
+			Post:       profile.Post,
+		}
+	}
+
 	return types.Descriptor{
 		Project: types.Project{
 			Name:     versionedDescriptor.Project.Name,
@@ -72,5 +101,6 @@ func NewDescriptor(projectTomlContents string) (types.Descriptor, toml.MetaData,
 		},
 		Metadata:      versionedDescriptor.Project.Metadata,
 		SchemaVersion: api.MustParse("0.2"),
+		Profiles:      profiles,
 	}, tomlMetaData, nil
 }
@@ -0,0 +1,162 @@
+// Package v03 parses project descriptors with schema version 0.3. On top of schema 0.2 it adds:
+//   - [[io.buildpacks.build.env]] entries with buildpacks and exec-env, to scope an env var
+//   - [[io.buildpacks.build.secrets]] entries, whose value is read from a file or an env var when building
+//   - [[io.buildpacks.run.processes]] entries, to choose the default process type
+//
+// The deprecated [[io.buildpacks.env.build]] table of schema 0.2 is not supported.
+//
+// pack builds with env vars and secrets set in the build execution environment, and with the default process
+// type of the descriptor. Scoping env vars and secrets to buildpacks or to the launch and test execution
+// environments, and overriding the command or args of a process, are parsed and validated but fail the build,
+// as the lifecycle doesn't support them yet.
+package v03
+
+import (
+	"github.com/BurntSushi/toml"
+	"github.com/buildpacks/lifecycle/api"
+
+	"github.com/buildpacks/pack/pkg/project/types"
+)
+
+type Buildpacks struct {
+	Include []string            `toml:"include"`
+	Exclude []string            `toml:"exclude"`
+	Group   []types.Buildpack   `toml:"group"`
+	Build   Build               `toml:"build"`
+	Builder string              `toml:"builder"`
+	Pre     types.GroupAddition `toml:"pre"`
+	Post    types.GroupAddition `toml:"post"`
+	Run     Run                 `toml:"run"`
+
+	Profiles map[string]Profile `toml:"profiles"`
+}
+
+// Profile is a named set of build settings, declared as `[io.buildpacks.profiles.<name>]`.
+type Profile struct {
+	Include []string            `toml:"include"`
+	Exclude []string            `toml:"exclude"`
+	Group   []types.Buildpack   `toml:"group"`
+	Build   Build               `toml:"build"`
+	Builder string              `toml:"builder"`
+	Pre     types.GroupAddition `toml:"pre"`
+	Post    types.GroupAddition `toml:"post"`
+}
+
+type Build struct {
+	Env     []EnvVar `toml:"env"`
+	Secrets []Secret `toml:"secrets"`
+}
+
+// EnvVar is a build env var, optionally scoped to buildpacks and execution environments.
+type EnvVar struct {
+	Name       string   `toml:"name"`
+	Value      string   `toml:"value"`
+	Buildpacks []string `toml:"buildpacks"`
+	ExecEnv    []string `toml:"exec-env"`
+}
+
+// Secret references a value read when building from a file within the directory of the descriptor, or from an env
+// var the user allowed with --secret.
+type Secret struct {
+	Name       string   `toml:"name"`
+	File       string   `toml:"file"`
+	Env        string   `toml:"env"`
+	Buildpacks []string `toml:"buildpacks"`
+	ExecEnv    []string `toml:"exec-env"`
+}
+
+type Run struct {
+	Processes []Process `toml:"processes"`
+}
+
+type Process struct {
+	Type    string   `toml:"type"`
+	Command []string `toml:"command"`
+	Args    []string `toml:"args"`
+	Default bool     `toml:"default"`
+}
+
+type Project struct {
+	Name          string                 `toml:"name"`
+	Licenses      []types.License        `toml:"licenses"`
+	Metadata      map[string]interface{} `toml:"metadata"`
+	SchemaVersion string                 `toml:"schema-version"`
+}
+
+type IO struct {
+	Buildpacks Buildpacks `toml:"buildpacks"`
+}
+
+type Descriptor struct {
+	Project Project `toml:"_"`
+	IO      IO      `toml:"io"`
+}
+
+func NewDescriptor(projectTomlContents string) (types.Descriptor, toml.MetaData, error) {
+	versionedDescriptor := &Descriptor{}
+	tomlMetaData, err := toml.Decode(projectTomlContents, &versionedDescriptor)
+	if err != nil {
+		return types.Descriptor{}, tomlMetaData, err
+	}
+
+	buildpacks := versionedDescriptor.IO.Buildpacks
+
+	var profiles map[string]types.Build
+	for name, profile := range buildpacks.Profiles {
+		if profiles == nil {
+			profiles = map[string]types.Build{}
+		}
+		profiles[name] = types.Build{
+			Include:    profile.Include,
+			Exclude:    profile.Exclude,
+			Buildpacks: profile.Group,
+			Env:        toEnvVars(profile.Build.Env),
+			Builder:    profile.Builder,
+			Pre:        profile.Pre,
+			Post:       profile.Post,
+			Secrets:    toSecrets(profile.Build.Secrets),
+		}
+	}
+
+	var processes []types.Process
+	for _, process := range buildpacks.Run.Processes {
+		processes = append(processes, types.Process(process))
+	}
+
+	return types.Descriptor{
+		Project: types.Project{
+			Name:     versionedDescriptor.Project.Name,
+			Licenses: versionedDescriptor.Project.Licenses,
+		},
+		Build: types.Build{
+			Include:    buildpacks.Include,
+			Exclude:    buildpacks.Exclude,
+			Buildpacks: buildpacks.Group,
+			Env:        toEnvVars(buildpacks.Build.Env),
+			Builder:    buildpacks.Builder,
+			Pre:        buildpacks.Pre,
+			Post:       buildpacks.Post,
+			Secrets:    toSecrets(buildpacks.Build.Secrets),
+		},
+		Run:           types.Run{Processes: processes},
+		Metadata:      versionedDescriptor.Project.Metadata,
+		SchemaVersion: api.MustParse("0.3"),
+		Profiles:      profiles,
+	}, tomlMetaData, nil
+}
+
+func toEnvVars(env []EnvVar) []types.EnvVar {
+	var envVars []types.EnvVar
+	for _, envVar := range env {
+		envVars = append(envVars, types.EnvVar(envVar))
+	}
+	return envVars
+}
+
+func toSecrets(secrets []Secret) []types.Secret {
+	var converted []types.Secret
+	for _, secret := range secrets {
+		converted = append(converted, types.Secret(secret))
+	}
+	return converted
+}
@@ -3,9 +3,13 @@ package registry
 const (
 	TypeGit    = "git"
 	TypeGitHub = "github"
+	TypeDir    = "dir"
+	TypeOCI    = "oci"
 )
 
 var Types = []string{
 	TypeGit,
 	TypeGitHub,
+	TypeDir,
+	TypeOCI,
 }